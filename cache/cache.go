@@ -0,0 +1,64 @@
+// Package cache stores the results of checks on disk, so that objects that have not changed since the previous run
+// don't have to be scored again.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/zegl/kube-score/config"
+)
+
+var _ config.ResultCache = (*Dir)(nil)
+
+// Dir is a config.ResultCache where each entry is stored as a separate file in a directory
+type Dir struct {
+	path string
+	salt string
+}
+
+// NewDir returns a cache that stores the entries in path, the directory is created if it does not exist.
+// The salt is part of the name of all entries, and should be changed whenever the checks change, for example by
+// setting it to the version of kube-score.
+func NewDir(path, salt string) (*Dir, error) {
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, err
+	}
+	return &Dir{path: path, salt: salt}, nil
+}
+
+// Get returns the entry for the key, entries that don't exist or can't be read are treated as missing
+func (d *Dir) Get(key string) ([]byte, bool) {
+	value, err := ioutil.ReadFile(d.filename(key))
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// Put stores the entry for the key. The entry is first written to a temporary file, so that a concurrent Get
+// never returns a partially written entry.
+func (d *Dir) Put(key string, value []byte) error {
+	fp, err := ioutil.TempFile(d.path, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := fp.Write(value); err != nil {
+		fp.Close()
+		os.Remove(fp.Name())
+		return err
+	}
+	if err := fp.Close(); err != nil {
+		os.Remove(fp.Name())
+		return err
+	}
+	return os.Rename(fp.Name(), d.filename(key))
+}
+
+func (d *Dir) filename(key string) string {
+	sum := sha256.Sum256([]byte(d.salt + "\x00" + key))
+	return filepath.Join(d.path, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDir(t *testing.T) {
+	path, err := ioutil.TempDir("", "kube-score-cache")
+	assert.Nil(t, err)
+	defer os.RemoveAll(path)
+
+	d, err := NewDir(filepath.Join(path, "nested"), "v1")
+	assert.Nil(t, err)
+
+	_, ok := d.Get("a")
+	assert.False(t, ok)
+
+	assert.Nil(t, d.Put("a", []byte("first")))
+	assert.Nil(t, d.Put("a", []byte("second")))
+	value, ok := d.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "second", string(value))
+
+	// Entries are not shared between salts
+	other, err := NewDir(filepath.Join(path, "nested"), "v2")
+	assert.Nil(t, err)
+	_, ok = other.Get("a")
+	assert.False(t, ok)
+
+	// No temporary files are left behind
+	files, err := ioutil.ReadDir(filepath.Join(path, "nested"))
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	ks "github.com/zegl/kube-score/domain"
+)
+
+func isTarArchive(filename string) bool {
+	return strings.HasSuffix(filename, ".tar") || isGzipTarArchive(filename)
+}
+
+func isGzipTarArchive(filename string) bool {
+	return strings.HasSuffix(filename, ".tar.gz") || strings.HasSuffix(filename, ".tgz")
+}
+
+// isArchive returns true if the file should be extracted before being parsed
+func isArchive(filename string) bool {
+	return isTarArchive(filename) || strings.HasSuffix(filename, ".gz")
+}
+
+// isManifestFile returns true if the file in an archive or a directory should be parsed, manifests can be written as
+// YAML or JSON
+func isManifestFile(filename string) bool {
+	return strings.HasSuffix(filename, ".yaml") || strings.HasSuffix(filename, ".yml") || strings.HasSuffix(filename, ".json")
+}
+
+// readArchive extracts all YAML and JSON files from a (gzipped) tar archive, or decompresses a single gzipped file.
+// The files in a tar archive are named by their path within the archive, and are returned sorted by name.
+func readArchive(r io.Reader, filename string) ([]ks.NamedReader, error) {
+	if strings.HasSuffix(filename, ".gz") || strings.HasSuffix(filename, ".tgz") {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	if !isTarArchive(filename) {
+		content, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		return []ks.NamedReader{
+			namedReader{Reader: bytes.NewReader(content), name: strings.TrimSuffix(filename, ".gz")},
+		}, nil
+	}
+
+	var res []ks.NamedReader
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if header.Typeflag != tar.TypeReg || !isManifestFile(header.Name) {
+			continue
+		}
+
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, namedReader{Reader: bytes.NewReader(content), name: path.Clean(header.Name)})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name() < res[j].Name()
+	})
+
+	return res, nil
+}
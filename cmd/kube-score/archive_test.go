@@ -0,0 +1,74 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadArchiveTarGz(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"manifests/z.yaml", "z"},
+		{"manifests/nested/a.yml", "a"},
+		{"manifests/b.json", "b"},
+		{"manifests/README.md", "readme"},
+	}
+	assert.Nil(t, tw.WriteHeader(&tar.Header{Name: "manifests/", Typeflag: tar.TypeDir, Mode: 0755}))
+	for _, f := range files {
+		assert.Nil(t, tw.WriteHeader(&tar.Header{Name: f.name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(f.content))}))
+		_, err := tw.Write([]byte(f.content))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, tw.Close())
+	assert.Nil(t, gz.Close())
+
+	res, err := readArchive(&buf, "bundle.tar.gz")
+	assert.Nil(t, err)
+	assert.Len(t, res, 3)
+
+	assert.Equal(t, "manifests/b.json", res[0].Name())
+	content, _ := ioutil.ReadAll(res[0])
+	assert.Equal(t, "b", string(content))
+
+	assert.Equal(t, "manifests/nested/a.yml", res[1].Name())
+	content, _ = ioutil.ReadAll(res[1])
+	assert.Equal(t, "a", string(content))
+
+	assert.Equal(t, "manifests/z.yaml", res[2].Name())
+	content, _ = ioutil.ReadAll(res[2])
+	assert.Equal(t, "z", string(content))
+}
+
+func TestReadArchiveGzip(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte("kind: Pod"))
+	assert.Nil(t, err)
+	assert.Nil(t, gz.Close())
+
+	res, err := readArchive(&buf, "pod.yaml.gz")
+	assert.Nil(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, "pod.yaml", res[0].Name())
+	content, _ := ioutil.ReadAll(res[0])
+	assert.Equal(t, "kind: Pod", string(content))
+}
+
+func TestIsArchive(t *testing.T) {
+	assert.True(t, isArchive("a.tar"))
+	assert.True(t, isArchive("a.tar.gz"))
+	assert.True(t, isArchive("a.tgz"))
+	assert.True(t, isArchive("a.yaml.gz"))
+	assert.False(t, isArchive("a.yaml"))
+}
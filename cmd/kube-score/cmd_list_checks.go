@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/zegl/kube-score/config"
+	"github.com/zegl/kube-score/parser"
+	"github.com/zegl/kube-score/score"
+)
+
+type listedCheck struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Comment     string   `json:"comment"`
+	Optional    bool     `json:"optional"`
+	TargetTypes []string `json:"target_types"`
+}
+
+// registeredChecks returns all registered checks, in the order that they are registered, with all the target types
+// of each check
+func registeredChecks() []listedCheck {
+	allChecks := score.RegisterAllChecks(parser.Empty(), config.Configuration{})
+
+	var res []listedCheck
+	for _, c := range allChecks.All() {
+		res = append(res, listedCheck{
+			ID:          c.ID,
+			Name:        c.Name,
+			Comment:     c.Comment,
+			Optional:    c.Optional,
+			TargetTypes: allChecks.TargetTypes(c.ID),
+		})
+	}
+
+	return res
+}
+
+func outputCheckList(w io.Writer, outputFormat string) error {
+	allChecks := registeredChecks()
+
+	switch outputFormat {
+	case "json":
+		j, err := json.MarshalIndent(allChecks, "", "    ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(j))
+		return err
+	case "human":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tNAME\tENABLED\tTARGETS")
+		for _, c := range allChecks {
+			enabled := "default"
+			if c.Optional {
+				enabled = "optional"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", c.ID, c.Name, enabled, strings.Join(c.TargetTypes, ", "))
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("Error: --list-checks only supports the 'human' and 'json' output formats")
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisteredChecksMergesTargetTypes(t *testing.T) {
+	var found bool
+	for _, c := range registeredChecks() {
+		if c.ID == "pvc-storageclass" {
+			found = true
+			assert.Equal(t, []string{"PersistentVolumeClaim", "StatefulSet"}, c.TargetTypes)
+			assert.True(t, c.Optional)
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestOutputCheckListJSON(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, outputCheckList(&buf, "json"))
+
+	var res []listedCheck
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &res))
+	assert.Equal(t, registeredChecks(), res)
+}
+
+func TestOutputCheckListHuman(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, outputCheckList(&buf, "human"))
+	assert.Contains(t, buf.String(), "container-image-tag")
+	assert.Contains(t, buf.String(), "PersistentVolumeClaim, StatefulSet")
+}
+
+func TestOutputCheckListUnsupportedFormat(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NotNil(t, outputCheckList(&buf, "ci"))
+}
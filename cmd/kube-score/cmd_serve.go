@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/logging"
+	"github.com/zegl/kube-score/parser"
+	"github.com/zegl/kube-score/renderer/json_v2"
+	"github.com/zegl/kube-score/score"
+)
+
+func serve(binName string, args []string) error {
+	fs := flag.NewFlagSet(binName, flag.ExitOnError)
+	printHelp := fs.Bool("help", false, "Print help")
+	listen := fs.String("listen", ":8080", "The address that the server listens on")
+	maxRequestBytes := fs.Int64("max-request-bytes", 5<<20, "The largest accepted request body in bytes, larger requests are rejected with 413 Request Entity Too Large")
+	timeout := fs.Duration("timeout", 30*time.Second, "The longest time that reading a request, or writing a response, may take")
+	optionalTests := fs.StringSlice("enable-optional-test", []string{}, "Enable an optional test, can be set multiple times")
+	ignoreTests := fs.StringSlice("ignore-test", []string{}, "Disable a test, can be set multiple times")
+	kubernetesVersion := fs.String("kubernetes-version", "v1.18", "Setting the kubernetes-version will affect the checks ran against the manifests. Set this to the version of Kubernetes that you're using in production for the best results.")
+	logLevel := fs.String("log-level", "info", "Set the level of the logs that are written to STDERR, one of 'debug', 'info', 'warn' or 'error'")
+	setDefault(fs, binName, "serve", false)
+
+	err := fs.Parse(args)
+	if err != nil {
+		return fmt.Errorf("failed to parse flags: %s", err)
+	}
+
+	if *printHelp {
+		fs.Usage()
+		return nil
+	}
+
+	if *maxRequestBytes <= 0 {
+		return errors.New("Invalid --max-request-bytes, must be greater than 0")
+	}
+
+	kubeVer, err := config.ParseSemver(*kubernetesVersion)
+	if err != nil {
+		return errors.New("Invalid --kubernetes-version. Use on format \"vN.NN\"")
+	}
+
+	level, err := logging.ParseLevel(*logLevel)
+	if err != nil {
+		return fmt.Errorf("Invalid --log-level: %w", err)
+	}
+	logger := logging.New(os.Stderr, level)
+
+	cnf := config.Configuration{
+		IgnoredTests:              listToStructMap(ignoreTests),
+		EnabledOptionalTests:      listToStructMap(optionalTests),
+		UseIgnoreChecksAnnotation: true,
+		KubernetesVersion:         kubeVer,
+		Logger:                    logger,
+	}
+
+	server := &http.Server{
+		Addr:              *listen,
+		Handler:           newServeHandler(cnf, *maxRequestBytes),
+		ReadHeaderTimeout: *timeout,
+		ReadTimeout:       *timeout,
+		WriteTimeout:      *timeout,
+		IdleTimeout:       2 * *timeout,
+	}
+
+	// Stop accepting new connections on SIGINT and SIGTERM, and let requests that are in progress finish
+	shutdownDone := make(chan struct{})
+	go func() {
+		defer close(shutdownDone)
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		<-signals
+
+		logger.Info("Shutting down")
+		ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			logger.Error("Failed to shut down gracefully", "error", err)
+		}
+	}()
+
+	logger.Info("Listening", "address", *listen)
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	<-shutdownDone
+	return nil
+}
+
+// newServeHandler returns the handler of the serve command. The base configuration is shared by all requests,
+// and the manifests in the body of each request to /score are scored with it.
+func newServeHandler(cnf config.Configuration, maxRequestBytes int64) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+
+	mux.HandleFunc("/score", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Read one byte more than the limit, to know if the body is too large
+		body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxRequestBytes+1))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read request: %s", err), http.StatusBadRequest)
+			return
+		}
+		if int64(len(body)) > maxRequestBytes {
+			http.Error(w, fmt.Sprintf("request body is larger than %d bytes", maxRequestBytes), http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		requestCnf := cnf
+		requestCnf.AllFiles = []ks.NamedReader{namedReader{Reader: bytes.NewReader(body), name: "request"}}
+
+		parsedFiles, err := parser.ParseFiles(requestCnf)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to parse manifests: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		scoreCard, err := score.Score(parsedFiles, requestCnf)
+		if err != nil {
+			cnf.Logger.Error("Failed to score manifests", "error", err)
+			http.Error(w, fmt.Sprintf("failed to score manifests: %s", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := io.Copy(w, json_v2.Output(scoreCard)); err != nil {
+			cnf.Logger.Warn("Failed to write response", "error", err)
+		}
+	})
+
+	return mux
+}
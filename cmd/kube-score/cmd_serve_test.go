@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zegl/kube-score/config"
+	"github.com/zegl/kube-score/renderer/json_v2"
+)
+
+func TestServeHealthz(t *testing.T) {
+	handler := newServeHandler(config.Configuration{}, 1024)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "ok\n", rec.Body.String())
+}
+
+func TestServeScore(t *testing.T) {
+	handler := newServeHandler(config.Configuration{}, 1024)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/score", strings.NewReader(podWithLatestTag)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var objects []json_v2.ScoredObject
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &objects))
+	assert.Len(t, objects, 1)
+	assert.Equal(t, "pod-test-1", objects[0].ObjectMeta.Name)
+
+	found := false
+	for _, c := range objects[0].Checks {
+		if c.Check.ID == "container-image-tag" {
+			found = true
+			assert.Equal(t, "CRITICAL", c.Grade.String())
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestServeScoreErrors(t *testing.T) {
+	handler := newServeHandler(config.Configuration{}, 64)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/score", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, http.MethodPost, rec.Header().Get("Allow"))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/score", strings.NewReader(podWithLatestTag)))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/score", strings.NewReader("kind: [")))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -12,3 +12,8 @@ var date = "N/A"
 func cmdVersion() {
 	fmt.Printf("kube-score version: %s, commit: %s, built: %s\n", version, commit, date)
 }
+
+// cacheSalt makes sure that results that are cached by one build of kube-score are not used by another
+func cacheSalt() string {
+	return version + "/" + commit
+}
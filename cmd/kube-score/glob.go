@@ -0,0 +1,130 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// hasGlobMeta returns true if the path contains any of the special characters used by filepath.Match
+func hasGlobMeta(path string) bool {
+	return strings.ContainsAny(path, `*?[`)
+}
+
+// expandGlob returns all files that matches the pattern, sorted by name.
+// In addition to the syntax supported by filepath.Match, a "**" path segment matches zero or more directories.
+func expandGlob(pattern string) ([]string, error) {
+	var matches []string
+
+	if !strings.Contains(pattern, "**") {
+		globMatches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range globMatches {
+			if info, err := os.Stat(m); err == nil && !info.IsDir() {
+				matches = append(matches, m)
+			}
+		}
+		sort.Strings(matches)
+		return matches, nil
+	}
+
+	patternSegments := strings.Split(filepath.ToSlash(pattern), "/")
+
+	err := filepath.Walk(globRoot(pattern), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		ok, err := matchSegments(patternSegments, strings.Split(filepath.ToSlash(path), "/"))
+		if err != nil {
+			return err
+		}
+		if ok {
+			matches = append(matches, path)
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// expandDir returns the YAML and JSON files in the directory, sorted by name. The files in subdirectories are not
+// included, use a "**" pattern for them.
+func expandDir(dir string) ([]string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []string
+	for _, entry := range entries {
+		if !entry.IsDir() && isManifestFile(entry.Name()) {
+			matches = append(matches, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return matches, nil
+}
+
+// globRoot returns the longest prefix of the pattern that does not contain any special characters
+func globRoot(pattern string) string {
+	patternSegments := strings.Split(filepath.ToSlash(pattern), "/")
+
+	var rootSegments []string
+	for _, segment := range patternSegments[:len(patternSegments)-1] {
+		if hasGlobMeta(segment) {
+			break
+		}
+		rootSegments = append(rootSegments, segment)
+	}
+	root := filepath.FromSlash(strings.Join(rootSegments, "/"))
+	if len(rootSegments) == 1 && rootSegments[0] == "" {
+		root = string(filepath.Separator)
+	} else if root == "" {
+		root = "."
+	}
+	return root
+}
+
+// matchSegments matches a path against a pattern, both split into segments by "/"
+func matchSegments(pattern, path []string) (bool, error) {
+	// Paths walked from "." does not have the "./" prefix
+	if len(pattern) > 0 && pattern[0] == "." && (len(path) == 0 || path[0] != ".") {
+		pattern = pattern[1:]
+	}
+
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			// Try to let "**" match zero or more segments
+			for i := 0; i <= len(path); i++ {
+				if ok, err := matchSegments(pattern[1:], path[i:]); ok || err != nil {
+					return ok, err
+				}
+			}
+			return false, nil
+		}
+
+		if len(path) == 0 {
+			return false, nil
+		}
+
+		ok, err := filepath.Match(pattern[0], path[0])
+		if err != nil || !ok {
+			return false, err
+		}
+
+		pattern = pattern[1:]
+		path = path[1:]
+	}
+
+	return len(path) == 0, nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandGlob(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kube-score-glob")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, f := range []string{"a.yaml", "b.yml", "sub/c.yaml", "sub/deep/d.yaml", "sub/deep/e.txt"} {
+		p := filepath.Join(dir, filepath.FromSlash(f))
+		assert.Nil(t, os.MkdirAll(filepath.Dir(p), 0755))
+		assert.Nil(t, ioutil.WriteFile(p, []byte{}, 0644))
+	}
+
+	join := func(paths ...string) (res []string) {
+		for _, p := range paths {
+			res = append(res, filepath.Join(dir, filepath.FromSlash(p)))
+		}
+		return
+	}
+
+	matches, err := expandGlob(filepath.Join(dir, "*.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, join("a.yaml"), matches)
+
+	matches, err = expandGlob(filepath.Join(dir, "**", "*.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, join("a.yaml", "sub/c.yaml", "sub/deep/d.yaml"), matches)
+
+	matches, err = expandGlob(filepath.Join(dir, "sub", "**"))
+	assert.Nil(t, err)
+	assert.Equal(t, join("sub/c.yaml", "sub/deep/d.yaml", "sub/deep/e.txt"), matches)
+
+	matches, err = expandGlob(filepath.Join(dir, "**", "*.json"))
+	assert.Nil(t, err)
+	assert.Empty(t, matches)
+
+	matches, err = expandGlob(filepath.Join(dir, "missing", "**", "*.yaml"))
+	assert.Nil(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestExpandInputsDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kube-score-dir")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, f := range []string{"a.yaml", "b.yml", "c.json", "notes.txt", "sub/d.yaml"} {
+		p := filepath.Join(dir, filepath.FromSlash(f))
+		assert.Nil(t, os.MkdirAll(filepath.Dir(p), 0755))
+		assert.Nil(t, ioutil.WriteFile(p, []byte{}, 0644))
+	}
+
+	files, err := expandInputs([]string{dir}, false)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "a.yaml"), filepath.Join(dir, "b.yml"), filepath.Join(dir, "c.json")}, files)
+
+	empty := filepath.Join(dir, "empty")
+	assert.Nil(t, os.Mkdir(empty, 0755))
+	_, err = expandInputs([]string{empty}, false)
+	assert.NotNil(t, err)
+	files, err = expandInputs([]string{empty}, true)
+	assert.Nil(t, err)
+	assert.Empty(t, files)
+}
+
+func TestMatchSegments(t *testing.T) {
+	ok, err := matchSegments([]string{"a", "**", "*.yaml"}, []string{"a", "b.yaml"})
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, err = matchSegments([]string{".", "**", "*.yaml"}, []string{"a", "b", "c.yaml"})
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, err = matchSegments([]string{"a", "**", "*.yaml"}, []string{"b", "c.yaml"})
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
@@ -10,18 +10,29 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	flag "github.com/spf13/pflag"
 	"golang.org/x/crypto/ssh/terminal"
+	"k8s.io/apimachinery/pkg/api/resource"
 
+	"github.com/zegl/kube-score/cache"
 	"github.com/zegl/kube-score/config"
 	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/logging"
 	"github.com/zegl/kube-score/parser"
+	"github.com/zegl/kube-score/policy"
 	"github.com/zegl/kube-score/renderer/ci"
 	"github.com/zegl/kube-score/renderer/human"
 	"github.com/zegl/kube-score/renderer/json_v2"
+	"github.com/zegl/kube-score/renderer/json_v3"
 	"github.com/zegl/kube-score/renderer/sarif"
 	"github.com/zegl/kube-score/score"
+	"github.com/zegl/kube-score/score/apps"
+	"github.com/zegl/kube-score/score/meta"
 	"github.com/zegl/kube-score/scorecard"
 )
 
@@ -39,6 +50,13 @@ func main() {
 			}
 		},
 
+		"serve": func(helpName string, args []string) {
+			if err := serve(helpName, args); err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "Failed to serve: %v", err)
+				os.Exit(1)
+			}
+		},
+
 		"list": func(helpName string, args []string) {
 			listChecks(helpName, args)
 		},
@@ -74,6 +92,7 @@ func setDefault(fs *flag.FlagSet, binName, actionName string, displayForMoreInfo
 
 Actions:
 	score	Checks all files in the input, and gives them a score and recommendations
+	serve	Starts a HTTP server that scores the manifests that are posted to it
 	list	Prints a CSV list of all available score checks
 	version	Print the version of kube-score
 	help	Print this message`+"\n\n", binName, binName)
@@ -97,16 +116,51 @@ Actions:
 func scoreFiles(binName string, args []string) error {
 	fs := flag.NewFlagSet(binName, flag.ExitOnError)
 	exitOneOnWarning := fs.Bool("exit-one-on-warning", false, "Exit with code 1 in case of warnings")
+	strict := fs.Bool("strict", false, "Exit with code 1 if any check is graded as warning or critical, without changing the grades or the output. The same as --exit-one-on-warning")
 	ignoreContainerCpuLimit := fs.Bool("ignore-container-cpu-limit", false, "Disables the requirement of setting a container CPU limit")
 	ignoreContainerMemoryLimit := fs.Bool("ignore-container-memory-limit", false, "Disables the requirement of setting a container memory limit")
+	minCPURequest := fs.String("min-cpu-request", "", "The container-resources check warns about containers with a lower CPU request than this, for example '10m'. Disabled by default")
+	minMemoryRequest := fs.String("min-memory-request", "", "The container-resources check warns about containers with a lower memory request than this, for example '16Mi'. Disabled by default")
+	explain := fs.Bool("explain", false, "Print why each failing check matters, and how it's usually fixed. The 'json', 'jsonl' and 'sarif' output formats include the same text in a separate field.")
+	maxSurgePercentage := fs.Int("max-surge-percentage", apps.DefaultMaxSurgePercentage, "The deployment-maxsurge-footprint check warns about Deployments with a maxSurge that is larger than this percentage of the replicas")
+	maxLimitRequestRatio := fs.Float64("max-limit-request-ratio", 0, "The container-resources check warns about containers with a CPU or memory limit that is more than this many times larger than the request. Disabled if set to 0")
 	verboseOutput := fs.CountP("verbose", "v", "Enable verbose output, can be set multiple times for increased verbosity.")
 	printHelp := fs.Bool("help", false, "Print help")
-	outputFormat := fs.StringP("output-format", "o", "human", "Set to 'human', 'json' or 'ci'. If set to ci, kube-score will output the program in a format that is easier to parse by other programs.")
-	outputVersion := fs.String("output-version", "", "Changes the version of the --output-format. The 'json' format has version 'v2' (default) and 'v1' (deprecated, will be removed in v1.7.0). The 'human' and 'ci' formats has only version 'v1' (default). If not explicitly set, the default version for that particular output format will be used.")
+	outputFormat := fs.StringP("output-format", "o", "human", "Set to 'human', 'json', 'jsonl' or 'ci'. If set to ci, kube-score will output the program in a format that is easier to parse by other programs. If set to jsonl, each object is written as a single line of JSON as soon as it has been scored.")
+	outputVersion := fs.String("output-version", "", "Changes the version of the --output-format. The 'json' format has version 'v2' (default), 'v3' (v2 wrapped together with metadata about the run) and 'v1' (deprecated, will be removed in v1.7.0). The 'human', 'jsonl', 'sarif' and 'ci' formats has only version 'v1' (default). If not explicitly set, the default version for that particular output format will be used. Unsupported versions are an error.")
 	optionalTests := fs.StringSlice("enable-optional-test", []string{}, "Enable an optional test, can be set multiple times")
 	ignoreTests := fs.StringSlice("ignore-test", []string{}, "Disable a test, can be set multiple times")
 	disableIgnoreChecksAnnotation := fs.Bool("disable-ignore-checks-annotations", false, "Set to true to disable the effect of the 'kube-score/ignore' annotations")
+	recommendedLabels := fs.StringSlice("recommended-label", []string{}, "Set the labels required by the object-recommended-labels check, can be set multiple times. Labels without a prefix are prefixed with app.kubernetes.io/. Defaults to name, instance, version, component, part-of and managed-by")
+	mutableImageTags := fs.StringSlice("mutable-image-tag", []string{}, "Set the tags that are considered to be mutable by the container-image-mutable-tag check, as regular expressions that must match the whole tag, can be set multiple times. The latest tag is always mutable. Defaults to stable, edge, main, master, develop, dev, nightly, canary, beta, alpha, lts, current, release and bare major versions")
+	maxAnnotationBytes := fs.Int("max-annotation-bytes", meta.DefaultMaxAnnotationBytes, "The object-metadata-size check warns about objects where the combined size of the keys and values of all annotations is larger than this number of bytes")
+	maxLabels := fs.Int("max-labels", meta.DefaultMaxLabels, "The object-metadata-size check warns about objects with more labels than this")
+	rwxStorageClasses := fs.StringSlice("rwx-storage-class", []string{}, "Set the StorageClasses that support the ReadWriteMany access mode in the statefulset-volumeclaim-accessmodes check, as regular expressions that must match the whole name of the StorageClass, can be set multiple times. Defaults to StorageClasses with nfs, efs, azurefile, azure-file, filestore, cephfs, glusterfs or rwx in the name")
+	topologyKeys := fs.StringSlice("topology-key", []string{}, "Allow a custom node label as topologyKey in the pod-affinity-topologykey check, in addition to the well-known labels such as kubernetes.io/hostname and topology.kubernetes.io/zone. Can be set multiple times")
+	logLevel := fs.String("log-level", "warn", "Set the level of the logs that are written to STDERR, one of 'debug', 'info', 'warn' or 'error'")
+	minGrade := fs.String("min-grade", "", "Only include checks that are graded as this or worse in the output, one of 'critical', 'warning' or 'ok'. Skipped checks are only included if --include-skipped is set. The exit code is still based on all checks. Includes all checks by default")
+	includeSkipped := fs.Bool("include-skipped", false, "Include all checks that are not enabled in the output as skipped, together with the reason that they were skipped. Skipped checks are always included in the 'json' and 'ci' output formats, and only with -vv in the 'human' output format.")
 	kubernetesVersion := fs.String("kubernetes-version", "v1.18", "Setting the kubernetes-version will affect the checks ran against the manifests. Set this to the version of Kubernetes that you're using in production for the best results.")
+	severityOverrides := fs.StringSlice("severity", []string{}, "Cap the most severe grade that a check can report, on the format check-id=grade where grade is one of 'critical', 'warning' or 'ok'. Can be set multiple times")
+	severityPolicyFile := fs.String("severity-policy", "", "Cap the most severe grade that a check can report depending on the namespace and labels of each object, with the rules in this YAML file. Rules that match an object take precedence over --severity. See the README for the format")
+	strictUnknown := fs.Bool("strict-unknown", false, "Grade objects with an apiVersion or kind that is not known to Kubernetes, such as a misspelled kind, as critical instead of warning")
+	only := fs.StringSlice("only", []string{}, "Only run the check with this ID, all other checks are skipped. The check is run even if it's optional or ignored. Can be set multiple times")
+	failOn := fs.StringSlice("fail-on", []string{}, "Only exit with code 1 if the check with this ID is not graded as OK, other failing checks are ignored when deciding the exit code. Can be set multiple times")
+	noSort := fs.Bool("no-sort", false, "Print each object as soon as it has been scored, in the order that they are defined in the input, instead of sorting the output. Only affects the 'human' output format.")
+	allowEmptyGlob := fs.Bool("allow-empty-glob", false, "Do not fail if a glob pattern or a directory in the file arguments does not match any files")
+	groupBy := fs.String("group-by", "", "Group the objects in the output. Can be set to 'namespace', in which case the objects are listed under their namespace together with a summary per namespace, which only affects the 'human' output format. Can be set to 'check', in which case each failed check is listed together with the number of objects that failed it and their grades, with the most failed check first, which is supported by the 'human' and 'json' output formats.")
+	summaryOnly := fs.Bool("summary-only", false, "Only print the worst grade of each object, followed by the number of objects per grade. Supported by the 'human' and 'json' output formats, where the 'json' output is an array of objects with their worst grade.")
+	headers := fs.StringSlice("header", []string{}, "Set a header on the format 'Name: value' in the requests to the URLs given as file arguments, such as an Authorization header. Can be set multiple times")
+	urlTimeout := fs.Duration("url-timeout", 30*time.Second, "The longest time that fetching a URL given as a file argument may take")
+	insecureSkipTLSVerify := fs.Bool("insecure-skip-tls-verify", false, "Don't verify the TLS certificates of the URLs given as file arguments")
+	reportObjects := fs.Bool("report-objects", false, "Instead of the results of the checks, list all objects that were read together with the file that they were read from and the number of checks that ran against them, to find objects that are not scored. Supported by the 'human' and 'json' output formats")
+	watch := fs.Bool("watch", false, "Score the files again each time that they are changed, until kube-score is stopped. The terminal is cleared before each run with the 'human' output format, other formats are written again after each change. Can not be used when reading from STDIN")
+	printTimings := fs.Bool("timing", false, "Measure the time spent in each check, and print a summary to STDERR when all files have been scored")
+	outputDir := fs.String("output-dir", "", "Write the result of each object to a separate file in this directory, instead of writing all results to STDOUT. The files are named <namespace>_<kind>_<name>, where characters other than letters, digits, ., _ and - are replaced with _, and the directory is created if it does not exist.")
+	cacheDir := fs.String("cache-dir", "", "Cache the results of the checks in this directory, so that objects that have not changed since the previous run don't have to be scored again. Checks that depend on other objects are never cached. Disabled by default")
+	manifestFormat := fs.String("manifest-format", "auto", "The format of the files that are scored, one of 'auto', 'yaml' or 'json'. JSON files can contain a single object, top-level arrays of objects or newline delimited JSON. If set to auto, files with a .json extension are parsed as JSON and all other files as YAML")
+	mergeSameIdentity := fs.Bool("merge-same-identity", false, "Merge objects with the same apiVersion, kind, namespace and name into a single object before they are scored, instead of scoring each of them. The objects are strategic merge patched in the order that they are read, so later files take precedence")
+	printChecks := fs.Bool("list-checks", false, "List all available checks, and exit. Supports the 'human' and 'json' output formats.")
 	setDefault(fs, binName, "score", false)
 
 	err := fs.Parse(args)
@@ -119,9 +173,54 @@ func scoreFiles(binName string, args []string) error {
 		return nil
 	}
 
-	if *outputFormat != "human" && *outputFormat != "ci" && *outputFormat != "json" && *outputFormat != "sarif" {
+	if *outputFormat != "human" && *outputFormat != "ci" && *outputFormat != "json" && *outputFormat != "jsonl" && *outputFormat != "sarif" {
+		fs.Usage()
+		return fmt.Errorf("Error: --output-format must be set to: 'human', 'json', 'jsonl', 'sarif' or 'ci'")
+	}
+
+	version := getOutputVersion(*outputVersion, *outputFormat)
+	if err := validateOutputVersion(*outputFormat, version); err != nil {
+		fs.Usage()
+		return err
+	}
+
+	if *groupBy != "" && *groupBy != "namespace" && *groupBy != "check" {
+		fs.Usage()
+		return fmt.Errorf("Error: --group-by must be set to: 'namespace' or 'check'")
+	}
+
+	if *groupBy == "check" && *outputFormat != "human" && *outputFormat != "json" {
+		fs.Usage()
+		return fmt.Errorf("Error: --group-by check is only supported by the 'human' and 'json' output formats")
+	}
+
+	if *groupBy == "check" && (*summaryOnly || *reportObjects || *outputDir != "") {
+		fs.Usage()
+		return fmt.Errorf("Error: --group-by check can not be used together with --summary-only, --report-objects or --output-dir")
+	}
+
+	if *summaryOnly && *outputFormat != "human" && *outputFormat != "json" {
+		fs.Usage()
+		return fmt.Errorf("Error: --summary-only is only supported by the 'human' and 'json' output formats")
+	}
+
+	if *reportObjects && *outputFormat != "human" && *outputFormat != "json" {
+		fs.Usage()
+		return fmt.Errorf("Error: --report-objects is only supported by the 'human' and 'json' output formats")
+	}
+
+	if *reportObjects && (*summaryOnly || *outputDir != "") {
+		fs.Usage()
+		return fmt.Errorf("Error: --report-objects can not be used together with --summary-only or --output-dir")
+	}
+
+	if *manifestFormat != "auto" && *manifestFormat != "yaml" && *manifestFormat != "json" {
 		fs.Usage()
-		return fmt.Errorf("Error: --output-format must be set to: 'human', 'json', 'sarif' or 'ci'")
+		return fmt.Errorf("Error: --manifest-format must be set to: 'auto', 'yaml' or 'json'")
+	}
+
+	if *printChecks {
+		return outputCheckList(os.Stdout, *outputFormat)
 	}
 
 	filesToRead := fs.Args()
@@ -130,27 +229,30 @@ func scoreFiles(binName string, args []string) error {
 
 Usage: %s score [--flag1 --flag2] file1 file2 ...
 
-Use "-" as filename to read from STDIN.`, execName(binName))
+Use "-" as filename to read from STDIN, and https:// URLs to fetch the files.`, execName(binName))
 	}
 
-	var allFilePointers []ks.NamedReader
-
-	for _, file := range filesToRead {
-		var fp io.Reader
-		var filename string
-
-		if file == "-" {
-			fp = os.Stdin
-			filename = "STDIN"
-		} else {
-			var err error
-			fp, err = os.Open(file)
-			if err != nil {
-				return err
+	if *watch {
+		for _, file := range filesToRead {
+			if file == "-" {
+				return errors.New("Error: --watch can not be used when reading from STDIN")
 			}
-			filename, _ = filepath.Abs(file)
 		}
-		allFilePointers = append(allFilePointers, namedReader{Reader: fp, name: filename})
+	}
+
+	expandedFiles, err := expandInputs(filesToRead, *allowEmptyGlob)
+	if err != nil {
+		return err
+	}
+
+	fetcher, err := newURLFetcher(*headers, *urlTimeout, *insecureSkipTLSVerify)
+	if err != nil {
+		return err
+	}
+
+	allFilePointers, err := readInputs(expandedFiles, fetcher)
+	if err != nil {
+		return err
 	}
 
 	ignoredTests := listToStructMap(ignoreTests)
@@ -161,79 +263,416 @@ Use "-" as filename to read from STDIN.`, execName(binName))
 		return errors.New("Invalid --kubernetes-version. Use on format \"vN.NN\"")
 	}
 
+	level, err := logging.ParseLevel(*logLevel)
+	if err != nil {
+		return fmt.Errorf("Invalid --log-level: %w", err)
+	}
+	// Keep logging unknown kinds with -vv, as before the logger was added
+	if !fs.Changed("log-level") && *verboseOutput > 1 {
+		level = logging.LevelInfo
+	}
+
+	if err := validateCheckIDs("--only", *only); err != nil {
+		return err
+	}
+	if err := validateCheckIDs("--fail-on", *failOn); err != nil {
+		return err
+	}
+
+	minCPU, err := parseOptionalQuantity("--min-cpu-request", *minCPURequest)
+	if err != nil {
+		return err
+	}
+	minMemory, err := parseOptionalQuantity("--min-memory-request", *minMemoryRequest)
+	if err != nil {
+		return err
+	}
+	if *maxLimitRequestRatio < 0 {
+		return errors.New("Invalid --max-limit-request-ratio, must not be negative")
+	}
+	if *maxSurgePercentage <= 0 {
+		return errors.New("Invalid --max-surge-percentage, must be greater than 0")
+	}
+	if *maxAnnotationBytes <= 0 {
+		return errors.New("Invalid --max-annotation-bytes, must be greater than 0")
+	}
+	if *maxLabels <= 0 {
+		return errors.New("Invalid --max-labels, must be greater than 0")
+	}
+
+	for _, tag := range *mutableImageTags {
+		if _, err := regexp.Compile(tag); err != nil {
+			return fmt.Errorf("Invalid --mutable-image-tag %q: %w", tag, err)
+		}
+	}
+
+	for _, class := range *rwxStorageClasses {
+		if _, err := regexp.Compile(class); err != nil {
+			return fmt.Errorf("Invalid --rwx-storage-class %q: %w", class, err)
+		}
+	}
+
+	severities, err := parseSeverityOverrides(*severityOverrides)
+	if err != nil {
+		return err
+	}
+
+	var severityPolicy *policy.Policy
+	if *severityPolicyFile != "" {
+		severityPolicy, err = policy.Load(*severityPolicyFile)
+		if err != nil {
+			return fmt.Errorf("Invalid --severity-policy: %w", err)
+		}
+		if err := validateCheckIDs("--severity-policy", severityPolicy.CheckIDs()); err != nil {
+			return err
+		}
+	}
+
+	var minOutputGrade scorecard.Grade
+	if *minGrade != "" {
+		minOutputGrade, err = scorecard.ParseGrade(*minGrade)
+		if err != nil {
+			return fmt.Errorf("Invalid --min-grade: %w", err)
+		}
+	}
+
+	// The format is detected by the extension of each file if it's not set
+	var inputFormat config.ManifestFormat
+	if *manifestFormat != "auto" {
+		inputFormat = config.ManifestFormat(*manifestFormat)
+	}
+
 	cnf := config.Configuration{
 		AllFiles:                              allFilePointers,
 		VerboseOutput:                         *verboseOutput,
 		IgnoreContainerCpuLimitRequirement:    *ignoreContainerCpuLimit,
 		IgnoreContainerMemoryLimitRequirement: *ignoreContainerMemoryLimit,
+		MinContainerCPURequest:                minCPU,
+		MinContainerMemoryRequest:             minMemory,
+		MaxContainerLimitRequestRatio:         *maxLimitRequestRatio,
+		MaxSurgePercentage:                    *maxSurgePercentage,
+		Explain:                               *explain,
 		IgnoredTests:                          ignoredTests,
 		EnabledOptionalTests:                  enabledOptionalTests,
 		UseIgnoreChecksAnnotation:             !*disableIgnoreChecksAnnotation,
 		KubernetesVersion:                     kubeVer,
+		ManifestFormat:                        inputFormat,
+		MergeSameIdentity:                     *mergeSameIdentity,
+		SeverityOverrides:                     severities,
+		SeverityPolicy:                        severityPolicy,
+		Strict:                                *strict || *exitOneOnWarning,
+		StrictUnknownKinds:                    *strictUnknown,
+		FailOnChecks:                          listToStructMap(failOn),
+		OnlyChecks:                            listToStructMap(only),
+		MinGrade:                              minOutputGrade,
+		IncludeSkipped:                        *includeSkipped,
+		RecommendedLabels:                     *recommendedLabels,
+		MutableImageTags:                      *mutableImageTags,
+		MaxAnnotationBytes:                    *maxAnnotationBytes,
+		MaxLabels:                             *maxLabels,
+		TopologyKeys:                          *topologyKeys,
+		ReadWriteManyStorageClasses:           *rwxStorageClasses,
+		Logger:                                logging.New(os.Stderr, level),
 	}
 
-	parsedFiles, err := parser.ParseFiles(cnf)
-	if err != nil {
-		return err
+	if *printTimings {
+		cnf.CheckTimings = make(map[string]time.Duration)
 	}
 
-	scoreCard, err := score.Score(parsedFiles, cnf)
-	if err != nil {
-		return err
+	if *cacheDir != "" {
+		resultCache, err := cache.NewDir(*cacheDir, cacheSalt())
+		if err != nil {
+			return fmt.Errorf("failed to create --cache-dir: %w", err)
+		}
+		cnf.Cache = resultCache
 	}
 
-	var exitCode int
-	if scoreCard.AnyBelowOrEqualToGrade(scorecard.GradeCritical) {
-		exitCode = 1
-	} else if *exitOneOnWarning && scoreCard.AnyBelowOrEqualToGrade(scorecard.GradeWarning) {
-		exitCode = 1
-	} else {
-		exitCode = 0
+	termWidth, _, err := terminal.GetSize(int(os.Stdin.Fd()))
+	// Assume a width of 80 if it can't be detected
+	if err != nil {
+		termWidth = 80
 	}
 
-	var r io.Reader
+	// scoreAndRender scores the files in cnf and writes the result, it returns the exit code that kube-score should exit with
+	scoreAndRender := func(cnf config.Configuration) (int, error) {
+		parsedFiles, err := parser.ParseFiles(cnf)
+		if err != nil {
+			return 0, err
+		}
 
-	version := getOutputVersion(*outputVersion, *outputFormat)
+		// Stream the human output while scoring if sorting is disabled, and always stream the jsonl output.
+		// All other formats are rendered when all objects have been scored.
+		streamHuman := *noSort && *groupBy == "" && !*summaryOnly && !*reportObjects && *outputFormat == "human" && version == "v1"
+		streamJSONLines := *outputFormat == "jsonl" && version == "v1"
+		streamOutput := *outputDir == "" && (streamHuman || streamJSONLines)
+
+		var onScored func(*scorecard.ScoredObject)
+		if streamOutput && streamHuman {
+			onScored = human.Stream(os.Stdout, *verboseOutput, termWidth)
+		} else if streamOutput && streamJSONLines {
+			onScored = json_v2.Stream(os.Stdout)
+		}
 
-	if *outputFormat == "json" && version == "v1" {
-		d, _ := json.MarshalIndent(scoreCard, "", "    ")
-		w := bytes.NewBufferString("")
-		w.WriteString(string(d))
-		r = w
-	} else if *outputFormat == "json" && version == "v2" {
-		r = json_v2.Output(scoreCard)
-	} else if *outputFormat == "human" && version == "v1" {
-		termWidth, _, err := terminal.GetSize(int(os.Stdin.Fd()))
-		// Assume a width of 80 if it can't be detected
+		// The checks are removed from the output only, the exit code is based on all checks
+		if onScored != nil && cnf.MinGrade != 0 {
+			stream := onScored
+			onScored = func(o *scorecard.ScoredObject) {
+				stream(o.WithMinGrade(cnf.MinGrade, cnf.IncludeSkipped))
+			}
+		}
+
+		scoreCard, err := score.ScoreWithCallback(parsedFiles, cnf, onScored)
 		if err != nil {
-			termWidth = 80
+			return 0, err
 		}
-		r = human.Human(scoreCard, *verboseOutput, termWidth)
-	} else if *outputFormat == "ci" && version == "v1" {
-		r = ci.CI(scoreCard)
-	} else if *outputFormat == "sarif" {
-		r = sarif.Output(scoreCard)
-	} else {
-		return fmt.Errorf("error: Unknown --output-format or --output-version")
+
+		if *printTimings {
+			if err := outputTimings(os.Stderr, cnf.CheckTimings); err != nil {
+				return 0, err
+			}
+		}
+
+		exitCode := getExitCode(scoreCard, cnf.Strict, cnf.FailOnChecks)
+
+		if streamOutput {
+			return exitCode, nil
+		}
+
+		metadata := runMetadata(cnf, time.Now())
+
+		// The report lists all checks that ran, so it's rendered before --min-grade removes any checks
+		if *reportObjects {
+			// Objects that have not been scored are listed with 0 checks
+			allObjects := scoreCard.WithDocuments(parsedFiles.Documents())
+			report := human.WithFooter(human.Report(allObjects), *verboseOutput, metadata)
+			if *outputFormat == "json" {
+				report = json_v2.Report(allObjects)
+			}
+			output, err := ioutil.ReadAll(report)
+			if err != nil {
+				return 0, err
+			}
+			fmt.Print(string(output))
+			return exitCode, nil
+		}
+
+		if cnf.MinGrade != 0 {
+			scoreCard = scoreCard.WithMinGrade(cnf.MinGrade, cnf.IncludeSkipped)
+		}
+
+		render := func(scoreCard *scorecard.Scorecard) (io.Reader, error) {
+			if *summaryOnly && *outputFormat == "json" {
+				return json_v2.Summary(scoreCard), nil
+			} else if *summaryOnly && *outputFormat == "human" {
+				return human.WithFooter(human.Summary(scoreCard), *verboseOutput, metadata), nil
+			} else if *groupBy == "check" && *outputFormat == "json" {
+				return json_v2.ByCheck(scoreCard), nil
+			} else if *groupBy == "check" && *outputFormat == "human" {
+				return human.WithFooter(human.HumanGroupedByCheck(scoreCard, *verboseOutput), *verboseOutput, metadata), nil
+			} else if *outputFormat == "json" && version == "v1" {
+				d, _ := json.MarshalIndent(scoreCard, "", "    ")
+				w := bytes.NewBufferString("")
+				w.WriteString(string(d))
+				return w, nil
+			} else if *outputFormat == "json" && version == "v2" {
+				return json_v2.Output(scoreCard), nil
+			} else if *outputFormat == "json" && version == "v3" {
+				return json_v3.Output(scoreCard, metadata), nil
+			} else if *outputFormat == "jsonl" && version == "v1" {
+				return json_v2.OutputLines(scoreCard), nil
+			} else if *outputFormat == "human" && version == "v1" && *groupBy == "namespace" {
+				return human.WithFooter(human.HumanGroupedByNamespace(scoreCard, *verboseOutput, termWidth), *verboseOutput, metadata), nil
+			} else if *outputFormat == "human" && version == "v1" {
+				return human.WithFooter(human.Human(scoreCard, *verboseOutput, termWidth), *verboseOutput, metadata), nil
+			} else if *outputFormat == "ci" && version == "v1" {
+				return ci.CI(scoreCard), nil
+			} else if *outputFormat == "sarif" {
+				return sarif.OutputWithOptions(scoreCard, sarif.Options{
+					IncludeSkipped: *includeSkipped,
+					Metadata:       &metadata,
+				}), nil
+			}
+			return nil, fmt.Errorf("error: Unknown --output-format or --output-version")
+		}
+
+		if *outputDir != "" {
+			if err := writeOutputDir(*outputDir, scoreCard, render, outputFileExtension(*outputFormat)); err != nil {
+				return 0, err
+			}
+			return exitCode, nil
+		}
+
+		r, err := render(scoreCard)
+		if err != nil {
+			return 0, err
+		}
+
+		output, _ := ioutil.ReadAll(r)
+		fmt.Print(string(output))
+		return exitCode, nil
 	}
 
-	output, _ := ioutil.ReadAll(r)
-	fmt.Print(string(output))
+	if *watch {
+		return watchInputs(cnf, filesToRead, *allowEmptyGlob, fetcher, *outputFormat == "human", scoreAndRender)
+	}
+
+	exitCode, err := scoreAndRender(cnf)
+	if err != nil {
+		return err
+	}
 	os.Exit(exitCode)
 	return nil
 }
 
+// expandInputs expands the glob patterns in the file arguments, shells are not always doing this for us. Directories
+// are expanded to the YAML and JSON files in them.
+func expandInputs(filesToRead []string, allowEmptyGlob bool) ([]string, error) {
+	var expandedFiles []string
+	for _, file := range filesToRead {
+		if file == "-" || isURL(file) {
+			expandedFiles = append(expandedFiles, file)
+			continue
+		}
+
+		if !hasGlobMeta(file) {
+			if info, err := os.Stat(file); err != nil || !info.IsDir() {
+				expandedFiles = append(expandedFiles, file)
+				continue
+			}
+			matches, err := expandDir(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read directory %s: %w", file, err)
+			}
+			if len(matches) == 0 && !allowEmptyGlob {
+				return nil, fmt.Errorf("Error: No YAML or JSON files found in the directory %s. Use --allow-empty-glob to allow directories without files.", file)
+			}
+			expandedFiles = append(expandedFiles, matches...)
+			continue
+		}
+
+		matches, err := expandGlob(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand glob pattern %s: %w", file, err)
+		}
+		if len(matches) == 0 && !allowEmptyGlob {
+			return nil, fmt.Errorf("Error: No files matched the pattern %s. Use --allow-empty-glob to allow patterns without matches.", file)
+		}
+		expandedFiles = append(expandedFiles, matches...)
+	}
+	return expandedFiles, nil
+}
+
+// readInputs opens all files, archives are extracted, "-" is read from STDIN and URLs are fetched
+func readInputs(files []string, fetcher *urlFetcher) ([]ks.NamedReader, error) {
+	var allFilePointers []ks.NamedReader
+
+	for _, file := range files {
+		var fp io.Reader
+		var filename string
+
+		if file == "-" {
+			fp = os.Stdin
+			filename = "STDIN"
+		} else if isURL(file) {
+			fetched, err := fetcher.fetch(file)
+			if err != nil {
+				return nil, err
+			}
+			allFilePointers = append(allFilePointers, fetched...)
+			continue
+		} else if isArchive(file) {
+			archive, err := os.Open(file)
+			if err != nil {
+				return nil, err
+			}
+			extracted, err := readArchive(archive, file)
+			archive.Close()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read archive %s: %w", file, err)
+			}
+			allFilePointers = append(allFilePointers, extracted...)
+			continue
+		} else {
+			var err error
+			fp, err = os.Open(file)
+			if err != nil {
+				return nil, err
+			}
+			filename, _ = filepath.Abs(file)
+		}
+		allFilePointers = append(allFilePointers, namedReader{Reader: fp, name: filename})
+	}
+
+	return allFilePointers, nil
+}
+
+// runMetadata describes this run of kube-score, it's included in the json v3, sarif and verbose human outputs
+func runMetadata(cnf config.Configuration, now time.Time) scorecard.Metadata {
+	allChecks := score.RegisterAllChecks(parser.Empty(), cnf)
+
+	enabledOptionalChecks := []string{}
+	for _, c := range allChecks.Enabled() {
+		if !c.Optional {
+			continue
+		}
+		enabledOptionalChecks = append(enabledOptionalChecks, c.ID)
+	}
+	sort.Strings(enabledOptionalChecks)
+
+	return scorecard.Metadata{
+		KubeScoreVersion:      version,
+		KubernetesVersion:     cnf.KubernetesVersion.String(),
+		Timestamp:             now.UTC(),
+		EnabledOptionalChecks: enabledOptionalChecks,
+	}
+}
+
+// getExitCode returns 1 if any check is critical, or if strict is set and any check is a warning.
+// If failOnChecks is not empty, critical checks that are not in failOnChecks no longer cause an exit code of 1,
+// instead any of the failOnChecks that are not graded as OK does.
+func getExitCode(scoreCard *scorecard.Scorecard, strict bool, failOnChecks map[string]struct{}) int {
+	if len(failOnChecks) > 0 {
+		if scoreCard.AnyBelowGradeForChecks(scorecard.GradeAllOK, failOnChecks) {
+			return 1
+		}
+	} else if scoreCard.AnyBelowOrEqualToGrade(scorecard.GradeCritical) {
+		return 1
+	}
+	if strict && scoreCard.AnyBelowOrEqualToGrade(scorecard.GradeWarning) {
+		return 1
+	}
+	return 0
+}
+
+// supportedOutputVersions are the versions of each output format, the first version is the default
+var supportedOutputVersions = map[string][]string{
+	"human": {"v1"},
+	"json":  {"v2", "v1", "v3"},
+	"jsonl": {"v1"},
+	"ci":    {"v1"},
+	"sarif": {"v1"},
+}
+
+// validateOutputVersion returns an error listing the supported versions if the format does not support the version
+func validateOutputVersion(format, version string) error {
+	for _, v := range supportedOutputVersions[format] {
+		if v == version {
+			return nil
+		}
+	}
+	return fmt.Errorf("Error: --output-version %s is not supported by the '%s' output format, supported versions are: %s",
+		version, format, strings.Join(supportedOutputVersions[format], ", "))
+}
+
 func getOutputVersion(flagValue, format string) string {
 	if len(flagValue) > 0 {
 		return flagValue
 	}
 
-	switch format {
-	case "json":
-		return "v2"
-	default:
-		return "v1"
+	if versions, ok := supportedOutputVersions[format]; ok {
+		return versions[0]
 	}
+	return "v1"
 }
 
 func listChecks(binName string, args []string) {
@@ -255,11 +694,62 @@ func listChecks(binName string, args []string) {
 		if c.Optional {
 			optionalString = "optional"
 		}
-		output.Write([]string{c.ID, c.TargetType, c.Comment, optionalString})
+		output.Write([]string{c.ID, strings.Join(allChecks.TargetTypes(c.ID), ", "), c.Comment, optionalString})
 	}
 	output.Flush()
 }
 
+func parseSeverityOverrides(items []string) (map[string]scorecard.Grade, error) {
+	overrides := make(map[string]scorecard.Grade)
+	var ids []string
+	for _, item := range items {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("Invalid --severity %q. Use on format \"check-id=grade\"", item)
+		}
+		grade, err := scorecard.ParseGrade(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid --severity %q: %w", item, err)
+		}
+		overrides[parts[0]] = grade
+		ids = append(ids, parts[0])
+	}
+	if err := validateCheckIDs("--severity", ids); err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+// parseOptionalQuantity parses a resource quantity, an empty value is parsed as zero
+func parseOptionalQuantity(flagName, value string) (resource.Quantity, error) {
+	if value == "" {
+		return resource.Quantity{}, nil
+	}
+	q, err := resource.ParseQuantity(value)
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf("Invalid %s %q: %w", flagName, value, err)
+	}
+	return q, nil
+}
+
+// validateCheckIDs returns an error listing all valid check IDs if any of the ids is not the ID of a registered check
+func validateCheckIDs(flagName string, ids []string) error {
+	valid := make(map[string]struct{})
+	var validIDs []string
+	for _, c := range registeredChecks() {
+		valid[c.ID] = struct{}{}
+		validIDs = append(validIDs, c.ID)
+	}
+
+	for _, id := range ids {
+		if _, ok := valid[id]; !ok {
+			sort.Strings(validIDs)
+			return fmt.Errorf("Invalid %s: unknown check %q. Valid checks are: %s", flagName, id, strings.Join(validIDs, ", "))
+		}
+	}
+	return nil
+}
+
 func listToStructMap(items *[]string) map[string]struct{} {
 	structMap := make(map[string]struct{})
 	for _, testID := range *items {
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/parser"
+	"github.com/zegl/kube-score/renderer/human"
+	"github.com/zegl/kube-score/renderer/json_v2"
+	"github.com/zegl/kube-score/score"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+const podWithLatestTag = `apiVersion: v1
+kind: Pod
+metadata:
+  name: pod-test-1
+spec:
+  containers:
+  - name: foobar
+    image: foo/bar:latest
+`
+
+func TestParseSeverityOverrides(t *testing.T) {
+	res, err := parseSeverityOverrides([]string{"pod-networkpolicy=warning", "container-image-tag=OK"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]scorecard.Grade{
+		"pod-networkpolicy":   scorecard.GradeWarning,
+		"container-image-tag": scorecard.GradeAllOK,
+	}, res)
+
+	_, err = parseSeverityOverrides([]string{"pod-networkpolicy"})
+	assert.NotNil(t, err)
+
+	_, err = parseSeverityOverrides([]string{"pod-networkpolicy=info"})
+	assert.NotNil(t, err)
+
+	_, err = parseSeverityOverrides([]string{"does-not-exist=warning"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), `Invalid --severity: unknown check "does-not-exist"`)
+}
+
+func TestSeverityOverrideExitCode(t *testing.T) {
+	scoreWithOverrides := func(overrides map[string]scorecard.Grade) *scorecard.Scorecard {
+		ignored := make(map[string]struct{})
+		for _, c := range registeredChecks() {
+			if c.ID != "container-image-tag" {
+				ignored[c.ID] = struct{}{}
+			}
+		}
+
+		cnf := config.Configuration{
+			AllFiles:          []ks.NamedReader{namedReader{Reader: strings.NewReader(podWithLatestTag), name: "pod.yaml"}},
+			IgnoredTests:      ignored,
+			SeverityOverrides: overrides,
+		}
+		parsed, err := parser.ParseFiles(cnf)
+		assert.Nil(t, err)
+		card, err := score.Score(parsed, cnf)
+		assert.Nil(t, err)
+		return card
+	}
+
+	assert.Equal(t, 1, getExitCode(scoreWithOverrides(nil), false, nil))
+
+	remapped := scoreWithOverrides(map[string]scorecard.Grade{"container-image-tag": scorecard.GradeWarning})
+	assert.Equal(t, 0, getExitCode(remapped, false, nil))
+	assert.Equal(t, 1, getExitCode(remapped, true, nil))
+}
+
+func TestFailOnExitCode(t *testing.T) {
+	cnf := config.Configuration{
+		AllFiles: []ks.NamedReader{namedReader{Reader: strings.NewReader(podWithLatestTag), name: "pod.yaml"}},
+	}
+	parsed, err := parser.ParseFiles(cnf)
+	assert.Nil(t, err)
+	card, err := score.Score(parsed, cnf)
+	assert.Nil(t, err)
+
+	// The pod has critical failures, but none of them are in the fail-on list
+	assert.Equal(t, 0, getExitCode(card, false, map[string]struct{}{"pod-probes": {}}))
+	assert.Equal(t, 1, getExitCode(card, false, map[string]struct{}{"container-image-tag": {}}))
+
+	// Warnings from any check fail the run in strict mode, also when fail-on is set
+	assert.Equal(t, 1, getExitCode(card, true, map[string]struct{}{"pod-probes": {}}))
+}
+
+func TestMinGradeOutput(t *testing.T) {
+	cnf := config.Configuration{
+		AllFiles: []ks.NamedReader{namedReader{Reader: strings.NewReader(podWithLatestTag + `---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod-test-1
+  namespace: other
+spec:
+  containers:
+  - name: foobar
+    image: foo/bar:1.0
+`), name: "pod.yaml"}},
+	}
+	parsed, err := parser.ParseFiles(cnf)
+	assert.Nil(t, err)
+	card, err := score.Score(parsed, cnf)
+	assert.Nil(t, err)
+
+	filtered := card.WithMinGrade(scorecard.GradeWarning, false)
+
+	// Only checks that are warning or worse are included
+	var objects []json_v2.ScoredObject
+	out, _ := ioutil.ReadAll(json_v2.Output(filtered))
+	assert.Nil(t, json.Unmarshal(out, &objects))
+	assert.Len(t, objects, 2)
+	for _, o := range objects {
+		assert.NotEmpty(t, o.Checks)
+		for _, c := range o.Checks {
+			assert.False(t, c.Skipped)
+			assert.LessOrEqual(t, int(c.Grade), int(scorecard.GradeWarning), c.Check.ID)
+		}
+	}
+
+	// The summary and the exit code are the same as for the unfiltered output
+	summary := func(card *scorecard.Scorecard) string {
+		out, _ := ioutil.ReadAll(human.HumanGroupedByNamespace(card, 0, 80))
+		return string(out[strings.Index(string(out), "Summary by namespace:"):])
+	}
+	assert.Equal(t, summary(card), summary(filtered))
+	assert.Equal(t, getExitCode(card, false, nil), getExitCode(filtered, false, nil))
+
+	// The scorecard itself is not changed
+	for _, o := range *card {
+		assert.True(t, len(o.Checks) > len((*filtered)[o.ResourceRefKey()].Checks))
+	}
+}
+
+func TestValidateCheckIDs(t *testing.T) {
+	assert.Nil(t, validateCheckIDs("--only", nil))
+	assert.Nil(t, validateCheckIDs("--only", []string{"container-image-tag", "pvc-storageclass"}))
+
+	err := validateCheckIDs("--only", []string{"container-image-tag", "does-not-exist"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), `Invalid --only: unknown check "does-not-exist". Valid checks are: `)
+	assert.Contains(t, err.Error(), "container-image-tag, ")
+}
+
+func TestParseOptionalQuantity(t *testing.T) {
+	q, err := parseOptionalQuantity("--min-cpu-request", "")
+	assert.Nil(t, err)
+	assert.True(t, q.IsZero())
+
+	q, err = parseOptionalQuantity("--min-cpu-request", "10m")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), q.MilliValue())
+
+	_, err = parseOptionalQuantity("--min-cpu-request", "ten")
+	assert.NotNil(t, err)
+}
+
+func TestValidateOutputVersion(t *testing.T) {
+	assert.Nil(t, validateOutputVersion("json", getOutputVersion("", "json")))
+	assert.Nil(t, validateOutputVersion("json", "v1"))
+	assert.Nil(t, validateOutputVersion("json", "v3"))
+	assert.Nil(t, validateOutputVersion("human", getOutputVersion("", "human")))
+	assert.Nil(t, validateOutputVersion("sarif", getOutputVersion("", "sarif")))
+
+	err := validateOutputVersion("json", "v1beta1")
+	assert.NotNil(t, err)
+	assert.Equal(t, "Error: --output-version v1beta1 is not supported by the 'json' output format, supported versions are: v2, v1, v3", err.Error())
+
+	assert.NotNil(t, validateOutputVersion("human", "v2"))
+}
+
+func TestRunMetadata(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	metadata := runMetadata(config.Configuration{
+		KubernetesVersion: config.Semver{Major: 1, Minor: 21},
+		EnabledOptionalTests: map[string]struct{}{
+			"pvc-storageclass":          {},
+			"container-seccomp-profile": {},
+			"container-image-tag":       {},
+		},
+	}, now)
+
+	assert.Equal(t, version, metadata.KubeScoreVersion)
+	assert.Equal(t, "v1.21", metadata.KubernetesVersion)
+	assert.Equal(t, now.UTC(), metadata.Timestamp)
+	assert.Equal(t, time.UTC, metadata.Timestamp.Location())
+	// Only optional checks are listed, container-image-tag is enabled by default. pvc-storageclass is listed once, even
+	// if it is registered for both PersistentVolumeClaims and StatefulSets
+	assert.Equal(t, []string{"container-seccomp-profile", "pvc-storageclass"}, metadata.EnabledOptionalChecks)
+
+	metadata = runMetadata(config.Configuration{}, now)
+	assert.Equal(t, []string{}, metadata.EnabledOptionalChecks)
+}
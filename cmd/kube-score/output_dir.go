@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func outputFileExtension(outputFormat string) string {
+	switch outputFormat {
+	case "json":
+		return "json"
+	case "jsonl":
+		return "jsonl"
+	case "sarif":
+		return "sarif"
+	default:
+		return "txt"
+	}
+}
+
+// outputFileName returns the name of the file that the scored object is written to, without an extension. The
+// namespace, kind and name come from the manifest, and are sanitized with safeFileNameComponent so that the file is
+// always created directly in the output directory.
+func outputFileName(o *scorecard.ScoredObject) string {
+	name := safeFileNameComponent(o.TypeMeta.Kind) + "_" + safeFileNameComponent(o.ObjectMeta.Name)
+	if o.ObjectMeta.Namespace != "" {
+		name = safeFileNameComponent(o.ObjectMeta.Namespace) + "_" + name
+	}
+	return name
+}
+
+// safeFileNameComponent replaces all characters other than A-Z, a-z, 0-9, ., _ and - with _, and breaks up all
+// sequences of dots, so that the result can't contain a path separator or refer to a parent directory
+func safeFileNameComponent(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '.' || r == '_' || r == '-' {
+			return r
+		}
+		return '_'
+	}, s)
+	for strings.Contains(s, "..") {
+		s = strings.Replace(s, "..", "._", -1)
+	}
+	if s == "" || s == "." {
+		s = "_"
+	}
+	return s
+}
+
+// writeOutputDir renders each object in the scorecard separately, and writes them to a file per object in dir.
+// If multiple objects would be written to the same file, a numeric suffix is added to the name of the file.
+func writeOutputDir(dir string, scoreCard *scorecard.Scorecard, render func(*scorecard.Scorecard) (io.Reader, error), extension string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Write the objects sorted by scorecard key, so that the suffixes are stable between runs
+	var keys []string
+	for k := range *scoreCard {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// usedNames are the names of the files that have been written, including their suffixes, so that a suffixed name
+	// never overwrites the file of an object with that name
+	usedNames := make(map[string]struct{})
+
+	for _, key := range keys {
+		o := (*scoreCard)[key]
+
+		base := outputFileName(o)
+		name := base
+		for i := 2; ; i++ {
+			if _, ok := usedNames[name]; !ok {
+				break
+			}
+			name = fmt.Sprintf("%s_%d", base, i)
+		}
+		usedNames[name] = struct{}{}
+
+		path := filepath.Join(dir, name+"."+extension)
+		if filepath.Dir(path) != filepath.Clean(dir) {
+			return fmt.Errorf("refusing to write the result of %s outside of the output directory", key)
+		}
+
+		r, err := render(&scorecard.Scorecard{key: o})
+		if err != nil {
+			return err
+		}
+
+		if err := writeOutputFile(path, r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeOutputFile(path string, r io.Reader) error {
+	fp, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(fp, r); err != nil {
+		fp.Close()
+		return err
+	}
+	return fp.Close()
+}
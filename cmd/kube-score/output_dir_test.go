@@ -0,0 +1,98 @@
+package main
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestWriteOutputDir(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "kube-score-output-dir")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmp)
+
+	card := scorecard.New()
+	card.NewObject(metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"}, metav1.ObjectMeta{Name: "foo", Namespace: "bar"}, false)
+	card.NewObject(metav1.TypeMeta{APIVersion: "extensions/v1beta1", Kind: "Deployment"}, metav1.ObjectMeta{Name: "foo", Namespace: "bar"}, false)
+	card.NewObject(metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"}, metav1.ObjectMeta{Name: "bar"}, false)
+
+	render := func(s *scorecard.Scorecard) (io.Reader, error) {
+		var names []string
+		for _, o := range *s {
+			names = append(names, o.TypeMeta.APIVersion+" "+o.ObjectMeta.Name)
+		}
+		return strings.NewReader(strings.Join(names, ",")), nil
+	}
+
+	dir := filepath.Join(tmp, "nested", "reports")
+	assert.Nil(t, writeOutputDir(dir, &card, render, "txt"))
+
+	files, err := ioutil.ReadDir(dir)
+	assert.Nil(t, err)
+
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	assert.Equal(t, []string{"Namespace_bar.txt", "bar_Deployment_foo.txt", "bar_Deployment_foo_2.txt"}, names)
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "bar_Deployment_foo.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, "apps/v1 foo", string(content))
+}
+
+func TestWriteOutputDirUnsafeNames(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "kube-score-output-dir")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmp)
+
+	card := scorecard.New()
+	card.NewObject(metav1.TypeMeta{APIVersion: "v1", Kind: "Service"}, metav1.ObjectMeta{Name: "../../../escaped"}, false)
+	card.NewObject(metav1.TypeMeta{APIVersion: "v1", Kind: "Service"}, metav1.ObjectMeta{Name: `..\..\escaped`, Namespace: ".."}, false)
+	card.NewObject(metav1.TypeMeta{APIVersion: "v1", Kind: "Service"}, metav1.ObjectMeta{Name: "foo_2", Namespace: "bar"}, false)
+	card.NewObject(metav1.TypeMeta{APIVersion: "v1", Kind: "Service"}, metav1.ObjectMeta{Name: "foo", Namespace: "bar"}, false)
+	card.NewObject(metav1.TypeMeta{APIVersion: "v2", Kind: "Service"}, metav1.ObjectMeta{Name: "foo", Namespace: "bar"}, false)
+
+	render := func(s *scorecard.Scorecard) (io.Reader, error) {
+		var names []string
+		for _, o := range *s {
+			names = append(names, o.TypeMeta.APIVersion+" "+o.ObjectMeta.Name)
+		}
+		return strings.NewReader(strings.Join(names, ",")), nil
+	}
+
+	dir := filepath.Join(tmp, "out")
+	assert.Nil(t, writeOutputDir(dir, &card, render, "txt"))
+
+	// Nothing is written outside of the output directory
+	files, err := ioutil.ReadDir(tmp)
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+
+	files, err = ioutil.ReadDir(dir)
+	assert.Nil(t, err)
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	assert.Equal(t, []string{
+		".__Service_.__.__escaped.txt",
+		"Service_.__.__.__escaped.txt",
+		"bar_Service_foo.txt",
+		"bar_Service_foo_2.txt",
+		"bar_Service_foo_3.txt",
+	}, names)
+
+	// The suffixed name of the second foo doesn't overwrite the object named foo_2
+	content, err := ioutil.ReadFile(filepath.Join(dir, "bar_Service_foo_2.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, "v1 foo_2", string(content))
+}
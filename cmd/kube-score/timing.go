@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// outputTimings writes a table of the time spent in each check, sorted with the most expensive check first
+func outputTimings(w io.Writer, timings map[string]time.Duration) error {
+	var ids []string
+	var total time.Duration
+	for id, d := range timings {
+		ids = append(ids, id)
+		total += d
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if timings[ids[i]] == timings[ids[j]] {
+			return ids[i] < ids[j]
+		}
+		return timings[ids[i]] > timings[ids[j]]
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tTIME")
+	for _, id := range ids {
+		fmt.Fprintf(tw, "%s\t%s\n", id, timings[id])
+	}
+	fmt.Fprintf(tw, "total\t%s\n", total)
+	return tw.Flush()
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputTimings(t *testing.T) {
+	var buf bytes.Buffer
+	err := outputTimings(&buf, map[string]time.Duration{
+		"container-image-tag": 2 * time.Millisecond,
+		"pod-probes":          5 * time.Millisecond,
+		"pod-networkpolicy":   2 * time.Millisecond,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, `CHECK                TIME
+pod-probes           5ms
+container-image-tag  2ms
+pod-networkpolicy    2ms
+total                9ms
+`, buf.String())
+}
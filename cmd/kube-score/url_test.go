@@ -0,0 +1,49 @@
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURLFetcher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte("apiVersion: v1\nkind: Pod\nmetadata:\n  name: foo\n---\napiVersion: v1\nkind: Pod\nmetadata:\n  name: bar\n"))
+	}))
+	defer server.Close()
+
+	fetcher, err := newURLFetcher([]string{"Authorization: Bearer token"}, time.Second, false)
+	assert.Nil(t, err)
+
+	files, err := fetcher.fetch(server.URL + "/pods.yaml")
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, server.URL+"/pods.yaml", files[0].Name())
+	content, _ := ioutil.ReadAll(files[0])
+	assert.Contains(t, string(content), "name: bar")
+
+	fetcher, err = newURLFetcher(nil, time.Second, false)
+	assert.Nil(t, err)
+	_, err = fetcher.fetch(server.URL + "/pods.yaml")
+	assert.EqualError(t, err, "failed to fetch "+server.URL+"/pods.yaml: 401 Unauthorized")
+}
+
+func TestNewURLFetcherInvalidHeader(t *testing.T) {
+	_, err := newURLFetcher([]string{"Authorization"}, time.Second, false)
+	assert.EqualError(t, err, `Invalid --header "Authorization". Use on format "Name: value"`)
+}
+
+func TestIsURL(t *testing.T) {
+	assert.True(t, isURL("https://example.com/pod.yaml"))
+	assert.True(t, isURL("http://example.com/pod.yaml"))
+	assert.False(t, isURL("pod.yaml"))
+	assert.False(t, isURL("-"))
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+)
+
+// watchDebounce is how long to wait for more changes before scoring again, editors often write a file in several steps
+const watchDebounce = 200 * time.Millisecond
+
+// clearScreen moves the cursor to the top left corner and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+// watchInputs scores the files, and scores them again each time that they are changed, until kube-score is stopped.
+// The same configuration is used for all runs, the files are read again before each run.
+func watchInputs(cnf config.Configuration, filesToRead []string, allowEmptyGlob bool, fetcher *urlFetcher, clear bool, scoreAndRender func(config.Configuration) (int, error)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch files: %w", err)
+	}
+	defer watcher.Close()
+
+	closeInputs(cnf.AllFiles)
+
+	inputs := make(map[string]struct{})
+	run := func() {
+		if clear {
+			fmt.Print(clearScreen)
+		}
+
+		err := func() error {
+			files, err := expandInputs(filesToRead, allowEmptyGlob)
+			if err != nil {
+				return err
+			}
+			for _, file := range files {
+				inputs[filepath.Clean(file)] = struct{}{}
+			}
+			if err := addWatchDirs(watcher, filesToRead, files); err != nil {
+				return err
+			}
+
+			cnf.AllFiles, err = readInputs(files, fetcher)
+			defer closeInputs(cnf.AllFiles)
+			if err != nil {
+				return err
+			}
+			if cnf.CheckTimings != nil {
+				cnf.CheckTimings = make(map[string]time.Duration)
+			}
+			_, err = scoreAndRender(cnf)
+			return err
+		}()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		fmt.Fprintln(os.Stderr, "Watching for changes, press Ctrl+C to stop")
+	}
+
+	isInput := func(path string) bool {
+		return isWatchedInput(path, filesToRead, inputs)
+	}
+
+	run()
+	return watchEvents(watcher.Events, watcher.Errors, isInput, watchDebounce, nil, run)
+}
+
+// addWatchDirs watches the directories of all files, the directories in the file arguments, and the directories that
+// can contain files that matches the glob patterns. Patterns with "**" are watched recursively. URLs are fetched again
+// on each run, but are not watched.
+func addWatchDirs(watcher *fsnotify.Watcher, filesToRead, files []string) error {
+	dirs := make(map[string]struct{})
+	for _, file := range files {
+		if !isURL(file) {
+			dirs[filepath.Dir(file)] = struct{}{}
+		}
+	}
+
+	for _, pattern := range filesToRead {
+		if isURL(pattern) {
+			continue
+		}
+		if !hasGlobMeta(pattern) {
+			dirs[filepath.Dir(pattern)] = struct{}{}
+			if info, err := os.Stat(pattern); err == nil && info.IsDir() {
+				dirs[filepath.Clean(pattern)] = struct{}{}
+			}
+			continue
+		}
+
+		root := globRoot(pattern)
+		dirs[root] = struct{}{}
+		if !strings.Contains(pattern, "**") {
+			continue
+		}
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				dirs[path] = struct{}{}
+			}
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// isWatchedInput returns true if the path is one of the files that has been scored, if it matches one of the glob
+// patterns, or if it's a YAML or JSON file in one of the directories, so that new files are scored as well
+func isWatchedInput(path string, filesToRead []string, inputs map[string]struct{}) bool {
+	path = filepath.Clean(path)
+	if _, ok := inputs[path]; ok {
+		return true
+	}
+
+	pathSegments := strings.Split(filepath.ToSlash(path), "/")
+	for _, pattern := range filesToRead {
+		if !hasGlobMeta(pattern) {
+			if filepath.Clean(pattern) == path {
+				return true
+			}
+			if filepath.Clean(pattern) == filepath.Dir(path) && isManifestFile(path) {
+				return true
+			}
+			continue
+		}
+		if ok, _ := matchSegments(strings.Split(filepath.ToSlash(pattern), "/"), pathSegments); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// watchEvents calls onChange when an input has changed, and no other input has changed during the debounce duration.
+// It returns when stop is closed, or if watching fails.
+func watchEvents(events <-chan fsnotify.Event, errs <-chan error, isInput func(string) bool, debounce time.Duration, stop <-chan struct{}, onChange func()) error {
+	var changed <-chan time.Time
+	for {
+		select {
+		case <-stop:
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) == 0 || !isInput(event.Name) {
+				continue
+			}
+			changed = time.After(debounce)
+		case err, ok := <-errs:
+			if !ok {
+				return nil
+			}
+			return fmt.Errorf("failed to watch files: %w", err)
+		case <-changed:
+			changed = nil
+			onChange()
+		}
+	}
+}
+
+// closeInputs closes all files that has been opened by readInputs
+func closeInputs(files []ks.NamedReader) {
+	for _, file := range files {
+		if named, ok := file.(namedReader); ok {
+			if closer, ok := named.Reader.(io.Closer); ok && named.Reader != os.Stdin {
+				closer.Close()
+			}
+		}
+	}
+}
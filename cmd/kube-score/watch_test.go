@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchEventsDebounce(t *testing.T) {
+	events := make(chan fsnotify.Event)
+	stop := make(chan struct{})
+	changes := make(chan struct{}, 10)
+	done := make(chan error)
+
+	isInput := func(path string) bool { return path == "pod.yaml" }
+	go func() {
+		done <- watchEvents(events, nil, isInput, 50*time.Millisecond, stop, func() { changes <- struct{}{} })
+	}()
+
+	// Rapid saves are scored once
+	for i := 0; i < 5; i++ {
+		events <- fsnotify.Event{Name: "pod.yaml", Op: fsnotify.Write}
+	}
+	events <- fsnotify.Event{Name: "other.txt", Op: fsnotify.Write}
+	events <- fsnotify.Event{Name: "pod.yaml", Op: fsnotify.Chmod}
+
+	select {
+	case <-changes:
+	case <-time.After(2 * time.Second):
+		t.Fatal("onChange was not called")
+	}
+	time.Sleep(150 * time.Millisecond)
+	assert.Len(t, changes, 0)
+
+	close(stop)
+	assert.Nil(t, <-done)
+}
+
+func TestIsWatchedInput(t *testing.T) {
+	inputs := map[string]struct{}{"pod.yaml": {}}
+	filesToRead := []string{"./pod.yaml", "manifests/*.yaml", "charts/**/*.yaml"}
+
+	assert.True(t, isWatchedInput("./pod.yaml", filesToRead, inputs))
+	assert.True(t, isWatchedInput("manifests/new.yaml", filesToRead, inputs))
+	assert.True(t, isWatchedInput("charts/app/templates/deployment.yaml", filesToRead, inputs))
+	assert.False(t, isWatchedInput("manifests/.new.yaml.swp", filesToRead, inputs))
+	assert.False(t, isWatchedInput("service.yaml", filesToRead, inputs))
+}
+
+func TestIsWatchedInputDirectory(t *testing.T) {
+	filesToRead := []string{"my-app/"}
+
+	assert.True(t, isWatchedInput("my-app/new.yaml", filesToRead, nil))
+	assert.True(t, isWatchedInput("my-app/new.json", filesToRead, nil))
+	assert.False(t, isWatchedInput("my-app/.new.yaml.swp", filesToRead, nil))
+	assert.False(t, isWatchedInput("my-app/sub/new.yaml", filesToRead, nil))
+	assert.False(t, isWatchedInput("other/new.yaml", filesToRead, nil))
+}
@@ -5,8 +5,14 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/logging"
+	"github.com/zegl/kube-score/policy"
+	"github.com/zegl/kube-score/scorecard"
 )
 
 type Configuration struct {
@@ -18,8 +24,114 @@ type Configuration struct {
 	EnabledOptionalTests                  map[string]struct{}
 	UseIgnoreChecksAnnotation             bool
 	KubernetesVersion                     Semver
+
+	// ManifestFormat is the format of the files in AllFiles. Files are parsed as JSON if they have a .json extension,
+	// and as YAML otherwise, if it's empty.
+	ManifestFormat ManifestFormat
+
+	// MergeSameIdentity merges all objects with the same apiVersion, kind, namespace and name into a single object
+	// before they are scored, with the later objects in AllFiles patched on top of the earlier ones.
+	MergeSameIdentity bool
+
+	// MinContainerCPURequest and MinContainerMemoryRequest are the smallest requests that are accepted by the
+	// "Container Resources" check. Requests that are not set are always reported, smaller requests are only reported
+	// if the minimum is not zero.
+	MinContainerCPURequest    resource.Quantity
+	MinContainerMemoryRequest resource.Quantity
+
+	// MaxContainerLimitRequestRatio is the highest ratio between the limit and the request of CPU or memory that is
+	// accepted by the "Container Resources" check. The ratio is not checked if it's zero.
+	MaxContainerLimitRequestRatio float64
+
+	// Explain adds the explanation of the check to all checks that did not pass
+	Explain bool
+
+	// MaxSurgePercentage is the highest maxSurge, as a percentage of the replicas, that is accepted by the
+	// "Deployment MaxSurge Footprint" check. The default percentage is used if it's zero.
+	MaxSurgePercentage int
+
+	// SeverityOverrides caps the most severe grade that a check (by ID) can report.
+	// A check that would have been graded as Critical with an override of Warning is reported as Warning.
+	SeverityOverrides map[string]scorecard.Grade
+
+	// SeverityPolicy caps the most severe grade of checks depending on the namespace and labels of each object.
+	// A matching rule in the policy takes precedence over SeverityOverrides. Not used if it's nil.
+	SeverityPolicy *policy.Policy
+
+	// Strict makes kube-score exit with a non-zero exit code if any check is graded as Warning or worse. The grades
+	// and the output are not affected. If FailOnChecks is also set, either of them can fail the run.
+	Strict bool
+
+	// StrictUnknownKinds grades objects with a kind or apiVersion that is not known to Kubernetes as Critical instead
+	// of Warning
+	StrictUnknownKinds bool
+
+	// FailOnChecks is a set of check IDs that decide the exit code. If set, only these checks (and warnings, if
+	// exiting on warnings is enabled) can make kube-score exit with a non-zero exit code.
+	FailOnChecks map[string]struct{}
+
+	// CheckTimings is populated with the total time spent in each check (by ID), if it's not nil
+	CheckTimings map[string]time.Duration
+
+	// OnlyChecks restricts the run to the checks with these IDs, if it's not empty. The checks are executed even if
+	// they are optional or ignored, and all other checks are skipped.
+	OnlyChecks map[string]struct{}
+
+	// MinGrade removes all checks that are graded better than it from the output, if it's not zero. Skipped checks are
+	// only kept if IncludeSkipped is set. The checks are removed after scoring, and the exit code is based on all checks.
+	MinGrade scorecard.Grade
+
+	// IncludeSkipped adds all checks that are not enabled to the results as skipped, together with the reason
+	IncludeSkipped bool
+
+	// RecommendedLabels is the set of labels required by the "Object Recommended Labels" check.
+	// Labels without a prefix are prefixed with app.kubernetes.io/.
+	RecommendedLabels []string
+
+	// MutableImageTags are the regular expressions of the tags that are considered to be mutable by the
+	// "Container Image Mutable Tag" check, in addition to latest. The expressions must match the whole tag.
+	MutableImageTags []string
+
+	// MaxAnnotationBytes and MaxLabels are the largest size of the annotations, and the largest number of labels, of
+	// an object that are accepted by the "Object Metadata Size" check. The defaults are used if they are 0.
+	MaxAnnotationBytes int
+	MaxLabels          int
+
+	// TopologyKeys are the node labels that are accepted as topologyKey by the "Pod Affinity TopologyKey" check, in
+	// addition to the well-known labels that are set by Kubernetes
+	TopologyKeys []string
+
+	// ReadWriteManyStorageClasses are the regular expressions of the StorageClasses that are expected to support the
+	// ReadWriteMany access mode by the "StatefulSet VolumeClaim AccessModes" check. The expressions must match the
+	// whole name of the StorageClass. The defaults are used if it's empty.
+	ReadWriteManyStorageClasses []string
+
+	// Logger is used to log details about the parsing and scoring, nothing is logged if it's nil
+	Logger *logging.Logger
+
+	// Cache stores the results of checks between runs, so that objects that have not changed don't have to be
+	// scored again. Results are not cached if it's nil.
+	Cache ResultCache
+}
+
+// ResultCache is a key-value store for the results of checks, see the cache package for an implementation that
+// stores the results on disk. The keys are already unique for the configuration and the content of the object.
+type ResultCache interface {
+	Get(key string) ([]byte, bool)
+	Put(key string, value []byte) error
 }
 
+// ManifestFormat is the format that files are parsed as
+type ManifestFormat string
+
+const (
+	// ManifestFormatYAML is one or more YAML documents, separated by "---"
+	ManifestFormatYAML ManifestFormat = "yaml"
+
+	// ManifestFormatJSON is one or more JSON objects, or arrays of objects, such as newline delimited JSON
+	ManifestFormatJSON ManifestFormat = "json"
+)
+
 type Semver struct {
 	Major int
 	Minor int
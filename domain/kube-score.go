@@ -4,8 +4,10 @@ import (
 	"io"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -15,6 +17,18 @@ type Check struct {
 	TargetType string
 	Comment    string
 	Optional   bool
+
+	// Explanation is only set on checks that did not pass, when explanations are enabled with config.Explain
+	Explanation *CheckExplanation `json:",omitempty"`
+}
+
+// CheckExplanation is the long form documentation of a check
+type CheckExplanation struct {
+	// Rationale describes why the check matters
+	Rationale string
+
+	// Remediation describes how a failing check is usually fixed
+	Remediation string
 }
 
 type NamedReader interface {
@@ -48,6 +62,8 @@ type HpaTargeter interface {
 	GetTypeMeta() metav1.TypeMeta
 	GetObjectMeta() metav1.ObjectMeta
 	HpaTarget() autoscalingv1.CrossVersionObjectReference
+	MinReplicas() *int32
+	MaxReplicas() int32
 	FileLocationer
 }
 
@@ -55,6 +71,7 @@ type Ingress interface {
 	GetTypeMeta() metav1.TypeMeta
 	GetObjectMeta() metav1.ObjectMeta
 	Rules() []networkingv1.IngressRule
+	IngressClassName() *string
 	FileLocationer
 }
 
@@ -102,6 +119,15 @@ type Deployments interface {
 	Deployments() []Deployment
 }
 
+type DaemonSet interface {
+	DaemonSet() appsv1.DaemonSet
+	FileLocationer
+}
+
+type DaemonSets interface {
+	DaemonSets() []DaemonSet
+}
+
 type NetworkPolicy interface {
 	NetworkPolicy() networkingv1.NetworkPolicy
 	FileLocationer
@@ -115,10 +141,55 @@ type Ingresses interface {
 	Ingresses() []Ingress
 }
 
+// UnknownObject is an object with a kind or apiVersion that is not known to Kubernetes, such as a misspelled kind.
+// Objects of custom resources are not unknown.
+type UnknownObject interface {
+	GetTypeMeta() metav1.TypeMeta
+	GetObjectMeta() metav1.ObjectMeta
+	// Suggestion is the known apiVersion and kind that is the closest to the ones of the object, or nil if there is
+	// no known kind that is similar enough
+	Suggestion() *metav1.TypeMeta
+	FileLocationer
+}
+
+type UnknownObjects interface {
+	UnknownObjects() []UnknownObject
+}
+
+// Document is an object that has been read from the input, of any kind. Objects of kinds that are not scored, such as
+// custom resources, are also documents.
+type Document struct {
+	TypeMeta   metav1.TypeMeta
+	ObjectMeta metav1.ObjectMeta
+	Location   FileLocation
+}
+
+type Documents interface {
+	Documents() []Document
+}
+
+// InvalidQuantity is a resource quantity of an object that can't be parsed, such as a memory limit of 512MB. Invalid
+// quantities are left out of the decoded object, so that the rest of the object can be scored.
+type InvalidQuantity struct {
+	TypeMeta   metav1.TypeMeta
+	ObjectMeta metav1.ObjectMeta
+	Location   FileLocation
+
+	// Path is the path to the quantity in the object, such as spec.containers[0].resources.limits.memory
+	Path  string
+	Value string
+}
+
+type InvalidQuantities interface {
+	InvalidQuantities() []InvalidQuantity
+}
+
 type CronJob interface {
 	GetTypeMeta() metav1.TypeMeta
 	GetObjectMeta() metav1.ObjectMeta
 	StartingDeadlineSeconds() *int64
+	Schedule() string
+	ConcurrencyPolicy() batchv1.ConcurrencyPolicy
 	FileLocationer
 }
 
@@ -136,6 +207,67 @@ type PodDisruptionBudgets interface {
 	PodDisruptionBudgets() []PodDisruptionBudget
 }
 
+type PersistentVolumeClaim interface {
+	PersistentVolumeClaim() corev1.PersistentVolumeClaim
+	FileLocationer
+}
+
+type PersistentVolumeClaims interface {
+	PersistentVolumeClaims() []PersistentVolumeClaim
+}
+
+type ConfigMap interface {
+	ConfigMap() corev1.ConfigMap
+	FileLocationer
+}
+
+type ConfigMaps interface {
+	ConfigMaps() []ConfigMap
+}
+
+type Secret interface {
+	Secret() corev1.Secret
+	FileLocationer
+}
+
+type Secrets interface {
+	Secrets() []Secret
+}
+
+type Namespace interface {
+	Namespace() corev1.Namespace
+	FileLocationer
+}
+
+type Namespaces interface {
+	Namespaces() []Namespace
+}
+
+// Role is a Role or a ClusterRole
+type Role interface {
+	GetTypeMeta() metav1.TypeMeta
+	GetObjectMeta() metav1.ObjectMeta
+	Rules() []rbacv1.PolicyRule
+	FileLocationer
+}
+
+type Roles interface {
+	Roles() []Role
+}
+
+// RoleBinding is a RoleBinding or a ClusterRoleBinding
+type RoleBinding interface {
+	GetTypeMeta() metav1.TypeMeta
+	GetObjectMeta() metav1.ObjectMeta
+	RoleRef() rbacv1.RoleRef
+	Subjects() []rbacv1.Subject
+	FileLocationer
+}
+
+type RoleBindings interface {
+	RoleBindings() []RoleBinding
+}
+
 type HorizontalPodAutoscalers interface {
 	HorizontalPodAutoscalers() []HpaTargeter
 }
@@ -147,9 +279,19 @@ type AllTypes interface {
 	Services
 	StatefulSets
 	Deployments
+	DaemonSets
 	NetworkPolicies
 	Ingresses
 	CronJobs
 	PodDisruptionBudgets
 	HorizontalPodAutoscalers
+	PersistentVolumeClaims
+	ConfigMaps
+	Secrets
+	Namespaces
+	Roles
+	RoleBindings
+	UnknownObjects
+	InvalidQuantities
+	Documents
 }
@@ -9,5 +9,7 @@ print("|----|--------|-------------|---------|")
 
 listAsCSV = subprocess.getoutput("kube-score list")
 
+# The ID of each check is an anchor, that the helpUri of the rules in the sarif output links to
 for row in csv.reader(StringIO(listAsCSV)):
+    row[0] = '<a name="' + row[0] + '"></a>' + row[0]
     print("| " + " | ".join(row) + " |")
@@ -0,0 +1,114 @@
+// Package logging is a minimal leveled logger, that writes structured log lines in the logfmt format.
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return fmt.Sprintf("level(%d)", int(l))
+	}
+}
+
+// ParseLevel parses a level from its name, one of "debug", "info", "warn" or "error"
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q, must be one of 'debug', 'info', 'warn' or 'error'", s)
+	}
+}
+
+// Logger writes all messages with a level higher than or equal to the configured level to w.
+// All methods are safe to use on a nil *Logger, in which case nothing is logged.
+type Logger struct {
+	w     io.Writer
+	level Level
+	mu    sync.Mutex
+}
+
+func New(w io.Writer, level Level) *Logger {
+	return &Logger{w: w, level: level}
+}
+
+// Debug logs msg, together with keyvals that are pairs of keys and values
+func (l *Logger) Debug(msg string, keyvals ...interface{}) {
+	l.log(LevelDebug, msg, keyvals)
+}
+
+// Info logs msg, together with keyvals that are pairs of keys and values
+func (l *Logger) Info(msg string, keyvals ...interface{}) {
+	l.log(LevelInfo, msg, keyvals)
+}
+
+// Warn logs msg, together with keyvals that are pairs of keys and values
+func (l *Logger) Warn(msg string, keyvals ...interface{}) {
+	l.log(LevelWarn, msg, keyvals)
+}
+
+// Error logs msg, together with keyvals that are pairs of keys and values
+func (l *Logger) Error(msg string, keyvals ...interface{}) {
+	l.log(LevelError, msg, keyvals)
+}
+
+func (l *Logger) log(level Level, msg string, keyvals []interface{}) {
+	if l == nil || level < l.level {
+		return
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("level=" + level.String())
+	buf.WriteString(" msg=" + formatValue(msg))
+
+	for i := 0; i < len(keyvals); i += 2 {
+		key := fmt.Sprint(keyvals[i])
+		value := "(missing)"
+		if i+1 < len(keyvals) {
+			value = fmt.Sprint(keyvals[i+1])
+		}
+		buf.WriteString(" " + key + "=" + formatValue(value))
+	}
+	buf.WriteString("\n")
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = l.w.Write(buf.Bytes())
+}
+
+// formatValue quotes the value if it's empty or contains spaces, quotes or equal signs
+func formatValue(s string) string {
+	if s == "" || strings.ContainsAny(s, " \"=\t\n") {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
@@ -0,0 +1,37 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggerLevels(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, LevelInfo)
+
+	l.Debug("not logged")
+	l.Info("Loaded file", "file", "/tmp/a b.yaml", "objects", 3)
+	l.Warn("Unknown kind", "kind", "ConfigMap")
+	l.Error("odd", "key")
+
+	assert.Equal(t, `level=info msg="Loaded file" file="/tmp/a b.yaml" objects=3
+level=warn msg="Unknown kind" kind=ConfigMap
+level=error msg=odd key=(missing)
+`, buf.String())
+}
+
+func TestNilLogger(t *testing.T) {
+	var l *Logger
+	l.Error("does not panic")
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("WARN")
+	assert.Nil(t, err)
+	assert.Equal(t, LevelWarn, level)
+
+	_, err = ParseLevel("verbose")
+	assert.NotNil(t, err)
+}
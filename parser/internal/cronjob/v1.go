@@ -16,6 +16,14 @@ func (c CronJobV1) StartingDeadlineSeconds() *int64 {
 	return c.Obj.Spec.StartingDeadlineSeconds
 }
 
+func (c CronJobV1) Schedule() string {
+	return c.Obj.Spec.Schedule
+}
+
+func (c CronJobV1) ConcurrencyPolicy() v1.ConcurrencyPolicy {
+	return c.Obj.Spec.ConcurrencyPolicy
+}
+
 func (c CronJobV1) FileLocation() ks.FileLocation {
 	return c.Location
 }
@@ -11,7 +11,7 @@ import (
 )
 
 type Appsv1DaemonSet struct {
-	appsv1.DaemonSet
+	Obj      appsv1.DaemonSet
 	Location ks.FileLocation
 }
 
@@ -20,16 +20,20 @@ func (d Appsv1DaemonSet) FileLocation() ks.FileLocation {
 }
 
 func (d Appsv1DaemonSet) GetTypeMeta() metav1.TypeMeta {
-	return d.TypeMeta
+	return d.Obj.TypeMeta
 }
 
 func (d Appsv1DaemonSet) GetObjectMeta() metav1.ObjectMeta {
-	return d.ObjectMeta
+	return d.Obj.ObjectMeta
 }
 
 func (d Appsv1DaemonSet) GetPodTemplateSpec() corev1.PodTemplateSpec {
-	d.Spec.Template.ObjectMeta.Namespace = d.ObjectMeta.Namespace
-	return d.Spec.Template
+	d.Obj.Spec.Template.ObjectMeta.Namespace = d.Obj.ObjectMeta.Namespace
+	return d.Obj.Spec.Template
+}
+
+func (d Appsv1DaemonSet) DaemonSet() appsv1.DaemonSet {
+	return d.Obj
 }
 
 type Appsv1beta2DaemonSet struct {
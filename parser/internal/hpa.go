@@ -30,6 +30,14 @@ func (d HPAv1) HpaTarget() autoscalingv1.CrossVersionObjectReference {
 	return d.Spec.ScaleTargetRef
 }
 
+func (d HPAv1) MinReplicas() *int32 {
+	return d.Spec.MinReplicas
+}
+
+func (d HPAv1) MaxReplicas() int32 {
+	return d.Spec.MaxReplicas
+}
+
 type HPAv2beta1 struct {
 	autoscalingv2beta1.HorizontalPodAutoscaler
 	Location ks.FileLocation
@@ -51,6 +59,14 @@ func (d HPAv2beta1) HpaTarget() autoscalingv1.CrossVersionObjectReference {
 	return autoscalingv1.CrossVersionObjectReference(d.Spec.ScaleTargetRef)
 }
 
+func (d HPAv2beta1) MinReplicas() *int32 {
+	return d.Spec.MinReplicas
+}
+
+func (d HPAv2beta1) MaxReplicas() int32 {
+	return d.Spec.MaxReplicas
+}
+
 type HPAv2beta2 struct {
 	autoscalingv2beta2.HorizontalPodAutoscaler
 	Location ks.FileLocation
@@ -71,3 +87,11 @@ func (d HPAv2beta2) GetObjectMeta() metav1.ObjectMeta {
 func (d HPAv2beta2) HpaTarget() autoscalingv1.CrossVersionObjectReference {
 	return autoscalingv1.CrossVersionObjectReference(d.Spec.ScaleTargetRef)
 }
+
+func (d HPAv2beta2) MinReplicas() *int32 {
+	return d.Spec.MinReplicas
+}
+
+func (d HPAv2beta2) MaxReplicas() int32 {
+	return d.Spec.MaxReplicas
+}
@@ -30,6 +30,10 @@ func (i IngressV1) GetTypeMeta() v1.TypeMeta {
 	return i.TypeMeta
 }
 
+func (i IngressV1) IngressClassName() *string {
+	return i.Spec.IngressClassName
+}
+
 func (i IngressV1) Rules() []networkingv1.IngressRule {
 	return i.Spec.Rules
 }
@@ -51,13 +55,18 @@ func (i IngressV1beta1) GetTypeMeta() v1.TypeMeta {
 	return i.TypeMeta
 }
 
+func (i IngressV1beta1) IngressClassName() *string {
+	return i.Spec.IngressClassName
+}
+
 func (i IngressV1beta1) Rules() []networkingv1.IngressRule {
 	var res []networkingv1.IngressRule
 
 	paths := func(in []networkingv1beta1.HTTPIngressPath) (out []networkingv1.HTTPIngressPath) {
 		for _, path := range in {
 			out = append(out, networkingv1.HTTPIngressPath{
-				Path: path.Path,
+				Path:     path.Path,
+				PathType: (*networkingv1.PathType)(path.PathType),
 				Backend: networkingv1.IngressBackend{
 					Service: &networkingv1.IngressServiceBackend{
 						Name: path.Backend.ServiceName,
@@ -104,13 +113,18 @@ func (i ExtensionsIngressV1beta1) GetTypeMeta() v1.TypeMeta {
 	return i.TypeMeta
 }
 
+func (i ExtensionsIngressV1beta1) IngressClassName() *string {
+	return i.Spec.IngressClassName
+}
+
 func (i ExtensionsIngressV1beta1) Rules() []networkingv1.IngressRule {
 	var res []networkingv1.IngressRule
 
 	paths := func(in []extensionsv1beta1.HTTPIngressPath) (out []networkingv1.HTTPIngressPath) {
 		for _, path := range in {
 			out = append(out, networkingv1.HTTPIngressPath{
-				Path: path.Path,
+				Path:     path.Path,
+				PathType: (*networkingv1.PathType)(path.PathType),
 				Backend: networkingv1.IngressBackend{
 					Service: &networkingv1.IngressServiceBackend{
 						Name: path.Backend.ServiceName,
@@ -0,0 +1,20 @@
+package namespace
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	ks "github.com/zegl/kube-score/domain"
+)
+
+type Namespace struct {
+	Obj      corev1.Namespace
+	Location ks.FileLocation
+}
+
+func (n Namespace) Namespace() corev1.Namespace {
+	return n.Obj
+}
+
+func (n Namespace) FileLocation() ks.FileLocation {
+	return n.Location
+}
@@ -0,0 +1,30 @@
+package unknown
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ks "github.com/zegl/kube-score/domain"
+)
+
+type Object struct {
+	TypeMeta   metav1.TypeMeta
+	ObjectMeta metav1.ObjectMeta
+	Suggested  *metav1.TypeMeta
+	Location   ks.FileLocation
+}
+
+func (o Object) GetTypeMeta() metav1.TypeMeta {
+	return o.TypeMeta
+}
+
+func (o Object) GetObjectMeta() metav1.ObjectMeta {
+	return o.ObjectMeta
+}
+
+func (o Object) Suggestion() *metav1.TypeMeta {
+	return o.Suggested
+}
+
+func (o Object) FileLocation() ks.FileLocation {
+	return o.Location
+}
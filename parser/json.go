@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/zegl/kube-score/config"
+)
+
+// isJSON returns true if the file should be parsed as JSON instead of YAML
+func isJSON(cnf config.Configuration, fileName string) bool {
+	switch cnf.ManifestFormat {
+	case config.ManifestFormatJSON:
+		return true
+	case config.ManifestFormatYAML:
+		return false
+	default:
+		return strings.HasSuffix(strings.ToLower(fileName), ".json")
+	}
+}
+
+// jsonDocument is a single object in a JSON file
+type jsonDocument struct {
+	raw  []byte
+	line int
+
+	// index is the position of the object in the top-level array that it's part of, or -1 if it's not in an array
+	index int
+}
+
+// jsonDocuments splits a JSON file into its objects. The file can contain a single object, a stream of objects such
+// as newline delimited JSON, or top-level arrays of objects.
+func jsonDocuments(data []byte) ([]jsonDocument, error) {
+	reader := bytes.NewReader(data)
+	dec := json.NewDecoder(reader)
+
+	// offset returns the position in data of the next value that the decoder reads
+	offset := func() int {
+		buffered, _ := ioutil.ReadAll(dec.Buffered())
+		pos := len(data) - reader.Len() - len(buffered)
+		for pos < len(data) && strings.IndexByte(" \t\r\n,", data[pos]) >= 0 {
+			pos++
+		}
+		return pos
+	}
+
+	var docs []jsonDocument
+	decodeObject := func(index int) error {
+		start := offset()
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		line := 1 + bytes.Count(data[:start], []byte("\n"))
+		if len(raw) == 0 || raw[0] != '{' {
+			return fmt.Errorf("expected a JSON object on line %d", line)
+		}
+		docs = append(docs, jsonDocument{raw: raw, line: line, index: index})
+		return nil
+	}
+
+	for {
+		start := offset()
+		if start == len(data) {
+			return docs, nil
+		}
+
+		if data[start] != '[' {
+			if err := decodeObject(-1); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// Consume the opening bracket, and decode each item of the array
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		for i := 0; dec.More(); i++ {
+			if err := decodeObject(i); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// jsonItemPath returns the name that is used as the file name of the object at index i in a top-level array, on the
+// format "file.json#[3]"
+func jsonItemPath(fileName string, i int) string {
+	return fmt.Sprintf("%s#[%d]", fileName, i)
+}
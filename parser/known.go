@@ -0,0 +1,289 @@
+package parser
+
+import (
+	"sort"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	apiserverinternalv1alpha1 "k8s.io/api/apiserverinternal/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authenticationv1beta1 "k8s.io/api/authentication/v1beta1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	authorizationv1beta1 "k8s.io/api/authorization/v1beta1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	coordinationv1beta1 "k8s.io/api/coordination/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+	eventsv1 "k8s.io/api/events/v1"
+	eventsv1beta1 "k8s.io/api/events/v1beta1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	flowcontrolv1alpha1 "k8s.io/api/flowcontrol/v1alpha1"
+	flowcontrolv1beta1 "k8s.io/api/flowcontrol/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	nodev1 "k8s.io/api/node/v1"
+	nodev1alpha1 "k8s.io/api/node/v1alpha1"
+	nodev1beta1 "k8s.io/api/node/v1beta1"
+	policyv1 "k8s.io/api/policy/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	rbacv1alpha1 "k8s.io/api/rbac/v1alpha1"
+	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	schedulingv1alpha1 "k8s.io/api/scheduling/v1alpha1"
+	schedulingv1beta1 "k8s.io/api/scheduling/v1beta1"
+	storagev1 "k8s.io/api/storage/v1"
+	storagev1alpha1 "k8s.io/api/storage/v1alpha1"
+	storagev1beta1 "k8s.io/api/storage/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+// knownKinds are the versions of all kinds that are built into Kubernetes, by group and kind. It's used to find
+// objects with a misspelled kind or apiVersion, which would otherwise be skipped in the same way as objects of
+// kinds that kube-score doesn't check. The versions are only used for suggestions, since new versions of the
+// built-in kinds are added in most releases of Kubernetes.
+var knownKinds = make(map[string]map[string][]string)
+
+// newerKinds are built-in kinds, or versions of them, that have been added to Kubernetes after the version of
+// k8s.io/api that kube-score is built with
+var newerKinds = []schema.GroupVersionKind{
+	{Group: "autoscaling", Version: "v2", Kind: "HorizontalPodAutoscaler"},
+	{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta2", Kind: "FlowSchema"},
+	{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta2", Kind: "PriorityLevelConfiguration"},
+	{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta3", Kind: "FlowSchema"},
+	{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta3", Kind: "PriorityLevelConfiguration"},
+	{Group: "flowcontrol.apiserver.k8s.io", Version: "v1", Kind: "FlowSchema"},
+	{Group: "flowcontrol.apiserver.k8s.io", Version: "v1", Kind: "PriorityLevelConfiguration"},
+	{Group: "admissionregistration.k8s.io", Version: "v1alpha1", Kind: "ValidatingAdmissionPolicy"},
+	{Group: "admissionregistration.k8s.io", Version: "v1alpha1", Kind: "ValidatingAdmissionPolicyBinding"},
+	{Group: "admissionregistration.k8s.io", Version: "v1alpha1", Kind: "MutatingAdmissionPolicy"},
+	{Group: "admissionregistration.k8s.io", Version: "v1alpha1", Kind: "MutatingAdmissionPolicyBinding"},
+	{Group: "admissionregistration.k8s.io", Version: "v1beta1", Kind: "ValidatingAdmissionPolicy"},
+	{Group: "admissionregistration.k8s.io", Version: "v1beta1", Kind: "ValidatingAdmissionPolicyBinding"},
+	{Group: "admissionregistration.k8s.io", Version: "v1", Kind: "ValidatingAdmissionPolicy"},
+	{Group: "admissionregistration.k8s.io", Version: "v1", Kind: "ValidatingAdmissionPolicyBinding"},
+	{Group: "storage.k8s.io", Version: "v1", Kind: "CSIStorageCapacity"},
+	{Group: "storage.k8s.io", Version: "v1alpha1", Kind: "VolumeAttributesClass"},
+	{Group: "admissionregistration.k8s.io", Version: "v1beta1", Kind: "MutatingAdmissionPolicy"},
+	{Group: "admissionregistration.k8s.io", Version: "v1beta1", Kind: "MutatingAdmissionPolicyBinding"},
+	{Group: "storage.k8s.io", Version: "v1beta1", Kind: "VolumeAttributesClass"},
+	{Group: "storage.k8s.io", Version: "v1", Kind: "VolumeAttributesClass"},
+	{Group: "networking.k8s.io", Version: "v1alpha1", Kind: "IPAddress"},
+	{Group: "networking.k8s.io", Version: "v1alpha1", Kind: "ServiceCIDR"},
+	{Group: "networking.k8s.io", Version: "v1beta1", Kind: "IPAddress"},
+	{Group: "networking.k8s.io", Version: "v1beta1", Kind: "ServiceCIDR"},
+	{Group: "networking.k8s.io", Version: "v1", Kind: "IPAddress"},
+	{Group: "networking.k8s.io", Version: "v1", Kind: "ServiceCIDR"},
+	{Group: "certificates.k8s.io", Version: "v1alpha1", Kind: "ClusterTrustBundle"},
+	{Group: "certificates.k8s.io", Version: "v1beta1", Kind: "ClusterTrustBundle"},
+	{Group: "coordination.k8s.io", Version: "v1alpha1", Kind: "LeaseCandidate"},
+	{Group: "coordination.k8s.io", Version: "v1beta1", Kind: "LeaseCandidate"},
+	{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"},
+	{Group: "apiextensions.k8s.io", Version: "v1beta1", Kind: "CustomResourceDefinition"},
+	{Group: "apiregistration.k8s.io", Version: "v1", Kind: "APIService"},
+	{Group: "apiregistration.k8s.io", Version: "v1beta1", Kind: "APIService"},
+}
+
+func init() {
+	known := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		admissionregistrationv1.AddToScheme, admissionregistrationv1beta1.AddToScheme,
+		apiserverinternalv1alpha1.AddToScheme,
+		appsv1.AddToScheme, appsv1beta1.AddToScheme, appsv1beta2.AddToScheme,
+		authenticationv1.AddToScheme, authenticationv1beta1.AddToScheme,
+		authorizationv1.AddToScheme, authorizationv1beta1.AddToScheme,
+		autoscalingv1.AddToScheme, autoscalingv2beta1.AddToScheme, autoscalingv2beta2.AddToScheme,
+		batchv1.AddToScheme, batchv1beta1.AddToScheme,
+		certificatesv1.AddToScheme, certificatesv1beta1.AddToScheme,
+		coordinationv1.AddToScheme, coordinationv1beta1.AddToScheme,
+		corev1.AddToScheme,
+		discoveryv1.AddToScheme, discoveryv1beta1.AddToScheme,
+		eventsv1.AddToScheme, eventsv1beta1.AddToScheme,
+		extensionsv1beta1.AddToScheme,
+		flowcontrolv1alpha1.AddToScheme, flowcontrolv1beta1.AddToScheme,
+		networkingv1.AddToScheme, networkingv1beta1.AddToScheme,
+		nodev1.AddToScheme, nodev1alpha1.AddToScheme, nodev1beta1.AddToScheme,
+		policyv1.AddToScheme, policyv1beta1.AddToScheme,
+		rbacv1.AddToScheme, rbacv1alpha1.AddToScheme, rbacv1beta1.AddToScheme,
+		schedulingv1.AddToScheme, schedulingv1alpha1.AddToScheme, schedulingv1beta1.AddToScheme,
+		storagev1.AddToScheme, storagev1alpha1.AddToScheme, storagev1beta1.AddToScheme,
+	} {
+		if err := addToScheme(known); err != nil {
+			panic(err)
+		}
+	}
+
+	// The kinds that are added to all groups, such as ListOptions and WatchEvent, are not objects
+	metaKinds := runtime.NewScheme()
+	metav1.AddToGroupVersion(metaKinds, schema.GroupVersion{Version: "v1"})
+	isMetaKind := func(kind string) bool {
+		_, ok := metaKinds.AllKnownTypes()[schema.GroupVersionKind{Version: "v1", Kind: kind}]
+		return ok
+	}
+
+	gvks := newerKinds
+	for gvk := range known.AllKnownTypes() {
+		if !isMetaKind(gvk.Kind) && !strings.HasSuffix(gvk.Kind, "List") && !strings.HasSuffix(gvk.Kind, "Options") {
+			gvks = append(gvks, gvk)
+		}
+	}
+
+	for _, gvk := range gvks {
+		if _, ok := knownKinds[gvk.Group]; !ok {
+			knownKinds[gvk.Group] = make(map[string][]string)
+		}
+		knownKinds[gvk.Group][gvk.Kind] = append(knownKinds[gvk.Group][gvk.Kind], gvk.Version)
+	}
+
+	// The most stable version of each kind is first
+	for _, kinds := range knownKinds {
+		for _, versions := range kinds {
+			sort.Slice(versions, func(i, j int) bool {
+				return version.CompareKubeAwareVersionStrings(versions[i], versions[j]) > 0
+			})
+		}
+	}
+}
+
+// isUnknownKind returns true if the kind is not known to Kubernetes in any version of the group, together with the
+// closest known apiVersion and kind if there is one that is similar enough. Kinds in groups that are not built into
+// Kubernetes are assumed to be custom resources, all custom resources have a group with a "." in the name.
+func isUnknownKind(gvk schema.GroupVersionKind) (bool, *metav1.TypeMeta) {
+	// Documents without any content, such as documents with only comments
+	if gvk.Empty() {
+		return false, nil
+	}
+
+	kinds, builtIn := knownKinds[gvk.Group]
+	if !builtIn && strings.Contains(gvk.Group, ".") {
+		return false, nil
+	}
+
+	// The version is not checked, a built-in kind can have newer versions than the ones that are known here
+	if _, ok := kinds[gvk.Kind]; ok {
+		return false, nil
+	}
+
+	return true, closestKnownKind(gvk)
+}
+
+// maxKindDistance is the largest number of edits to the kind for a known kind to be suggested
+const maxKindDistance = 2
+
+// closestKnownKind returns the known kind with the lowest edit distance to the kind, preferring kinds in the same
+// group, or nil if no kind is close enough
+func closestKnownKind(gvk schema.GroupVersionKind) *metav1.TypeMeta {
+	var groups []string
+	for group := range knownKinds {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	var best *schema.GroupVersionKind
+	bestDistance := 0
+	for _, group := range groups {
+		var kinds []string
+		for kind := range knownKinds[group] {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+
+		for _, kind := range kinds {
+			kindDistance := editDistance(strings.ToLower(gvk.Kind), strings.ToLower(kind))
+			if kindDistance > maxKindDistance {
+				continue
+			}
+			// Differences in casing are still differences, but smaller than any other edit
+			distance := 4 * kindDistance
+			if kind != gvk.Kind {
+				distance++
+			}
+			if group != gvk.Group {
+				distance += 2
+			}
+			if best == nil || distance < bestDistance {
+				best = &schema.GroupVersionKind{Group: group, Kind: kind}
+				bestDistance = distance
+			}
+		}
+	}
+
+	if best == nil {
+		return nil
+	}
+
+	// Keep the version if the suggested kind has it, or if it's more stable than all known versions of the suggested
+	// kind, otherwise suggest the most stable version
+	versions := knownKinds[best.Group][best.Kind]
+	best.Version = versions[0]
+	for _, v := range versions {
+		if v == gvk.Version {
+			best.Version = v
+		}
+	}
+	if version.CompareKubeAwareVersionStrings(gvk.Version, best.Version) > 0 {
+		best.Version = gvk.Version
+	}
+
+	apiVersion, kind := best.ToAPIVersionAndKind()
+	return &metav1.TypeMeta{APIVersion: apiVersion, Kind: kind}
+}
+
+// isList returns true for the v1 List kind, and for the list kinds of the kinds that are built into Kubernetes, such
+// as apps/v1 DeploymentList. Custom resources can have kinds that end with "List" without being lists.
+func isList(gvk schema.GroupVersionKind) bool {
+	if gvk.Group == "" && gvk.Kind == "List" {
+		return true
+	}
+	if !strings.HasSuffix(gvk.Kind, "List") {
+		return false
+	}
+	_, ok := knownKinds[gvk.Group][strings.TrimSuffix(gvk.Kind, "List")]
+	return ok
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,153 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	sigsyaml "sigs.k8s.io/yaml"
+
+	"github.com/zegl/kube-score/config"
+)
+
+// unmergedItem is an object that has been read, but not decoded, when config.MergeSameIdentity is enabled
+type unmergedItem struct {
+	gvk        schema.GroupVersionKind
+	fileName   string
+	fileOffset int
+	raw        []byte
+}
+
+type identityMeta struct {
+	Metadata struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"metadata"`
+}
+
+// mergeAndDecode merges the items that have the same apiVersion, kind, namespace and name, and decodes the result.
+//
+// The items are merged in the order that they were read, each item is applied as a patch on top of the previous
+// ones. Kinds that are known to kube-score are merged with a strategic merge patch, the same way as kubectl patch,
+// where lists such as containers are merged by their merge key. Other kinds, such as custom resources, are merged with
+// a JSON merge patch (RFC 7386), where lists are replaced. In both cases, a scalar field that is set in multiple items
+// gets the value from the last item, and a field that is set to null is removed.
+//
+// The merged object is reported at the location of the first item. Objects without a name are never merged.
+func mergeAndDecode(cnf config.Configuration, s *parsedObjects, items []unmergedItem) error {
+	var order []string
+	groups := make(map[string][]unmergedItem)
+
+	for i, item := range items {
+		var meta identityMeta
+		if err := yaml.Unmarshal(item.raw, &meta); err != nil {
+			return err
+		}
+
+		key := fmt.Sprintf("%s/%s/%s/%s", item.gvk.GroupVersion().String(), item.gvk.Kind, meta.Metadata.Namespace, meta.Metadata.Name)
+		if meta.Metadata.Name == "" {
+			key = fmt.Sprintf("unnamed/%d", i)
+		}
+
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], item)
+	}
+
+	for _, key := range order {
+		group := groups[key]
+		first := group[0]
+
+		if len(group) == 1 {
+			if err := decodeItem(cnf, s, first.gvk, first.fileName, first.fileOffset, first.raw); err != nil {
+				return err
+			}
+			continue
+		}
+
+		merged, err := mergeItems(group)
+		if err != nil {
+			return fmt.Errorf("failed to merge %s: %w", key, err)
+		}
+
+		for _, item := range group[1:] {
+			cnf.Logger.Info("Merged object", "file", item.fileName, "line", item.fileOffset, "into", first.fileName, "intoLine", first.fileOffset)
+		}
+
+		// The merged object is JSON, resolve the location before the Helm comment of the first item is lost
+		location := detectFileLocation(first.fileName, first.fileOffset, first.raw)
+		if err := decodeItem(cnf, s, first.gvk, location.Name, location.Line, merged); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mergeItems returns the JSON of all items merged together, see mergeAndDecode
+func mergeItems(items []unmergedItem) ([]byte, error) {
+	merged, err := sigsyaml.YAMLToJSON(items[0].raw)
+	if err != nil {
+		return nil, err
+	}
+
+	dataStruct, err := scheme.New(items[0].gvk)
+	if err != nil {
+		// Not known to the scheme, fall back to a JSON merge patch
+		dataStruct = nil
+	}
+
+	for _, item := range items[1:] {
+		patch, err := sigsyaml.YAMLToJSON(item.raw)
+		if err != nil {
+			return nil, err
+		}
+
+		if dataStruct != nil {
+			merged, err = strategicpatch.StrategicMergePatch(merged, patch, dataStruct)
+		} else {
+			merged, err = jsonMergePatch(merged, patch)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", item.fileName, item.fileOffset, err)
+		}
+	}
+
+	return merged, nil
+}
+
+// jsonMergePatch applies patch to original as a JSON merge patch, as defined in RFC 7386
+func jsonMergePatch(original, patch []byte) ([]byte, error) {
+	var o, p interface{}
+	if err := json.Unmarshal(original, &o); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergeValue(o, p))
+}
+
+func mergeValue(original, patch interface{}) interface{} {
+	patchMap, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	originalMap, ok := original.(map[string]interface{})
+	if !ok {
+		originalMap = make(map[string]interface{})
+	}
+
+	for k, v := range patchMap {
+		if v == nil {
+			delete(originalMap, k)
+			continue
+		}
+		originalMap[k] = mergeValue(originalMap[k], v)
+	}
+	return originalMap
+}
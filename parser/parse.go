@@ -22,18 +22,27 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	sigsyaml "sigs.k8s.io/yaml"
 
 	"github.com/zegl/kube-score/config"
 	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/parser/internal"
+	internalconfigmap "github.com/zegl/kube-score/parser/internal/configmap"
 	internalcronjob "github.com/zegl/kube-score/parser/internal/cronjob"
+	internalnamespace "github.com/zegl/kube-score/parser/internal/namespace"
 	internalnetpol "github.com/zegl/kube-score/parser/internal/networkpolicy"
 	internalpdb "github.com/zegl/kube-score/parser/internal/pdb"
 	internalpod "github.com/zegl/kube-score/parser/internal/pod"
+	internalpvc "github.com/zegl/kube-score/parser/internal/pvc"
+	internalrbac "github.com/zegl/kube-score/parser/internal/rbac"
+	internalsecret "github.com/zegl/kube-score/parser/internal/secret"
 	internalservice "github.com/zegl/kube-score/parser/internal/service"
+	internalunknown "github.com/zegl/kube-score/parser/internal/unknown"
 )
 
 var scheme = runtime.NewScheme()
@@ -53,6 +62,7 @@ func addToScheme(scheme *runtime.Scheme) {
 	batchv1.AddToScheme(scheme)
 	batchv1beta1.AddToScheme(scheme)
 	policyv1beta1.AddToScheme(scheme)
+	rbacv1.AddToScheme(scheme)
 }
 
 type detectKind struct {
@@ -68,10 +78,28 @@ type parsedObjects struct {
 	services             []ks.Service
 	podDisruptionBudgets []ks.PodDisruptionBudget
 	deployments          []ks.Deployment
+	daemonsets           []ks.DaemonSet
 	statefulsets         []ks.StatefulSet
 	ingresses            []ks.Ingress // supports multiple versions of ingress
 	cronjobs             []ks.CronJob
 	hpaTargeters         []ks.HpaTargeter // all versions of HPAs
+	pvcs                 []ks.PersistentVolumeClaim
+	configMaps           []ks.ConfigMap
+	secrets              []ks.Secret
+	namespaces           []ks.Namespace
+	roles                []ks.Role        // both Roles and ClusterRoles
+	roleBindings         []ks.RoleBinding // both RoleBindings and ClusterRoleBindings, these are not scored
+	unknownObjects       []ks.UnknownObject
+	invalidQuantities    []ks.InvalidQuantity
+	documents            []ks.Document
+
+	// unmerged are decoded by mergeAndDecode when all files have been read, if config.MergeSameIdentity is set
+	unmerged []unmergedItem
+}
+
+// objectCount returns the number of objects that have been read so far
+func (p *parsedObjects) objectCount() int {
+	return len(p.bothMetas) + len(p.unmerged)
 }
 
 func (p *parsedObjects) Services() []ks.Service {
@@ -102,6 +130,10 @@ func (p *parsedObjects) Deployments() []ks.Deployment {
 	return p.deployments
 }
 
+func (p *parsedObjects) DaemonSets() []ks.DaemonSet {
+	return p.daemonsets
+}
+
 func (p *parsedObjects) StatefulSets() []ks.StatefulSet {
 	return p.statefulsets
 }
@@ -118,6 +150,52 @@ func (p *parsedObjects) HorizontalPodAutoscalers() []ks.HpaTargeter {
 	return p.hpaTargeters
 }
 
+func (p *parsedObjects) PersistentVolumeClaims() []ks.PersistentVolumeClaim {
+	return p.pvcs
+}
+
+func (p *parsedObjects) ConfigMaps() []ks.ConfigMap {
+	return p.configMaps
+}
+
+func (p *parsedObjects) Secrets() []ks.Secret {
+	return p.secrets
+}
+
+func (p *parsedObjects) Namespaces() []ks.Namespace {
+	return p.namespaces
+}
+
+func (p *parsedObjects) Roles() []ks.Role {
+	return p.roles
+}
+
+func (p *parsedObjects) RoleBindings() []ks.RoleBinding {
+	return p.roleBindings
+}
+
+func (p *parsedObjects) UnknownObjects() []ks.UnknownObject {
+	return p.unknownObjects
+}
+
+// addInvalidQuantities adds the invalid quantities that were removed from the document
+func (p *parsedObjects) addInvalidQuantities(document ks.Document, invalid []ks.InvalidQuantity) {
+	for _, q := range invalid {
+		q.TypeMeta = document.TypeMeta
+		q.ObjectMeta = document.ObjectMeta
+		q.Location = document.Location
+		p.invalidQuantities = append(p.invalidQuantities, q)
+	}
+}
+
+func (p *parsedObjects) InvalidQuantities() []ks.InvalidQuantity {
+	return p.invalidQuantities
+}
+
+func (p *parsedObjects) Documents() []ks.Document {
+	return p.documents
+}
+
 func Empty() ks.AllTypes {
 	return &parsedObjects{}
 }
@@ -134,6 +212,28 @@ func ParseFiles(cnf config.Configuration) (ks.AllTypes, error) {
 		// Convert to unix style newlines
 		fullFile = bytes.Replace(fullFile, []byte("\r\n"), []byte("\n"), -1)
 
+		objectsBefore := s.objectCount()
+
+		if isJSON(cnf, namedReader.Name()) {
+			docs, err := jsonDocuments(fullFile)
+			if err != nil {
+				cnf.Logger.Error("Failed to parse JSON", "file", namedReader.Name(), "error", err)
+				return nil, fmt.Errorf("failed to parse %s: %w", namedReader.Name(), err)
+			}
+			for _, doc := range docs {
+				fileName := namedReader.Name()
+				if doc.index >= 0 {
+					fileName = jsonItemPath(fileName, doc.index)
+				}
+				if err := detectAndDecode(cnf, s, fileName, doc.line, doc.raw); err != nil {
+					cnf.Logger.Error("Failed to parse object", "file", fileName, "line", doc.line, "error", err)
+					return nil, err
+				}
+			}
+			cnf.Logger.Info("Loaded file", "file", namedReader.Name(), "objects", s.objectCount()-objectsBefore)
+			continue
+		}
+
 		offset := 1 // Line numbers are 1 indexed
 
 		// Remove initial "---\n" if present
@@ -147,12 +247,24 @@ func ParseFiles(cnf config.Configuration) (ks.AllTypes, error) {
 			if len(bytes.TrimSpace(fileContents)) > 0 {
 				err := detectAndDecode(cnf, s, namedReader.Name(), offset, fileContents)
 				if err != nil {
+					cnf.Logger.Error("Failed to parse object", "file", namedReader.Name(), "line", offset, "error", err)
 					return nil, err
 				}
 			}
 
 			offset += 2 + bytes.Count(fileContents, []byte("\n"))
 		}
+
+		cnf.Logger.Info("Loaded file", "file", namedReader.Name(), "objects", s.objectCount()-objectsBefore)
+	}
+
+	if cnf.MergeSameIdentity {
+		items := s.unmerged
+		s.unmerged = nil
+		if err := mergeAndDecode(cnf, s, items); err != nil {
+			cnf.Logger.Error("Failed to merge objects", "error", err)
+			return nil, err
+		}
 	}
 
 	return s, nil
@@ -167,15 +279,23 @@ func detectAndDecode(cnf config.Configuration, s *parsedObjects, fileName string
 
 	detectedVersion := schema.FromAPIVersionAndKind(detect.ApiVersion, detect.Kind)
 
-	// Parse lists and their items recursively
-	if detectedVersion == corev1.SchemeGroupVersion.WithKind("List") {
-		var list corev1.List
-		err := decode(raw, &list)
+	// Parse lists and their items recursively, both v1 List and typed lists such as apps/v1 DeploymentList
+	if isList(detectedVersion) {
+		var list listItems
+		err := yaml.Unmarshal(raw, &list)
 		if err != nil {
 			return err
 		}
-		for _, listItem := range list.Items {
-			err := detectAndDecode(cnf, s, fileName, fileOffset, listItem.Raw)
+
+		listLocation := detectFileLocation(fileName, fileOffset, raw)
+		for i := range list.Items {
+			item, err := yaml.Marshal(&list.Items[i])
+			if err != nil {
+				return err
+			}
+			// The line of the item is relative to the start of the list
+			itemLine := listLocation.Line + list.Items[i].Line - 1
+			err = detectAndDecode(cnf, s, listItemPath(listLocation.Name, i), itemLine, item)
 			if err != nil {
 				return err
 			}
@@ -183,6 +303,12 @@ func detectAndDecode(cnf config.Configuration, s *parsedObjects, fileName string
 		return nil
 	}
 
+	// Objects are decoded when all files have been read, so that objects with the same identity can be merged first
+	if cnf.MergeSameIdentity {
+		s.unmerged = append(s.unmerged, unmergedItem{gvk: detectedVersion, fileName: fileName, fileOffset: fileOffset, raw: raw})
+		return nil
+	}
+
 	err = decodeItem(cnf, s, detectedVersion, fileName, fileOffset, raw)
 	if err != nil {
 		return err
@@ -191,6 +317,20 @@ func detectAndDecode(cnf config.Configuration, s *parsedObjects, fileName string
 	return nil
 }
 
+// listItems is used to read the items of any kind of list
+type listItems struct {
+	Items []yaml.Node `yaml:"items"`
+}
+
+// listItemPath returns the name that is used as the file name of the item at index i in a list, on the format
+// "file.yaml#items[3]". Items of nested lists are named "file.yaml#items[3].items[0]".
+func listItemPath(listName string, i int) string {
+	if strings.Contains(listName, "#items[") {
+		return fmt.Sprintf("%s.items[%d]", listName, i)
+	}
+	return fmt.Sprintf("%s#items[%d]", listName, i)
+}
+
 func decode(data []byte, object runtime.Object) error {
 	deserializer := codecs.UniversalDeserializer()
 	if _, _, err := deserializer.Decode(data, nil, object); err != nil {
@@ -226,38 +366,63 @@ func decodeItem(cnf config.Configuration, s *parsedObjects, detectedVersion sche
 
 	fileLocation := detectFileLocation(fileName, fileOffset, fileContents)
 
+	// All objects are recorded as documents, also the ones that are skipped because their kind is not scored
+	var obj metav1.PartialObjectMetadata
+	if err := sigsyaml.Unmarshal(fileContents, &obj); err != nil {
+		return fmt.Errorf("%s:%d: %w", fileLocation.Name, fileLocation.Line, err)
+	}
+	apiVersion, kind := detectedVersion.ToAPIVersionAndKind()
+	document := ks.Document{
+		TypeMeta:   metav1.TypeMeta{APIVersion: apiVersion, Kind: kind},
+		ObjectMeta: obj.ObjectMeta,
+		Location:   fileLocation,
+	}
+	s.documents = append(s.documents, document)
+
+	// Invalid quantities are only removed from the kinds that are decoded with the typed Kubernetes structs, objects
+	// of other kinds are never decoded with a resource.Quantity
+	contents := fileContents
+	if scheme.Recognizes(detectedVersion) {
+		sanitized, invalidQuantities, err := removeInvalidQuantities(fileContents)
+		if err != nil {
+			return err
+		}
+		s.addInvalidQuantities(document, invalidQuantities)
+		contents = sanitized
+	}
+
 	var errs parseError
 
 	switch detectedVersion {
 	case corev1.SchemeGroupVersion.WithKind("Pod"):
 		var pod corev1.Pod
-		errs.AddIfErr(decode(fileContents, &pod))
+		errs.AddIfErr(decode(contents, &pod))
 		p := internalpod.Pod{pod, fileLocation}
 		s.pods = append(s.pods, p)
 		s.bothMetas = append(s.bothMetas, ks.BothMeta{pod.TypeMeta, pod.ObjectMeta, p})
 
 	case batchv1.SchemeGroupVersion.WithKind("Job"):
 		var job batchv1.Job
-		errs.AddIfErr(decode(fileContents, &job))
+		errs.AddIfErr(decode(contents, &job))
 		addPodSpeccer(internal.Batchv1Job{job, fileLocation})
 
 	case batchv1beta1.SchemeGroupVersion.WithKind("CronJob"):
 		var cronjob batchv1beta1.CronJob
-		errs.AddIfErr(decode(fileContents, &cronjob))
+		errs.AddIfErr(decode(contents, &cronjob))
 		cjob := internalcronjob.CronJobV1beta1{cronjob, fileLocation}
 		addPodSpeccer(cjob)
 		s.cronjobs = append(s.cronjobs, cjob)
 
 	case batchv1.SchemeGroupVersion.WithKind("CronJob"):
 		var cronjob batchv1.CronJob
-		errs.AddIfErr(decode(fileContents, &cronjob))
+		errs.AddIfErr(decode(contents, &cronjob))
 		cjob := internalcronjob.CronJobV1{cronjob, fileLocation}
 		addPodSpeccer(cjob)
 		s.cronjobs = append(s.cronjobs, cjob)
 
 	case appsv1.SchemeGroupVersion.WithKind("Deployment"):
 		var deployment appsv1.Deployment
-		errs.AddIfErr(decode(fileContents, &deployment))
+		errs.AddIfErr(decode(contents, &deployment))
 		deploy := internal.Appsv1Deployment{deployment, fileLocation}
 		addPodSpeccer(deploy)
 
@@ -265,20 +430,20 @@ func decodeItem(cnf config.Configuration, s *parsedObjects, detectedVersion sche
 		s.deployments = append(s.deployments, deploy)
 	case appsv1beta1.SchemeGroupVersion.WithKind("Deployment"):
 		var deployment appsv1beta1.Deployment
-		errs.AddIfErr(decode(fileContents, &deployment))
+		errs.AddIfErr(decode(contents, &deployment))
 		addPodSpeccer(internal.Appsv1beta1Deployment{deployment, fileLocation})
 	case appsv1beta2.SchemeGroupVersion.WithKind("Deployment"):
 		var deployment appsv1beta2.Deployment
-		errs.AddIfErr(decode(fileContents, &deployment))
+		errs.AddIfErr(decode(contents, &deployment))
 		addPodSpeccer(internal.Appsv1beta2Deployment{deployment, fileLocation})
 	case extensionsv1beta1.SchemeGroupVersion.WithKind("Deployment"):
 		var deployment extensionsv1beta1.Deployment
-		errs.AddIfErr(decode(fileContents, &deployment))
+		errs.AddIfErr(decode(contents, &deployment))
 		addPodSpeccer(internal.Extensionsv1beta1Deployment{deployment, fileLocation})
 
 	case appsv1.SchemeGroupVersion.WithKind("StatefulSet"):
 		var statefulSet appsv1.StatefulSet
-		errs.AddIfErr(decode(fileContents, &statefulSet))
+		errs.AddIfErr(decode(contents, &statefulSet))
 		sset := internal.Appsv1StatefulSet{statefulSet, fileLocation}
 		addPodSpeccer(sset)
 
@@ -286,103 +451,172 @@ func decodeItem(cnf config.Configuration, s *parsedObjects, detectedVersion sche
 		s.statefulsets = append(s.statefulsets, sset)
 	case appsv1beta1.SchemeGroupVersion.WithKind("StatefulSet"):
 		var statefulSet appsv1beta1.StatefulSet
-		errs.AddIfErr(decode(fileContents, &statefulSet))
+		errs.AddIfErr(decode(contents, &statefulSet))
 		addPodSpeccer(internal.Appsv1beta1StatefulSet{statefulSet, fileLocation})
 	case appsv1beta2.SchemeGroupVersion.WithKind("StatefulSet"):
 		var statefulSet appsv1beta2.StatefulSet
-		errs.AddIfErr(decode(fileContents, &statefulSet))
+		errs.AddIfErr(decode(contents, &statefulSet))
 		addPodSpeccer(internal.Appsv1beta2StatefulSet{statefulSet, fileLocation})
 
 	case appsv1.SchemeGroupVersion.WithKind("DaemonSet"):
 		var daemonset appsv1.DaemonSet
-		errs.AddIfErr(decode(fileContents, &daemonset))
-		addPodSpeccer(internal.Appsv1DaemonSet{daemonset, fileLocation})
+		errs.AddIfErr(decode(contents, &daemonset))
+		ds := internal.Appsv1DaemonSet{daemonset, fileLocation}
+		addPodSpeccer(ds)
+		s.daemonsets = append(s.daemonsets, ds)
 	case appsv1beta2.SchemeGroupVersion.WithKind("DaemonSet"):
 		var daemonset appsv1beta2.DaemonSet
-		errs.AddIfErr(decode(fileContents, &daemonset))
+		errs.AddIfErr(decode(contents, &daemonset))
 		addPodSpeccer(internal.Appsv1beta2DaemonSet{daemonset, fileLocation})
 	case extensionsv1beta1.SchemeGroupVersion.WithKind("DaemonSet"):
 		var daemonset extensionsv1beta1.DaemonSet
-		errs.AddIfErr(decode(fileContents, &daemonset))
+		errs.AddIfErr(decode(contents, &daemonset))
 		addPodSpeccer(internal.Extensionsv1beta1DaemonSet{daemonset, fileLocation})
 
 	case networkingv1.SchemeGroupVersion.WithKind("NetworkPolicy"):
 		var netpol networkingv1.NetworkPolicy
-		errs.AddIfErr(decode(fileContents, &netpol))
+		errs.AddIfErr(decode(contents, &netpol))
 		np := internalnetpol.NetworkPolicy{netpol, fileLocation}
 		s.networkPolicies = append(s.networkPolicies, np)
 		s.bothMetas = append(s.bothMetas, ks.BothMeta{netpol.TypeMeta, netpol.ObjectMeta, np})
 
 	case corev1.SchemeGroupVersion.WithKind("Service"):
 		var service corev1.Service
-		errs.AddIfErr(decode(fileContents, &service))
+		errs.AddIfErr(decode(contents, &service))
 		serv := internalservice.Service{service, fileLocation}
 		s.services = append(s.services, serv)
 		s.bothMetas = append(s.bothMetas, ks.BothMeta{service.TypeMeta, service.ObjectMeta, serv})
 
+	case corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"):
+		var pvc corev1.PersistentVolumeClaim
+		errs.AddIfErr(decode(contents, &pvc))
+		p := internalpvc.PersistentVolumeClaim{pvc, fileLocation}
+		s.pvcs = append(s.pvcs, p)
+		s.bothMetas = append(s.bothMetas, ks.BothMeta{pvc.TypeMeta, pvc.ObjectMeta, p})
+
+	case corev1.SchemeGroupVersion.WithKind("ConfigMap"):
+		var configMap corev1.ConfigMap
+		errs.AddIfErr(decode(contents, &configMap))
+		cm := internalconfigmap.ConfigMap{configMap, fileLocation}
+		s.configMaps = append(s.configMaps, cm)
+		s.bothMetas = append(s.bothMetas, ks.BothMeta{configMap.TypeMeta, configMap.ObjectMeta, cm})
+
+	case corev1.SchemeGroupVersion.WithKind("Secret"):
+		var secret corev1.Secret
+		errs.AddIfErr(decode(contents, &secret))
+		sec := internalsecret.Secret{secret, fileLocation}
+		s.secrets = append(s.secrets, sec)
+		s.bothMetas = append(s.bothMetas, ks.BothMeta{secret.TypeMeta, secret.ObjectMeta, sec})
+
+	case corev1.SchemeGroupVersion.WithKind("Namespace"):
+		var namespace corev1.Namespace
+		errs.AddIfErr(decode(contents, &namespace))
+		ns := internalnamespace.Namespace{namespace, fileLocation}
+		s.namespaces = append(s.namespaces, ns)
+		s.bothMetas = append(s.bothMetas, ks.BothMeta{namespace.TypeMeta, namespace.ObjectMeta, ns})
+
+	case rbacv1.SchemeGroupVersion.WithKind("Role"):
+		var role rbacv1.Role
+		errs.AddIfErr(decode(contents, &role))
+		r := internalrbac.Role{role, fileLocation}
+		s.roles = append(s.roles, r)
+		s.bothMetas = append(s.bothMetas, ks.BothMeta{role.TypeMeta, role.ObjectMeta, r})
+
+	case rbacv1.SchemeGroupVersion.WithKind("ClusterRole"):
+		var clusterRole rbacv1.ClusterRole
+		errs.AddIfErr(decode(contents, &clusterRole))
+		r := internalrbac.ClusterRole{clusterRole, fileLocation}
+		s.roles = append(s.roles, r)
+		s.bothMetas = append(s.bothMetas, ks.BothMeta{clusterRole.TypeMeta, clusterRole.ObjectMeta, r})
+
+	case rbacv1.SchemeGroupVersion.WithKind("RoleBinding"):
+		var binding rbacv1.RoleBinding
+		errs.AddIfErr(decode(contents, &binding))
+		s.roleBindings = append(s.roleBindings, internalrbac.RoleBinding{binding, fileLocation})
+
+	case rbacv1.SchemeGroupVersion.WithKind("ClusterRoleBinding"):
+		var binding rbacv1.ClusterRoleBinding
+		errs.AddIfErr(decode(contents, &binding))
+		s.roleBindings = append(s.roleBindings, internalrbac.ClusterRoleBinding{binding, fileLocation})
+
 	case policyv1beta1.SchemeGroupVersion.WithKind("PodDisruptionBudget"):
 		var disruptBudget policyv1beta1.PodDisruptionBudget
-		errs.AddIfErr(decode(fileContents, &disruptBudget))
+		errs.AddIfErr(decode(contents, &disruptBudget))
 		dbug := internalpdb.PodDisruptionBudgetV1beta1{disruptBudget, fileLocation}
 		s.podDisruptionBudgets = append(s.podDisruptionBudgets, dbug)
 		s.bothMetas = append(s.bothMetas, ks.BothMeta{disruptBudget.TypeMeta, disruptBudget.ObjectMeta, dbug})
 	case policyv1.SchemeGroupVersion.WithKind("PodDisruptionBudget"):
 		var disruptBudget policyv1.PodDisruptionBudget
-		errs.AddIfErr(decode(fileContents, &disruptBudget))
+		errs.AddIfErr(decode(contents, &disruptBudget))
 		dbug := internalpdb.PodDisruptionBudgetV1{disruptBudget, fileLocation}
 		s.podDisruptionBudgets = append(s.podDisruptionBudgets, dbug)
 		s.bothMetas = append(s.bothMetas, ks.BothMeta{disruptBudget.TypeMeta, disruptBudget.ObjectMeta, dbug})
 
 	case extensionsv1beta1.SchemeGroupVersion.WithKind("Ingress"):
 		var ingress extensionsv1beta1.Ingress
-		errs.AddIfErr(decode(fileContents, &ingress))
+		errs.AddIfErr(decode(contents, &ingress))
 		ing := internal.ExtensionsIngressV1beta1{ingress, fileLocation}
 		s.ingresses = append(s.ingresses, ing)
 		s.bothMetas = append(s.bothMetas, ks.BothMeta{ingress.TypeMeta, ingress.ObjectMeta, ing})
 
 	case networkingv1beta1.SchemeGroupVersion.WithKind("Ingress"):
 		var ingress networkingv1beta1.Ingress
-		errs.AddIfErr(decode(fileContents, &ingress))
+		errs.AddIfErr(decode(contents, &ingress))
 		ing := internal.IngressV1beta1{ingress, fileLocation}
 		s.ingresses = append(s.ingresses, ing)
 		s.bothMetas = append(s.bothMetas, ks.BothMeta{ingress.TypeMeta, ingress.ObjectMeta, ing})
 
 	case networkingv1.SchemeGroupVersion.WithKind("Ingress"):
 		var ingress networkingv1.Ingress
-		errs.AddIfErr(decode(fileContents, &ingress))
+		errs.AddIfErr(decode(contents, &ingress))
 		ing := internal.IngressV1{ingress, fileLocation}
 		s.ingresses = append(s.ingresses, ing)
 		s.bothMetas = append(s.bothMetas, ks.BothMeta{ingress.TypeMeta, ingress.ObjectMeta, ing})
 
 	case autoscalingv1.SchemeGroupVersion.WithKind("HorizontalPodAutoscaler"):
 		var hpa autoscalingv1.HorizontalPodAutoscaler
-		errs.AddIfErr(decode(fileContents, &hpa))
+		errs.AddIfErr(decode(contents, &hpa))
 		h := internal.HPAv1{hpa, fileLocation}
 		s.hpaTargeters = append(s.hpaTargeters, h)
 		s.bothMetas = append(s.bothMetas, ks.BothMeta{hpa.TypeMeta, hpa.ObjectMeta, h})
 
 	case autoscalingv2beta1.SchemeGroupVersion.WithKind("HorizontalPodAutoscaler"):
 		var hpa autoscalingv2beta1.HorizontalPodAutoscaler
-		errs.AddIfErr(decode(fileContents, &hpa))
+		errs.AddIfErr(decode(contents, &hpa))
 		h := internal.HPAv2beta1{hpa, fileLocation}
 		s.hpaTargeters = append(s.hpaTargeters, h)
 		s.bothMetas = append(s.bothMetas, ks.BothMeta{hpa.TypeMeta, hpa.ObjectMeta, h})
 
 	case autoscalingv2beta2.SchemeGroupVersion.WithKind("HorizontalPodAutoscaler"):
 		var hpa autoscalingv2beta2.HorizontalPodAutoscaler
-		errs.AddIfErr(decode(fileContents, &hpa))
+		errs.AddIfErr(decode(contents, &hpa))
 		h := internal.HPAv2beta2{hpa, fileLocation}
 		s.hpaTargeters = append(s.hpaTargeters, h)
 		s.bothMetas = append(s.bothMetas, ks.BothMeta{hpa.TypeMeta, hpa.ObjectMeta, h})
 
 	default:
-		if cnf.VerboseOutput > 1 {
+		if unknownKind, suggestion := isUnknownKind(detectedVersion); unknownKind {
+			s.unknownObjects = append(s.unknownObjects, internalunknown.Object{
+				TypeMeta:   document.TypeMeta,
+				ObjectMeta: document.ObjectMeta,
+				Suggested:  suggestion,
+				Location:   fileLocation,
+			})
+			cnf.Logger.Info("Object has a kind that is not known to Kubernetes", "file", fileName, "line", fileOffset, "kind", detectedVersion.GroupVersion().String()+"/"+detectedVersion.Kind)
+			return nil
+		}
+
+		cnf.Logger.Info("Skipped object with unknown kind", "file", fileName, "line", fileOffset, "kind", detectedVersion.GroupVersion().String()+"/"+detectedVersion.Kind)
+		if cnf.VerboseOutput > 1 && cnf.Logger == nil {
 			log.Printf("Unknown datatype: %s", detectedVersion.String())
 		}
+		return nil
 	}
 
 	if errs.Any() {
 		return errs
 	}
+
+	cnf.Logger.Debug("Decoded object", "file", fileName, "line", fileOffset, "kind", detectedVersion.GroupVersion().String()+"/"+detectedVersion.Kind)
 	return nil
 }
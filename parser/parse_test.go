@@ -1,14 +1,20 @@
 package parser
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/zegl/kube-score/config"
 	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/logging"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestParse(t *testing.T) {
@@ -72,3 +78,334 @@ spec:
 	assert.Equal(t, "someName", fl.Name)
 	assert.Equal(t, 123, fl.Line)
 }
+
+func TestParseLogging(t *testing.T) {
+	var buf bytes.Buffer
+
+	_, err := ParseFiles(config.Configuration{
+		AllFiles: []ks.NamedReader{namedReader{strings.NewReader(`apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: foo
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: foo
+`), "test.yaml"}},
+		Logger: logging.New(&buf, logging.LevelDebug),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, `level=info msg="Skipped object with unknown kind" file=test.yaml line=1 kind=v1/ServiceAccount
+level=debug msg="Decoded object" file=test.yaml line=6 kind=v1/Pod
+level=info msg="Loaded file" file=test.yaml objects=1
+`, buf.String())
+}
+
+type namedReader struct {
+	io.Reader
+	name string
+}
+
+func (n namedReader) Name() string {
+	return n.name
+}
+
+func TestParseJSON(t *testing.T) {
+	type location struct {
+		name string
+		file string
+		line int
+	}
+
+	cases := []struct {
+		fname    string
+		expected []location
+	}{
+		{
+			"testdata/json-object.json",
+			[]location{{"foo", "testdata/json-object.json", 1}},
+		}, {
+			"testdata/json-array.json",
+			[]location{{"foo", "testdata/json-array.json#[0]", 2}, {"bar", "testdata/json-array.json#[1]", 12}},
+		}, {
+			"testdata/json-stream.json",
+			[]location{{"foo", "testdata/json-stream.json", 1}, {"bar", "testdata/json-stream.json#items[0]", 4}},
+		},
+	}
+
+	for _, tc := range cases {
+		fp, err := os.Open(tc.fname)
+		assert.Nil(t, err)
+		parsed, err := ParseFiles(config.Configuration{
+			AllFiles: []ks.NamedReader{fp},
+		})
+		fp.Close()
+		assert.Nil(t, err, tc.fname)
+
+		var locations []location
+		for _, pod := range parsed.Pods() {
+			locations = append(locations, location{pod.Pod().Name, pod.FileLocation().Name, pod.FileLocation().Line})
+		}
+		assert.Equal(t, tc.expected, locations, tc.fname)
+	}
+}
+
+func TestParseManifestFormat(t *testing.T) {
+	stream := `{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "foo"}}
+{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "bar"}}`
+
+	// Newline delimited JSON is not valid YAML
+	_, err := ParseFiles(config.Configuration{
+		AllFiles: []ks.NamedReader{namedReader{strings.NewReader(stream), "STDIN"}},
+	})
+	assert.NotNil(t, err)
+
+	parsed, err := ParseFiles(config.Configuration{
+		AllFiles:       []ks.NamedReader{namedReader{strings.NewReader(stream), "STDIN"}},
+		ManifestFormat: config.ManifestFormatJSON,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, parsed.Pods(), 2)
+
+	// Arrays can only contain objects
+	_, err = ParseFiles(config.Configuration{
+		AllFiles: []ks.NamedReader{namedReader{strings.NewReader(`[{"apiVersion": "v1", "kind": "Pod"}, 1]`), "test.json"}},
+	})
+	assert.EqualError(t, err, "failed to parse test.json: expected a JSON object on line 1")
+}
+
+func TestIsUnknownKind(t *testing.T) {
+	cases := []struct {
+		apiVersion string
+		kind       string
+		unknown    bool
+		suggestion string
+	}{
+		{apiVersion: "apps/v1", kind: "Deployment"},
+		{apiVersion: "v1", kind: "ServiceAccount"},
+		{apiVersion: "rbac.authorization.k8s.io/v1", kind: "ClusterRole"},
+		{apiVersion: "autoscaling/v2", kind: "HorizontalPodAutoscaler"},
+		{apiVersion: "cert-manager.io/v1", kind: "Certificate"},
+		{apiVersion: "", kind: ""},
+		{apiVersion: "apps/v1", kind: "Deploymnet", unknown: true, suggestion: "apps/v1 Deployment"},
+		{apiVersion: "v1", kind: "service", unknown: true, suggestion: "v1 Service"},
+		{apiVersion: "apps/v1beta3", kind: "Deployment"},
+		{apiVersion: "networking.k8s.io/v1", kind: "ServiceCIDR"},
+		{apiVersion: "networking.k8s.io/v1", kind: "IPAddress"},
+		{apiVersion: "storage.k8s.io/v1", kind: "VolumeAttributesClass"},
+		{apiVersion: "coordination.k8s.io/v1beta1", kind: "LeaseCandidate"},
+		{apiVersion: "certificates.k8s.io/v1beta1", kind: "ClusterTrustBundle"},
+		{apiVersion: "admissionregistration.k8s.io/v1beta1", kind: "MutatingAdmissionPolicy"},
+		{apiVersion: "internal.apiserver.k8s.io/v1", kind: "StorageVersions", unknown: true, suggestion: "internal.apiserver.k8s.io/v1 StorageVersion"},
+		{apiVersion: "v1", kind: "Deployment", unknown: true, suggestion: "apps/v1 Deployment"},
+		{apiVersion: "app/v1", kind: "StatefulSet", unknown: true, suggestion: "apps/v1 StatefulSet"},
+		{apiVersion: "batch/v1beta1", kind: "CronJob"},
+		{apiVersion: "networking.k8s.io/v1", kind: "Ingres", unknown: true, suggestion: "networking.k8s.io/v1 Ingress"},
+		{apiVersion: "v1", kind: "SomethingElse", unknown: true},
+	}
+
+	for _, tc := range cases {
+		unknown, suggestion := isUnknownKind(schema.FromAPIVersionAndKind(tc.apiVersion, tc.kind))
+		assert.Equal(t, tc.unknown, unknown, "%s %s", tc.apiVersion, tc.kind)
+		if tc.suggestion == "" {
+			assert.Nil(t, suggestion, "%s %s", tc.apiVersion, tc.kind)
+		} else if assert.NotNil(t, suggestion, "%s %s", tc.apiVersion, tc.kind) {
+			assert.Equal(t, tc.suggestion, suggestion.APIVersion+" "+suggestion.Kind)
+		}
+	}
+}
+
+func TestParseMergeSameIdentity(t *testing.T) {
+	open := func() []ks.NamedReader {
+		var files []ks.NamedReader
+		for _, f := range []string{"testdata/merge-base.yaml", "testdata/merge-patch.yaml"} {
+			fp, err := os.Open(f)
+			assert.Nil(t, err)
+			files = append(files, fp)
+		}
+		return files
+	}
+
+	// Without merging, all objects are parsed
+	parsed, err := ParseFiles(config.Configuration{AllFiles: open()})
+	assert.Nil(t, err)
+	assert.Len(t, parsed.Deployments(), 3)
+	assert.Len(t, parsed.UnknownObjects(), 0)
+
+	parsed, err = ParseFiles(config.Configuration{AllFiles: open(), MergeSameIdentity: true})
+	assert.Nil(t, err)
+
+	// The deployment in another namespace is not merged
+	deployments := parsed.Deployments()
+	assert.Len(t, deployments, 2)
+	assert.Equal(t, "other", deployments[1].Deployment().Namespace)
+
+	merged := deployments[0]
+	assert.Equal(t, ks.FileLocation{Name: "testdata/merge-base.yaml", Line: 1}, merged.FileLocation())
+	assert.Equal(t, int32(3), *merged.Deployment().Spec.Replicas)
+	assert.Equal(t, map[string]string{"app": "app"}, merged.Deployment().Labels)
+
+	// Containers are merged by name, and scalar fields get the value from the last file
+	containers := merged.Deployment().Spec.Template.Spec.Containers
+	assert.Len(t, containers, 2)
+	assert.Equal(t, "app:2.0", containers[0].Image)
+	assert.Equal(t, "128Mi", containers[0].Resources.Limits.Memory().String())
+	assert.Equal(t, "sidecar:1.0", containers[1].Image)
+}
+
+func TestJSONMergePatch(t *testing.T) {
+	merged, err := jsonMergePatch(
+		[]byte(`{"spec":{"size":"small","colors":["red","green"],"removed":true}}`),
+		[]byte(`{"spec":{"colors":["blue"],"removed":null,"added":1}}`),
+	)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"spec":{"size":"small","colors":["blue"],"added":1}}`, string(merged))
+}
+
+func TestParseInvalidQuantities(t *testing.T) {
+	parsed, err := ParseFiles(config.Configuration{
+		AllFiles: []ks.NamedReader{namedReader{strings.NewReader(`apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: foo
+  namespace: bar
+spec:
+  template:
+    spec:
+      containers:
+      - name: foo
+        resources:
+          limits:
+            cpu: 500m
+            memory: 1GB
+  volumeClaimTemplates:
+  - metadata:
+      name: data
+    spec:
+      resources:
+        requests:
+          storage: 1 Gi
+`), "test.yaml"}},
+	})
+	assert.Nil(t, err)
+
+	// The rest of the object is decoded without the invalid quantities
+	statefulSets := parsed.StatefulSets()
+	assert.Len(t, statefulSets, 1)
+	limits := statefulSets[0].StatefulSet().Spec.Template.Spec.Containers[0].Resources.Limits
+	assert.Equal(t, "500m", limits.Cpu().String())
+	_, hasMemory := limits[corev1.ResourceMemory]
+	assert.False(t, hasMemory)
+
+	invalid := parsed.InvalidQuantities()
+	assert.Len(t, invalid, 2)
+	assert.Equal(t, "StatefulSet", invalid[0].TypeMeta.Kind)
+	assert.Equal(t, "foo", invalid[0].ObjectMeta.Name)
+	assert.Equal(t, "bar", invalid[0].ObjectMeta.Namespace)
+	assert.Equal(t, ks.FileLocation{Name: "test.yaml", Line: 1}, invalid[0].Location)
+	assert.Equal(t, "spec.template.spec.containers[0].resources.limits.memory", invalid[0].Path)
+	assert.Equal(t, "1GB", invalid[0].Value)
+	assert.Equal(t, "spec.volumeClaimTemplates[0].spec.resources.requests.storage", invalid[1].Path)
+	assert.Equal(t, "1 Gi", invalid[1].Value)
+}
+
+func TestParseDocuments(t *testing.T) {
+	parsed, err := ParseFiles(config.Configuration{
+		AllFiles: []ks.NamedReader{namedReader{strings.NewReader(`apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: foo
+  namespace: bar
+---
+apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: foo-tls
+  namespace: bar
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: foo
+  namespace: bar
+spec:
+  selector:
+    app: foo
+`), "test.yaml"}},
+	})
+	assert.Nil(t, err)
+
+	// All objects are documents, also the ones that are not scored
+	documents := parsed.Documents()
+	assert.Len(t, documents, 3)
+	assert.Equal(t, "ServiceAccount", documents[0].TypeMeta.Kind)
+	assert.Equal(t, "foo", documents[0].ObjectMeta.Name)
+	assert.Equal(t, "bar", documents[0].ObjectMeta.Namespace)
+	assert.Equal(t, ks.FileLocation{Name: "test.yaml", Line: 1}, documents[0].Location)
+	assert.Equal(t, "cert-manager.io/v1", documents[1].TypeMeta.APIVersion)
+	assert.Equal(t, "Certificate", documents[1].TypeMeta.Kind)
+	assert.Equal(t, "foo-tls", documents[1].ObjectMeta.Name)
+	assert.Equal(t, ks.FileLocation{Name: "test.yaml", Line: 7}, documents[1].Location)
+	assert.Equal(t, "Service", documents[2].TypeMeta.Kind)
+	assert.Equal(t, ks.FileLocation{Name: "test.yaml", Line: 13}, documents[2].Location)
+}
+
+func TestParseInvalidMetadata(t *testing.T) {
+	_, err := ParseFiles(config.Configuration{
+		AllFiles: []ks.NamedReader{namedReader{strings.NewReader(`apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: foo
+---
+apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: [foo, bar]
+`), "test.yaml"}},
+	})
+	if assert.Error(t, err) {
+		assert.True(t, strings.HasPrefix(err.Error(), "test.yaml:6: "), err.Error())
+	}
+}
+
+func TestParseListKinds(t *testing.T) {
+	parsed, err := ParseFiles(config.Configuration{
+		AllFiles: []ks.NamedReader{namedReader{strings.NewReader(`apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ServiceAccount
+  metadata:
+    name: foo
+---
+apiVersion: v1
+kind: ServiceAccountList
+items:
+- apiVersion: v1
+  kind: ServiceAccount
+  metadata:
+    name: bar
+---
+apiVersion: example.com/v1
+kind: AccessList
+metadata:
+  name: baz
+spec:
+  items:
+  - apiVersion: v1
+    kind: ServiceAccount
+    metadata:
+      name: qux
+`), "test.yaml"}},
+	})
+	assert.Nil(t, err)
+
+	// Custom resources with a kind that ends with "List" are not lists
+	documents := parsed.Documents()
+	assert.Len(t, documents, 3)
+	assert.Equal(t, "foo", documents[0].ObjectMeta.Name)
+	assert.Equal(t, "bar", documents[1].ObjectMeta.Name)
+	assert.Equal(t, "AccessList", documents[2].TypeMeta.Kind)
+	assert.Equal(t, "baz", documents[2].ObjectMeta.Name)
+}
@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	sigsyaml "sigs.k8s.io/yaml"
+
+	ks "github.com/zegl/kube-score/domain"
+)
+
+// quantityLists are the fields in a resources object that are maps of resource names to quantities
+var quantityLists = []string{"requests", "limits"}
+
+// removeInvalidQuantities removes the resource quantities that can't be parsed from the object, as a single invalid
+// quantity would make the whole object fail to decode. The quantities of all resources fields are checked, such as
+// the resources of containers and the storage requests of PersistentVolumeClaims and volumeClaimTemplates.
+//
+// The contents are returned unmodified if all quantities are valid.
+func removeInvalidQuantities(contents []byte) ([]byte, []ks.InvalidQuantity, error) {
+	if !bytes.Contains(contents, []byte("resources")) {
+		return contents, nil, nil
+	}
+
+	var obj interface{}
+	if err := sigsyaml.Unmarshal(contents, &obj); err != nil {
+		// The error is returned when the object is decoded
+		return contents, nil, nil
+	}
+
+	var invalid []ks.InvalidQuantity
+	walkQuantities(obj, "", &invalid)
+	if len(invalid) == 0 {
+		return contents, nil, nil
+	}
+
+	sanitized, err := json.Marshal(obj)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sanitized, invalid, nil
+}
+
+func walkQuantities(v interface{}, path string, invalid *[]ks.InvalidQuantity) {
+	switch v := v.(type) {
+	case []interface{}:
+		for i, item := range v {
+			walkQuantities(item, fmt.Sprintf("%s[%d]", path, i), invalid)
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+			if resources, ok := v[key].(map[string]interface{}); ok && key == "resources" {
+				removeInvalidResources(resources, fieldPath, invalid)
+			}
+			walkQuantities(v[key], fieldPath, invalid)
+		}
+	}
+}
+
+func removeInvalidResources(resources map[string]interface{}, path string, invalid *[]ks.InvalidQuantity) {
+	for _, list := range quantityLists {
+		quantities, ok := resources[list].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		names := make([]string, 0, len(quantities))
+		for name := range quantities {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			// Numbers are valid quantities, such as cpu: 1
+			value, ok := quantities[name].(string)
+			if !ok {
+				continue
+			}
+			if _, err := resource.ParseQuantity(value); err == nil {
+				continue
+			}
+			*invalid = append(*invalid, ks.InvalidQuantity{
+				Path:  path + "." + list + "." + name,
+				Value: value,
+			})
+			delete(quantities, name)
+		}
+	}
+}
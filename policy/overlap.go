@@ -0,0 +1,158 @@
+package policy
+
+import (
+	"path"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// overlaps returns true if there can be an object that is matched by both rules, for at least one check
+func (r rule) overlaps(other rule) bool {
+	if r.check != other.check && r.check != AllChecks && other.check != AllChecks {
+		return false
+	}
+	return namespacesOverlap(r.namespace, other.namespace) && selectorsOverlap(r.selector, other.selector)
+}
+
+// namespaceChars are the characters that are allowed in the name of a namespace
+const namespaceChars = "abcdefghijklmnopqrstuvwxyz0123456789-"
+
+// namespacesOverlap returns true if there is a namespace that is matched by both namespace globs. An empty glob
+// matches all namespaces.
+func namespacesOverlap(a, b string) bool {
+	if a == "" || b == "" {
+		return true
+	}
+	ta, tb := globTokens(a), globTokens(b)
+
+	// Characters that are not allowed in namespaces can still be matched by literals in the globs
+	alphabet := namespaceChars + a + b
+
+	// The globs are walked together, one character at a time, until the end of both globs is reached at the same time
+	type state struct{ i, j int }
+	visited := make(map[state]struct{})
+	queue := []state{{0, 0}}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		if _, ok := visited[s]; ok {
+			continue
+		}
+		visited[s] = struct{}{}
+
+		if s.i == len(ta) && s.j == len(tb) {
+			return true
+		}
+
+		// A star can match an empty string
+		if s.i < len(ta) && ta[s.i] == "*" {
+			queue = append(queue, state{s.i + 1, s.j})
+		}
+		if s.j < len(tb) && tb[s.j] == "*" {
+			queue = append(queue, state{s.i, s.j + 1})
+		}
+
+		for k := 0; k < len(alphabet); k++ {
+			c := string(alphabet[k])
+			i, ok := globNext(ta, s.i, c)
+			if !ok {
+				continue
+			}
+			j, ok := globNext(tb, s.j, c)
+			if !ok {
+				continue
+			}
+			queue = append(queue, state{i, j})
+		}
+	}
+	return false
+}
+
+// globTokens splits a glob that has been validated with path.Match into patterns that each match a single character,
+// and "*"
+func globTokens(glob string) []string {
+	var tokens []string
+	for i := 0; i < len(glob); i++ {
+		start := i
+		switch glob[i] {
+		case '\\':
+			i++
+		case '[':
+			for i++; i < len(glob) && glob[i] != ']'; i++ {
+				if glob[i] == '\\' {
+					i++
+				}
+			}
+		}
+		tokens = append(tokens, glob[start:i+1])
+	}
+	return tokens
+}
+
+// globNext returns the position in the tokens after matching the character c at position i, and false if the token
+// doesn't match c
+func globNext(tokens []string, i int, c string) (int, bool) {
+	if i == len(tokens) {
+		return i, false
+	}
+	if tokens[i] == "*" {
+		return i, true
+	}
+	ok, _ := path.Match(tokens[i], c)
+	return i + 1, ok
+}
+
+// selectorsOverlap returns true if there is a set of labels that is matched by both selectors
+func selectorsOverlap(a, b labels.Selector) bool {
+	ra, _ := a.Requirements()
+	rb, _ := b.Requirements()
+	byKey := make(map[string]labels.Requirements)
+	for _, requirements := range []labels.Requirements{ra, rb} {
+		for _, r := range requirements {
+			byKey[r.Key()] = append(byKey[r.Key()], r)
+		}
+	}
+
+	// The requirements on different keys are independent of each other, so it's enough to find a value (or no label)
+	// for each key that matches all requirements on that key
+	for key, requirements := range byKey {
+		if !requirementsSatisfiable(key, requirements) {
+			return false
+		}
+	}
+	return true
+}
+
+// requirementsSatisfiable returns true if there is a value of the label with the key, or no label, that matches all
+// requirements
+func requirementsSatisfiable(key string, requirements labels.Requirements) bool {
+	// The candidates are no label, a value that is not used by any of the requirements, the values of the requirements,
+	// and the integers next to them for gt and lt
+	candidates := []labels.Set{{}, {key: "\x00"}}
+	for _, r := range requirements {
+		for value := range r.Values() {
+			candidates = append(candidates, labels.Set{key: value})
+			if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+				candidates = append(candidates,
+					labels.Set{key: strconv.FormatInt(n-1, 10)},
+					labels.Set{key: strconv.FormatInt(n+1, 10)},
+				)
+			}
+		}
+	}
+
+	for _, candidate := range candidates {
+		matchesAll := true
+		for _, r := range requirements {
+			if !r.Matches(candidate) {
+				matchesAll = false
+				break
+			}
+		}
+		if matchesAll {
+			return true
+		}
+	}
+	return false
+}
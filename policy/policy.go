@@ -0,0 +1,217 @@
+// Package policy overrides the severity of checks depending on the namespace and the labels of the scored object.
+//
+// A policy is a YAML file with a list of rules:
+//
+//	rules:
+//	  - check: container-resources
+//	    severity: warning
+//	  - check: container-resources
+//	    namespace: "prod-*"
+//	    selector: "tier in (frontend, backend)"
+//	    severity: critical
+//
+// Each rule caps the most severe grade that the check can report for the objects that it matches, in the same way
+// as config.SeverityOverrides. Only the most specific matching rule is used for each check, see Policy.MaxSeverity.
+package policy
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// AllChecks is used as the check ID of rules that apply to all checks
+const AllChecks = "*"
+
+// Policy is a parsed and validated policy file
+type Policy struct {
+	rules []rule
+}
+
+type rule struct {
+	check     string
+	namespace string
+	selector  labels.Selector
+	severity  scorecard.Grade
+
+	// specificity is used to order the rules, see Policy.MaxSeverity
+	specificity [3]int
+}
+
+type policyFile struct {
+	Rules []ruleFile `yaml:"rules"`
+}
+
+type ruleFile struct {
+	Check     string `yaml:"check"`
+	Namespace string `yaml:"namespace"`
+	Selector  string `yaml:"selector"`
+	Severity  string `yaml:"severity"`
+}
+
+// Load reads and parses the policy file at path
+func Load(path string) (*Policy, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+
+	p, err := Parse(fp)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// Parse parses a policy. All rules are validated, and rules that have the same conditions are reported as an error.
+// Rules that are equally specific and can match the same object, but have different severities, are also reported as
+// an error, as it would otherwise depend on the order of the rules which severity is used.
+func Parse(r io.Reader) (*Policy, error) {
+	var file policyFile
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	p := &Policy{}
+	seen := make(map[string]int)
+	for i, rf := range file.Rules {
+		ru, err := parseRule(rf)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+
+		key := ru.check + "\x00" + ru.namespace + "\x00" + ru.selector.String()
+		if prev, ok := seen[key]; ok {
+			return nil, fmt.Errorf("rule %d: has the same check, namespace and selector as rule %d", i+1, prev+1)
+		}
+		seen[key] = i
+
+		for j, prev := range p.rules {
+			if prev.specificity == ru.specificity && prev.severity != ru.severity && prev.overlaps(ru) {
+				return nil, fmt.Errorf("rule %d: conflicts with rule %d, both can match the same object and are equally specific, but have different severities", i+1, j+1)
+			}
+		}
+
+		p.rules = append(p.rules, ru)
+	}
+	return p, nil
+}
+
+func parseRule(rf ruleFile) (rule, error) {
+	if rf.Check == "" {
+		return rule{}, fmt.Errorf("check is not set")
+	}
+	if rf.Severity == "" {
+		return rule{}, fmt.Errorf("severity is not set")
+	}
+
+	severity, err := scorecard.ParseGrade(rf.Severity)
+	if err != nil {
+		return rule{}, err
+	}
+
+	if _, err := path.Match(rf.Namespace, ""); err != nil {
+		return rule{}, fmt.Errorf("invalid namespace %q: %w", rf.Namespace, err)
+	}
+
+	selector, err := labels.Parse(rf.Selector)
+	if err != nil {
+		return rule{}, fmt.Errorf("invalid selector %q: %w", rf.Selector, err)
+	}
+
+	ru := rule{
+		check:     rf.Check,
+		namespace: rf.Namespace,
+		selector:  selector,
+		severity:  severity,
+	}
+
+	if ru.check != AllChecks {
+		ru.specificity[0] = 1
+	}
+	switch {
+	case ru.namespace == "" || ru.namespace == "*":
+	case strings.ContainsAny(ru.namespace, `*?[\`):
+		ru.specificity[1] = 1
+	default:
+		ru.specificity[1] = 2
+	}
+	requirements, _ := selector.Requirements()
+	ru.specificity[2] = len(requirements)
+
+	return ru, nil
+}
+
+func (r rule) matches(checkID string, meta metav1.ObjectMeta) bool {
+	if r.check != AllChecks && r.check != checkID {
+		return false
+	}
+	if r.namespace != "" {
+		if ok, _ := path.Match(r.namespace, meta.Namespace); !ok {
+			return false
+		}
+	}
+	return r.selector.Matches(labels.Set(meta.Labels))
+}
+
+func (r rule) moreSpecificThan(other rule) bool {
+	for i := range r.specificity {
+		if r.specificity[i] != other.specificity[i] {
+			return r.specificity[i] > other.specificity[i]
+		}
+	}
+	return false
+}
+
+// MaxSeverity returns the most severe grade that the check can report for the object, and false if no rule matches.
+//
+// If multiple rules match, the most specific rule is used. A rule for a check ID is more specific than a rule for all
+// checks ("*"). Then, a namespace without wildcards is more specific than a namespace glob, which is more specific
+// than no namespace. Last, a selector with more requirements is more specific than one with fewer. Equally specific
+// rules that match the same object have the same severity, see Parse.
+func (p *Policy) MaxSeverity(checkID string, meta metav1.ObjectMeta) (scorecard.Grade, bool) {
+	if p == nil {
+		return 0, false
+	}
+
+	var best *rule
+	for i := range p.rules {
+		r := &p.rules[i]
+		if !r.matches(checkID, meta) {
+			continue
+		}
+		if best == nil || r.moreSpecificThan(*best) {
+			best = r
+		}
+	}
+
+	if best == nil {
+		return 0, false
+	}
+	return best.severity, true
+}
+
+// CheckIDs returns the IDs of all checks that are referenced by the rules, except for AllChecks
+func (p *Policy) CheckIDs() []string {
+	var ids []string
+	seen := make(map[string]struct{})
+	for _, r := range p.rules {
+		if _, ok := seen[r.check]; ok || r.check == AllChecks {
+			continue
+		}
+		seen[r.check] = struct{}{}
+		ids = append(ids, r.check)
+	}
+	return ids
+}
@@ -0,0 +1,192 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestMaxSeverity(t *testing.T) {
+	p, err := Parse(strings.NewReader(`
+rules:
+  - check: "*"
+    namespace: "dev-*"
+    severity: ok
+  - check: container-resources
+    severity: warning
+  - check: container-resources
+    namespace: "prod-*"
+    severity: critical
+  - check: container-resources
+    namespace: prod-eu
+    severity: warning
+  - check: container-resources
+    namespace: "prod-*"
+    selector: "tier in (frontend, backend), env=prod"
+    severity: ok
+`))
+	assert.Nil(t, err)
+
+	cases := []struct {
+		check     string
+		namespace string
+		labels    map[string]string
+		expected  scorecard.Grade
+		ok        bool
+	}{
+		{check: "container-image-tag", namespace: "default", ok: false},
+		{check: "container-image-tag", namespace: "dev-1", expected: scorecard.GradeAllOK, ok: true},
+		{check: "container-resources", namespace: "default", expected: scorecard.GradeWarning, ok: true},
+		{check: "container-resources", namespace: "dev-1", expected: scorecard.GradeWarning, ok: true},
+		{check: "container-resources", namespace: "prod-us", expected: scorecard.GradeCritical, ok: true},
+		{check: "container-resources", namespace: "prod-eu", labels: map[string]string{"tier": "frontend", "env": "prod"}, expected: scorecard.GradeWarning, ok: true},
+		{check: "container-resources", namespace: "prod-us", labels: map[string]string{"tier": "frontend", "env": "prod"}, expected: scorecard.GradeAllOK, ok: true},
+		{check: "container-resources", namespace: "prod-us", labels: map[string]string{"tier": "frontend"}, expected: scorecard.GradeCritical, ok: true},
+	}
+
+	for _, tc := range cases {
+		grade, ok := p.MaxSeverity(tc.check, metav1.ObjectMeta{Namespace: tc.namespace, Labels: tc.labels})
+		assert.Equal(t, tc.ok, ok, "%+v", tc)
+		assert.Equal(t, tc.expected, grade, "%+v", tc)
+	}
+
+	assert.Equal(t, []string{"container-resources"}, p.CheckIDs())
+}
+
+func TestParseConflictingRules(t *testing.T) {
+	conflicting := map[string]string{
+		"namespace globs": `
+  - check: container-resources
+    namespace: "prod-*"
+    severity: warning
+  - check: container-resources
+    namespace: "*-eu"
+    severity: ok`,
+		"all checks": `
+  - check: "*"
+    severity: warning
+  - check: "*"
+    selector: "tier=frontend"
+    severity: critical
+  - check: "*"
+    selector: "team=data"
+    severity: ok`,
+		"selectors": `
+  - check: container-resources
+    selector: "tier in (frontend, backend)"
+    severity: warning
+  - check: container-resources
+    selector: "tier notin (frontend)"
+    severity: ok`,
+		"numbers": `
+  - check: container-resources
+    selector: "replicas>1"
+    severity: warning
+  - check: container-resources
+    selector: "replicas<5"
+    severity: ok`,
+	}
+	for name, rules := range conflicting {
+		_, err := Parse(strings.NewReader("rules:" + rules + "\n"))
+		if assert.Error(t, err, name) {
+			assert.Contains(t, err.Error(), "conflicts with rule", name)
+		}
+	}
+
+	valid := map[string]string{
+		"same severity": `
+  - check: container-resources
+    namespace: "prod-*"
+    severity: warning
+  - check: container-resources
+    namespace: "*-eu"
+    severity: warning`,
+		"different checks": `
+  - check: container-resources
+    severity: warning
+  - check: pod-networkpolicy
+    severity: ok`,
+		"different specificity": `
+  - check: container-resources
+    namespace: "prod-*"
+    severity: warning
+  - check: container-resources
+    namespace: "prod-eu"
+    severity: ok`,
+		"disjoint namespace globs": `
+  - check: container-resources
+    namespace: "prod-*"
+    severity: warning
+  - check: container-resources
+    namespace: "dev-*"
+    severity: ok`,
+		"disjoint character classes": `
+  - check: container-resources
+    namespace: "team-[a-m]*"
+    severity: warning
+  - check: container-resources
+    namespace: "team-[n-z]*"
+    severity: ok`,
+		"disjoint selectors": `
+  - check: container-resources
+    selector: "tier=frontend"
+    severity: warning
+  - check: container-resources
+    selector: "tier=backend"
+    severity: ok`,
+		"disjoint exists": `
+  - check: container-resources
+    selector: "tier"
+    severity: warning
+  - check: container-resources
+    selector: "!tier"
+    severity: ok`,
+		"disjoint numbers": `
+  - check: container-resources
+    selector: "replicas>1"
+    severity: warning
+  - check: container-resources
+    selector: "replicas<2"
+    severity: ok`,
+	}
+	for name, rules := range valid {
+		_, err := Parse(strings.NewReader("rules:" + rules + "\n"))
+		assert.Nil(t, err, name)
+	}
+}
+
+func TestMaxSeverityNilPolicy(t *testing.T) {
+	var p *Policy
+	_, ok := p.MaxSeverity("container-resources", metav1.ObjectMeta{})
+	assert.False(t, ok)
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := map[string]string{
+		"rules:\n  - severity: ok\n":                                         "rule 1: check is not set",
+		"rules:\n  - check: foo\n":                                           "rule 1: severity is not set",
+		"rules:\n  - check: foo\n    severity: info\n":                       "rule 1: unknown grade: info",
+		"rules:\n  - check: foo\n    namespace: \"[\"\n    severity: ok\n":   "rule 1: invalid namespace \"[\": syntax error in pattern",
+		"rules:\n  - check: foo\n    selector: \"a in\"\n    severity: ok\n": "rule 1: invalid selector \"a in\"",
+		"rules:\n  - check: foo\n    sevrity: ok\n":                          "field sevrity not found",
+		"rules:\n  - check: foo\n    selector: \"a=b,c=d\"\n    severity: ok\n  - check: foo\n    selector: \"c=d, a=b\"\n    severity: warning\n": "rule 2: has the same check, namespace and selector as rule 1",
+	}
+
+	for input, expected := range cases {
+		_, err := Parse(strings.NewReader(input))
+		if assert.Error(t, err, input) {
+			assert.Contains(t, err.Error(), expected, input)
+		}
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	p, err := Parse(strings.NewReader(""))
+	assert.Nil(t, err)
+	_, ok := p.MaxSeverity("container-resources", metav1.ObjectMeta{})
+	assert.False(t, ok)
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/eidolon/wordwrap"
 	"github.com/fatih/color"
@@ -26,33 +27,237 @@ func Human(scoreCard *scorecard.Scorecard, verboseOutput int, termWidth int) io.
 	w := bytes.NewBufferString("")
 
 	for _, key := range keys {
-		scoredObject := (*scoreCard)[key]
+		io.Copy(w, outputHumanObject((*scoreCard)[key], verboseOutput, termWidth))
+	}
+
+	return w
+}
+
+// ClusterScopedNamespace is the name of the group that objects without a namespace are listed under by
+// HumanGroupedByNamespace
+const ClusterScopedNamespace = "(cluster-scoped)"
+
+// HumanGroupedByNamespace is the same as Human, but lists the objects grouped by their namespace. A summary of the
+// number of objects per grade is printed for each namespace, followed by a summary for all namespaces.
+func HumanGroupedByNamespace(scoreCard *scorecard.Scorecard, verboseOutput int, termWidth int) io.Reader {
+	var keys []string
+	for k := range *scoreCard {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
 
-		// Headers for each object
-		var writtenHeaderChars int
-		writtenHeaderChars, _ = color.New(color.FgMagenta).Fprintf(w, "%s/%s %s", scoredObject.TypeMeta.APIVersion, scoredObject.TypeMeta.Kind, scoredObject.ObjectMeta.Name)
-		if scoredObject.ObjectMeta.Namespace != "" {
-			written2, _ := color.New(color.FgMagenta).Fprintf(w, " in %s", scoredObject.ObjectMeta.Namespace)
-			writtenHeaderChars += written2
+	var namespaces []string
+	keysByNamespace := make(map[string][]string)
+	for _, key := range keys {
+		namespace := (*scoreCard)[key].ObjectMeta.Namespace
+		if namespace == "" {
+			namespace = ClusterScopedNamespace
+		}
+		if _, ok := keysByNamespace[namespace]; !ok {
+			namespaces = append(namespaces, namespace)
 		}
+		keysByNamespace[namespace] = append(keysByNamespace[namespace], key)
+	}
+	sort.Strings(namespaces)
+
+	w := bytes.NewBufferString("")
+
+	var total gradeTally
+	tallies := make(map[string]gradeTally)
 
-		// Adjust to termsize
-		fmt.Fprintf(w, safeRepeat(" ", min(80, termWidth)-writtenHeaderChars-2))
+	for _, namespace := range namespaces {
+		color.New(color.Bold).Fprintf(w, "Namespace: %s\n", namespace)
 
-		if scoredObject.AnyBelowOrEqualToGrade(scorecard.GradeCritical) {
-			fmt.Fprintf(w, "💥\n")
-		} else if scoredObject.AnyBelowOrEqualToGrade(scorecard.GradeWarning) {
-			fmt.Fprintf(w, "🤔\n")
-		} else {
-			fmt.Fprintf(w, "✅\n")
+		var tally gradeTally
+		for _, key := range keysByNamespace[namespace] {
+			scoredObject := (*scoreCard)[key]
+			tally.add(scoredObject)
+			total.add(scoredObject)
+			io.Copy(w, outputHumanObject(scoredObject, verboseOutput, termWidth))
 		}
+		tallies[namespace] = tally
+
+		fmt.Fprintln(w)
+	}
 
-		for _, card := range scoredObject.Checks {
-			r := outputHumanStep(card, verboseOutput, termWidth)
-			io.Copy(w, r)
+	color.New(color.Bold).Fprintln(w, "Summary by namespace:")
+	for _, namespace := range namespaces {
+		fmt.Fprintf(w, "    %s: %s\n", namespace, tallies[namespace])
+	}
+	color.New(color.Bold).Fprintf(w, "Total: %s\n", total)
+
+	return w
+}
+
+// HumanGroupedByCheck inverts the output, and lists each check that has failed for at least one object, together
+// with the number of objects that have failed it and the grade of each of them. The checks that have failed for the
+// most objects are listed first. The comments of the checks are only included if verboseOutput is set.
+func HumanGroupedByCheck(scoreCard *scorecard.Scorecard, verboseOutput int) io.Reader {
+	w := bytes.NewBufferString("")
+
+	byCheck := scoreCard.ByCheck()
+	for _, check := range byCheck {
+		objects := "objects"
+		if len(check.Findings) == 1 {
+			objects = "object"
+		}
+		color.New(color.Bold).Fprintf(w, "%s (%s): %d %s\n", check.Check.Name, check.Check.ID, len(check.Findings), objects)
+
+		for _, finding := range check.Findings {
+			col := color.FgRed
+			if finding.Score.Grade >= scorecard.GradeWarning {
+				col = color.FgYellow
+			}
+			color.New(col).Fprintf(w, "    [%s] %s\n", finding.Score.Grade.String(), objectRef(finding.Object))
+
+			if verboseOutput == 0 {
+				continue
+			}
+			for _, comment := range finding.Score.Comments {
+				fmt.Fprintf(w, "        · ")
+				if len(comment.Path) > 0 {
+					fmt.Fprintf(w, "%s -> ", comment.Path)
+				}
+				fmt.Fprintln(w, comment.Summary)
+			}
 		}
 	}
 
+	color.New(color.Bold).Fprintf(w, "Total: %d failed checks\n", len(byCheck))
+
+	return w
+}
+
+// Summary lists each object on a single line together with the worst grade of its checks, followed by the number of
+// objects per grade
+func Summary(scoreCard *scorecard.Scorecard) io.Reader {
+	var keys []string
+	for k := range *scoreCard {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	w := bytes.NewBufferString("")
+
+	var total gradeTally
+	for _, key := range keys {
+		scoredObject := (*scoreCard)[key]
+		total.add(scoredObject)
+
+		ref := objectRef(scoredObject)
+		fmt.Fprintf(w, "%s: %s\n", ref, scoredObject.WorstGrade())
+	}
+
+	color.New(color.Bold).Fprintf(w, "Total: %s\n", total)
+
+	return w
+}
+
+// Report lists all objects in the scorecard, with the file that they were read from and the number of checks that were
+// not skipped, followed by the number of objects
+func Report(scoreCard *scorecard.Scorecard) io.Reader {
+	var keys []string
+	for k := range *scoreCard {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	w := bytes.NewBufferString("")
+
+	for _, key := range keys {
+		scoredObject := (*scoreCard)[key]
+
+		ref := objectRef(scoredObject)
+		fmt.Fprintf(w, "%s (%s:%d): %d checks\n", ref, scoredObject.FileLocation.Name, scoredObject.FileLocation.Line, scoredObject.ChecksRun())
+	}
+
+	color.New(color.Bold).Fprintf(w, "Total: %d objects\n", len(keys))
+
+	return w
+}
+
+// WithFooter appends a line describing the run to the output if verboseOutput is set, and returns the output
+// unchanged otherwise
+func WithFooter(r io.Reader, verboseOutput int, metadata scorecard.Metadata) io.Reader {
+	if verboseOutput == 0 {
+		return r
+	}
+
+	optionalChecks := "none"
+	if len(metadata.EnabledOptionalChecks) > 0 {
+		optionalChecks = strings.Join(metadata.EnabledOptionalChecks, ", ")
+	}
+	footer := fmt.Sprintf("kube-score %s, kubernetes %s, %s, optional checks: %s\n",
+		metadata.KubeScoreVersion, metadata.KubernetesVersion, metadata.Timestamp.UTC().Format(time.RFC3339), optionalChecks)
+
+	return io.MultiReader(r, strings.NewReader(footer))
+}
+
+// objectRef returns the apiVersion, kind, name and namespace of the object, as it's printed in the header of the object
+func objectRef(scoredObject *scorecard.ScoredObject) string {
+	ref := fmt.Sprintf("%s/%s %s", scoredObject.TypeMeta.APIVersion, scoredObject.TypeMeta.Kind, scoredObject.ObjectMeta.Name)
+	if scoredObject.ObjectMeta.Namespace != "" {
+		ref += " in " + scoredObject.ObjectMeta.Namespace
+	}
+	return ref
+}
+
+// gradeTally counts the number of objects by the worst grade of any of their checks
+type gradeTally struct {
+	critical int
+	warning  int
+	ok       int
+}
+
+func (t *gradeTally) add(scoredObject *scorecard.ScoredObject) {
+	if scoredObject.AnyBelowOrEqualToGrade(scorecard.GradeCritical) {
+		t.critical++
+	} else if scoredObject.AnyBelowOrEqualToGrade(scorecard.GradeWarning) {
+		t.warning++
+	} else {
+		t.ok++
+	}
+}
+
+func (t gradeTally) String() string {
+	return fmt.Sprintf("%d critical, %d warning, %d ok", t.critical, t.warning, t.ok)
+}
+
+// Stream returns a function that writes a scored object to w as soon as it's called, it can be used together
+// with score.ScoreWithCallback to output objects while scoring is still in progress.
+func Stream(w io.Writer, verboseOutput int, termWidth int) func(*scorecard.ScoredObject) {
+	return func(scoredObject *scorecard.ScoredObject) {
+		io.Copy(w, outputHumanObject(scoredObject, verboseOutput, termWidth))
+	}
+}
+
+func outputHumanObject(scoredObject *scorecard.ScoredObject, verboseOutput int, termWidth int) io.Reader {
+	w := bytes.NewBufferString("")
+
+	// Headers for each object
+	var writtenHeaderChars int
+	writtenHeaderChars, _ = color.New(color.FgMagenta).Fprintf(w, "%s/%s %s", scoredObject.TypeMeta.APIVersion, scoredObject.TypeMeta.Kind, scoredObject.ObjectMeta.Name)
+	if scoredObject.ObjectMeta.Namespace != "" {
+		written2, _ := color.New(color.FgMagenta).Fprintf(w, " in %s", scoredObject.ObjectMeta.Namespace)
+		writtenHeaderChars += written2
+	}
+
+	// Adjust to termsize
+	fmt.Fprintf(w, safeRepeat(" ", min(80, termWidth)-writtenHeaderChars-2))
+
+	if scoredObject.AnyBelowOrEqualToGrade(scorecard.GradeCritical) {
+		fmt.Fprintf(w, "💥\n")
+	} else if scoredObject.AnyBelowOrEqualToGrade(scorecard.GradeWarning) {
+		fmt.Fprintf(w, "🤔\n")
+	} else {
+		fmt.Fprintf(w, "✅\n")
+	}
+
+	for _, card := range scoredObject.Checks {
+		r := outputHumanStep(card, verboseOutput, termWidth)
+		io.Copy(w, r)
+	}
+
 	return w
 }
 
@@ -117,6 +322,17 @@ func outputHumanStep(card scorecard.TestScore, verboseOutput int, termWidth int)
 		fmt.Fprintln(w)
 	}
 
+	// The explanation is only set if it's enabled with --explain
+	if card.Check.Explanation != nil {
+		wrapWidth := termWidth - 13
+		if wrapWidth < 40 {
+			wrapWidth = 40
+		}
+		wrapper := wordwrap.Wrapper(wrapWidth, false)
+		fmt.Fprintln(w, wordwrap.Indent(wrapper(card.Check.Explanation.Rationale), "        Why: ", false))
+		fmt.Fprintln(w, wordwrap.Indent(wrapper(card.Check.Explanation.Remediation), "        Fix: ", false))
+	}
+
 	return w
 }
 
@@ -1,8 +1,11 @@
 package human
 
 import (
+	"bytes"
 	"io/ioutil"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -419,3 +422,246 @@ func TestHumanOutputWithLongObjectNames(t *testing.T) {
             nisl venenatis, elementum augue a, porttitor libero.
 `, string(all))
 }
+
+func TestHumanStreamMatchesHuman(t *testing.T) {
+	t.Parallel()
+	card := getTestCard()
+
+	var streamed bytes.Buffer
+	onScored := Stream(&streamed, 2, 100)
+	onScored((*card)["a"])
+
+	single := &scorecard.Scorecard{"a": (*card)["a"]}
+	expected, _ := ioutil.ReadAll(Human(single, 2, 100))
+
+	assert.Equal(t, string(expected), streamed.String())
+}
+
+func TestHumanOutputGroupedByNamespace(t *testing.T) {
+	t.Parallel()
+	card := getTestCard()
+	(*card)["c"] = &scorecard.ScoredObject{
+		TypeMeta: v1.TypeMeta{
+			Kind:       "Testing",
+			APIVersion: "v1",
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "baz",
+			Namespace: "foofoo",
+		},
+		Checks: []scorecard.TestScore{
+			{
+				Check: domain.Check{
+					Name: "test-critical",
+				},
+				Grade: scorecard.GradeCritical,
+			},
+		},
+	}
+
+	r := HumanGroupedByNamespace(card, 0, 100)
+	all, err := ioutil.ReadAll(r)
+	assert.Nil(t, err)
+	assert.Equal(t, `Namespace: (cluster-scoped)
+v1/Testing bar-no-namespace                                                   🤔
+    [WARNING] test-warning-two-comments
+        · a -> summary
+            description
+        · summary
+            description
+            More information: https://kube-score.com/whatever
+
+Namespace: foofoo
+v1/Testing foo in foofoo                                                      🤔
+    [WARNING] test-warning-two-comments
+        · a -> summary
+            description
+        · summary
+            description
+            More information: https://kube-score.com/whatever
+v1/Testing baz in foofoo                                                      💥
+    [CRITICAL] test-critical
+
+Summary by namespace:
+    (cluster-scoped): 0 critical, 1 warning, 0 ok
+    foofoo: 1 critical, 1 warning, 0 ok
+Total: 1 critical, 2 warning, 0 ok
+`, string(all))
+}
+
+func TestHumanOutputGroupedByCheck(t *testing.T) {
+	t.Parallel()
+	warning := scorecard.TestScore{
+		Check:    domain.Check{Name: "Test Warning", ID: "test-warning"},
+		Grade:    scorecard.GradeWarning,
+		Comments: []scorecard.TestScoreComment{{Path: "a", Summary: "summary", Description: "description"}},
+	}
+	critical := scorecard.TestScore{
+		Check: domain.Check{Name: "Test Critical", ID: "test-critical"},
+		Grade: scorecard.GradeCritical,
+	}
+	ok := scorecard.TestScore{
+		Check: domain.Check{Name: "Test OK", ID: "test-ok"},
+		Grade: scorecard.GradeAllOK,
+	}
+	skipped := scorecard.TestScore{
+		Check:   domain.Check{Name: "Test Skipped", ID: "test-skipped"},
+		Grade:   scorecard.GradeCritical,
+		Skipped: true,
+	}
+
+	card := &scorecard.Scorecard{
+		"a": &scorecard.ScoredObject{
+			TypeMeta:   v1.TypeMeta{Kind: "Testing", APIVersion: "v1"},
+			ObjectMeta: v1.ObjectMeta{Name: "foo", Namespace: "foofoo"},
+			Checks:     []scorecard.TestScore{warning, critical, ok, skipped},
+		},
+		"b": &scorecard.ScoredObject{
+			TypeMeta:   v1.TypeMeta{Kind: "Testing", APIVersion: "v1"},
+			ObjectMeta: v1.ObjectMeta{Name: "bar"},
+			Checks:     []scorecard.TestScore{warning, ok},
+		},
+	}
+
+	all, err := ioutil.ReadAll(HumanGroupedByCheck(card, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, `Test Warning (test-warning): 2 objects
+    [WARNING] v1/Testing foo in foofoo
+    [WARNING] v1/Testing bar
+Test Critical (test-critical): 1 object
+    [CRITICAL] v1/Testing foo in foofoo
+Total: 2 failed checks
+`, string(all))
+
+	// The comments are included in the verbose output
+	all, err = ioutil.ReadAll(HumanGroupedByCheck(card, 1))
+	assert.Nil(t, err)
+	assert.Equal(t, `Test Warning (test-warning): 2 objects
+    [WARNING] v1/Testing foo in foofoo
+        · a -> summary
+    [WARNING] v1/Testing bar
+        · a -> summary
+Test Critical (test-critical): 1 object
+    [CRITICAL] v1/Testing foo in foofoo
+Total: 2 failed checks
+`, string(all))
+}
+
+func TestHumanOutputSummary(t *testing.T) {
+	t.Parallel()
+	card := getTestCard()
+	(*card)["c"] = &scorecard.ScoredObject{
+		TypeMeta: v1.TypeMeta{
+			Kind:       "Testing",
+			APIVersion: "v1",
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "baz",
+			Namespace: "foofoo",
+		},
+		Checks: []scorecard.TestScore{
+			{
+				Check: domain.Check{
+					Name: "test-critical",
+				},
+				Grade: scorecard.GradeCritical,
+			},
+		},
+	}
+	(*card)["d"] = &scorecard.ScoredObject{
+		TypeMeta: v1.TypeMeta{
+			Kind:       "Testing",
+			APIVersion: "v1",
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: "ok",
+		},
+	}
+
+	r := Summary(card)
+	all, err := ioutil.ReadAll(r)
+	assert.Nil(t, err)
+	assert.Equal(t, `v1/Testing foo in foofoo: WARNING
+v1/Testing bar-no-namespace: WARNING
+v1/Testing baz in foofoo: CRITICAL
+v1/Testing ok: OK
+Total: 1 critical, 2 warning, 1 ok
+`, string(all))
+}
+
+func TestHumanOutputReport(t *testing.T) {
+	t.Parallel()
+	card := getTestCard()
+	(*card)["c"] = &scorecard.ScoredObject{
+		TypeMeta: v1.TypeMeta{
+			Kind:       "Widget",
+			APIVersion: "example.com/v1",
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: "unhandled",
+		},
+		FileLocation: domain.FileLocation{Name: "widget.yaml", Line: 1},
+	}
+
+	r := Report(card)
+	all, err := ioutil.ReadAll(r)
+	assert.Nil(t, err)
+	assert.Equal(t, `v1/Testing foo in foofoo (:0): 2 checks
+v1/Testing bar-no-namespace (:0): 2 checks
+example.com/v1/Widget unhandled (widget.yaml:1): 0 checks
+Total: 3 objects
+`, string(all))
+}
+
+func TestHumanOutputWithFooter(t *testing.T) {
+	t.Parallel()
+
+	metadata := scorecard.Metadata{
+		KubeScoreVersion:      "v1.2.3",
+		KubernetesVersion:     "v1.21",
+		Timestamp:             time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC),
+		EnabledOptionalChecks: []string{"container-seccomp-profile", "pvc-storageclass"},
+	}
+
+	all, err := ioutil.ReadAll(WithFooter(strings.NewReader("output\n"), 1, metadata))
+	assert.Nil(t, err)
+	assert.Equal(t, "output\nkube-score v1.2.3, kubernetes v1.21, 2021-06-01T12:00:00Z, optional checks: container-seccomp-profile, pvc-storageclass\n", string(all))
+
+	all, err = ioutil.ReadAll(WithFooter(strings.NewReader("output\n"), 0, metadata))
+	assert.Nil(t, err)
+	assert.Equal(t, "output\n", string(all))
+}
+
+func TestHumanOutputExplanation(t *testing.T) {
+	t.Parallel()
+
+	card := &scorecard.Scorecard{
+		"a": &scorecard.ScoredObject{
+			TypeMeta:   v1.TypeMeta{Kind: "Testing", APIVersion: "v1"},
+			ObjectMeta: v1.ObjectMeta{Name: "foo"},
+			Checks: []scorecard.TestScore{
+				{
+					Check: domain.Check{
+						Name: "test-warning",
+						Explanation: &domain.CheckExplanation{
+							Rationale:   "This is why the check matters, and it is long enough to be wrapped over multiple lines",
+							Remediation: "Fix it",
+						},
+					},
+					Grade:    scorecard.GradeWarning,
+					Comments: []scorecard.TestScoreComment{{Summary: "summary"}},
+				},
+			},
+		},
+	}
+
+	all, err := ioutil.ReadAll(Human(card, 0, 80))
+	assert.Nil(t, err)
+	assert.Equal(t, `v1/Testing foo                                                                🤔
+    [WARNING] test-warning
+        · summary
+        Why: This is why the check matters, and it is long enough to be wrapped
+             over multiple lines
+        Fix: Fix it
+`, string(all))
+}
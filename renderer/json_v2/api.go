@@ -16,6 +16,14 @@ type Check struct {
 	TargetType string `json:"target_type"`
 	Comment    string `json:"comment"`
 	Optional   bool   `json:"optional"`
+
+	// Explanation is only set if it's enabled with --explain
+	Explanation *Explanation `json:"explanation,omitempty"`
+}
+
+type Explanation struct {
+	Rationale   string `json:"rationale"`
+	Remediation string `json:"remediation"`
 }
 
 type ScoredObject struct {
@@ -28,19 +36,30 @@ type ScoredObject struct {
 }
 
 type TestScore struct {
-	Check    Check              `json:"check"`
-	Grade    scorecard.Grade    `json:"grade"`
-	Skipped  bool               `json:"skipped"`
-	Comments []TestScoreComment `json:"comments"`
+	Check      Check              `json:"check"`
+	Grade      scorecard.Grade    `json:"grade"`
+	Skipped    bool               `json:"skipped"`
+	SkipReason string             `json:"skip_reason,omitempty"`
+	Comments   []TestScoreComment `json:"comments"`
 }
 
 type TestScoreComment struct {
 	Path        string `json:"path"`
 	Summary     string `json:"summary"`
 	Description string `json:"description"`
+	Code        string `json:"code,omitempty"`
 }
 
 func Output(input *scorecard.Scorecard) io.Reader {
+	j, err := json.MarshalIndent(Objects(input), "", "    ")
+	if err != nil {
+		panic(err)
+	}
+	return bytes.NewBuffer(j)
+}
+
+// Objects converts all objects in the scorecard to the layout of the v2 format
+func Objects(input *scorecard.Scorecard) []ScoredObject {
 	var objs []ScoredObject
 
 	for k, v := range *input {
@@ -54,20 +73,17 @@ func Output(input *scorecard.Scorecard) io.Reader {
 		})
 	}
 
-	j, err := json.MarshalIndent(objs, "", "    ")
-	if err != nil {
-		panic(err)
-	}
-	return bytes.NewBuffer(j)
+	return objs
 }
 
 func convertTestScore(in []scorecard.TestScore) (res []TestScore) {
 	for _, v := range in {
 		res = append(res, TestScore{
-			Check:    convertCheck(v.Check),
-			Grade:    v.Grade,
-			Skipped:  v.Skipped,
-			Comments: convertComments(v.Comments),
+			Check:      convertCheck(v.Check),
+			Grade:      v.Grade,
+			Skipped:    v.Skipped,
+			SkipReason: string(v.SkipReason),
+			Comments:   convertComments(v.Comments),
 		})
 	}
 	return
@@ -79,17 +95,25 @@ func convertComments(in []scorecard.TestScoreComment) (res []TestScoreComment) {
 			Path:        v.Path,
 			Summary:     v.Summary,
 			Description: v.Description,
+			Code:        string(v.Code),
 		})
 	}
 	return
 }
 
 func convertCheck(v ks.Check) Check {
-	return Check{
+	c := Check{
 		Name:       v.Name,
 		ID:         v.ID,
 		TargetType: v.TargetType,
 		Comment:    v.Comment,
 		Optional:   v.Optional,
 	}
+	if v.Explanation != nil {
+		c.Explanation = &Explanation{
+			Rationale:   v.Explanation.Rationale,
+			Remediation: v.Explanation.Remediation,
+		}
+	}
+	return c
 }
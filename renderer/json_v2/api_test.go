@@ -0,0 +1,33 @@
+package json_v2
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestObjectsCommentCode(t *testing.T) {
+	t.Parallel()
+
+	card := scorecard.New()
+	o := card.NewObject(v1.TypeMeta{Kind: "Pod", APIVersion: "v1"}, v1.ObjectMeta{Name: "foo"}, false)
+	o.Checks = []scorecard.TestScore{{
+		Check: domain.Check{ID: "check"},
+		Grade: scorecard.GradeCritical,
+		Comments: []scorecard.TestScoreComment{
+			{Summary: "with code", Code: scorecard.CodeContainerWritableRootFS},
+			{Summary: "without code"},
+		},
+	}}
+
+	all, err := ioutil.ReadAll(Output(&card))
+	assert.Nil(t, err)
+	assert.Contains(t, string(all), `"code": "CONTAINER_WRITABLE_ROOTFS"`)
+	assert.Equal(t, 1, strings.Count(string(all), `"code"`))
+}
@@ -0,0 +1,64 @@
+package json_v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// CheckFindings is a check in the output grouped by check, with all objects that have failed it
+type CheckFindings struct {
+	Check    Check          `json:"check"`
+	Count    int            `json:"count"`
+	Findings []CheckFinding `json:"findings"`
+}
+
+// CheckFinding is an object that has failed a check
+type CheckFinding struct {
+	Object     string             `json:"object"`
+	APIVersion string             `json:"api_version"`
+	Kind       string             `json:"kind"`
+	Namespace  string             `json:"namespace,omitempty"`
+	Name       string             `json:"name"`
+	FileName   string             `json:"file_name"`
+	FileRow    int                `json:"file_row"`
+	Grade      scorecard.Grade    `json:"grade"`
+	Comments   []TestScoreComment `json:"comments"`
+}
+
+// ByCheck returns the checks that have failed for at least one object, together with the objects that have failed
+// them, as a JSON array with the most failed check first. See scorecard.Scorecard.ByCheck.
+func ByCheck(input *scorecard.Scorecard) io.Reader {
+	byCheck := input.ByCheck()
+
+	checks := make([]CheckFindings, 0, len(byCheck))
+	for _, c := range byCheck {
+		findings := make([]CheckFinding, 0, len(c.Findings))
+		for _, f := range c.Findings {
+			findings = append(findings, CheckFinding{
+				Object:     f.Object.ResourceRefKey(),
+				APIVersion: f.Object.TypeMeta.APIVersion,
+				Kind:       f.Object.TypeMeta.Kind,
+				Namespace:  f.Object.ObjectMeta.Namespace,
+				Name:       f.Object.ObjectMeta.Name,
+				FileName:   f.Object.FileLocation.Name,
+				FileRow:    f.Object.FileLocation.Line,
+				Grade:      f.Score.Grade,
+				Comments:   convertComments(f.Score.Comments),
+			})
+		}
+		checks = append(checks, CheckFindings{
+			Check:    convertCheck(c.Check),
+			Count:    len(findings),
+			Findings: findings,
+		})
+	}
+
+	j, err := json.MarshalIndent(checks, "", "    ")
+	if err != nil {
+		panic(err)
+	}
+	return bytes.NewBuffer(j)
+}
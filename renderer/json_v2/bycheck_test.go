@@ -0,0 +1,52 @@
+package json_v2
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestByCheck(t *testing.T) {
+	t.Parallel()
+
+	card := scorecard.New()
+	pod := card.NewObject(v1.TypeMeta{Kind: "Pod", APIVersion: "v1"}, v1.ObjectMeta{Name: "foo", Namespace: "bar"}, false)
+	pod.FileLocation = domain.FileLocation{Name: "pod.yaml", Line: 3}
+	pod.Checks = []scorecard.TestScore{
+		{Check: domain.Check{ID: "first"}, Grade: scorecard.GradeWarning, Comments: []scorecard.TestScoreComment{{Path: "a", Summary: "summary"}}},
+		{Check: domain.Check{ID: "second"}, Grade: scorecard.GradeCritical},
+		{Check: domain.Check{ID: "ok"}, Grade: scorecard.GradeAllOK},
+		{Check: domain.Check{ID: "skipped"}, Grade: scorecard.GradeCritical, Skipped: true},
+	}
+	other := card.NewObject(v1.TypeMeta{Kind: "Pod", APIVersion: "v1"}, v1.ObjectMeta{Name: "other", Namespace: "bar"}, false)
+	other.FileLocation = domain.FileLocation{Name: "pod.yaml", Line: 10}
+	other.Checks = []scorecard.TestScore{
+		{Check: domain.Check{ID: "second"}, Grade: scorecard.GradeWarning},
+	}
+
+	all, err := ioutil.ReadAll(ByCheck(&card))
+	assert.Nil(t, err)
+
+	var checks []CheckFindings
+	assert.Nil(t, json.Unmarshal(all, &checks))
+
+	// The check that most objects have failed is first
+	assert.Len(t, checks, 2)
+	assert.Equal(t, "second", checks[0].Check.ID)
+	assert.Equal(t, 2, checks[0].Count)
+	assert.Equal(t, []CheckFinding{
+		{Object: "Pod/v1/bar/foo", APIVersion: "v1", Kind: "Pod", Namespace: "bar", Name: "foo", FileName: "pod.yaml", FileRow: 3, Grade: scorecard.GradeCritical},
+		{Object: "Pod/v1/bar/other", APIVersion: "v1", Kind: "Pod", Namespace: "bar", Name: "other", FileName: "pod.yaml", FileRow: 10, Grade: scorecard.GradeWarning},
+	}, checks[0].Findings)
+
+	assert.Equal(t, "first", checks[1].Check.ID)
+	assert.Equal(t, 1, checks[1].Count)
+	assert.Len(t, checks[1].Findings, 1)
+	assert.Equal(t, []TestScoreComment{{Path: "a", Summary: "summary"}}, checks[1].Findings[0].Comments)
+}
@@ -0,0 +1,58 @@
+package json_v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// ScoredObjectLine is a single line in the JSON Lines output, it's the same as ScoredObject with the addition of the
+// grade of the object, which is the lowest grade of all checks that were not skipped.
+type ScoredObjectLine struct {
+	ScoredObject
+	Grade scorecard.Grade `json:"grade"`
+}
+
+// Stream returns a function that writes each scored object to w as a single line of JSON, as soon as it's called.
+// Each object is written to w with a single call to Write.
+func Stream(w io.Writer) func(*scorecard.ScoredObject) {
+	enc := json.NewEncoder(w)
+	return func(scoredObject *scorecard.ScoredObject) {
+		if err := enc.Encode(convertLine(scoredObject)); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// OutputLines returns all objects in the scorecard in the JSON Lines format, one object per line, sorted by object name
+func OutputLines(input *scorecard.Scorecard) io.Reader {
+	var keys []string
+	for k := range *input {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	w := bytes.NewBufferString("")
+	write := Stream(w)
+	for _, k := range keys {
+		write((*input)[k])
+	}
+	return w
+}
+
+func convertLine(v *scorecard.ScoredObject) ScoredObjectLine {
+	return ScoredObjectLine{
+		ScoredObject: ScoredObject{
+			ObjectName: v.ResourceRefKey(),
+			TypeMeta:   v.TypeMeta,
+			ObjectMeta: v.ObjectMeta,
+			Checks:     convertTestScore(v.Checks),
+			FileName:   v.FileLocation.Name,
+			FileRow:    v.FileLocation.Line,
+		},
+		Grade: v.WorstGrade(),
+	}
+}
@@ -0,0 +1,103 @@
+package json_v2
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestStreamLines(t *testing.T) {
+	t.Parallel()
+
+	objects := []*scorecard.ScoredObject{
+		{
+			TypeMeta:     v1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+			ObjectMeta:   v1.ObjectMeta{Name: "foo", Namespace: "bar"},
+			FileLocation: domain.FileLocation{Name: "/tmp/a.yaml", Line: 12},
+			Checks: []scorecard.TestScore{
+				{
+					Check:    domain.Check{ID: "first"},
+					Grade:    scorecard.GradeWarning,
+					Comments: []scorecard.TestScoreComment{{Summary: "first-a"}},
+				},
+				{
+					Check: domain.Check{ID: "second"},
+					Grade: scorecard.GradeAllOK,
+				},
+				{
+					Check:   domain.Check{ID: "skipped"},
+					Grade:   scorecard.GradeCritical,
+					Skipped: true,
+				},
+			},
+		},
+		{
+			TypeMeta:   v1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+			ObjectMeta: v1.ObjectMeta{Name: "foo"},
+			Checks: []scorecard.TestScore{
+				{
+					Check: domain.Check{ID: "third"},
+					Grade: scorecard.GradeCritical,
+				},
+			},
+		},
+	}
+
+	var w bytes.Buffer
+	stream := Stream(&w)
+
+	var lines []ScoredObjectLine
+	for _, o := range objects {
+		stream(o)
+
+		// Each object is available as a complete line as soon as it has been written
+		line, err := w.ReadBytes('\n')
+		assert.Nil(t, err)
+
+		var l ScoredObjectLine
+		assert.Nil(t, json.Unmarshal(line, &l))
+		lines = append(lines, l)
+	}
+	assert.Equal(t, 0, w.Len())
+
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "Pod/v1/bar/foo", lines[0].ObjectName)
+	assert.Equal(t, "bar", lines[0].ObjectMeta.Namespace)
+	assert.Equal(t, "/tmp/a.yaml", lines[0].FileName)
+	assert.Equal(t, 12, lines[0].FileRow)
+	assert.Equal(t, scorecard.GradeWarning, lines[0].Grade)
+	assert.Len(t, lines[0].Checks, 3)
+	assert.Equal(t, "first", lines[0].Checks[0].Check.ID)
+	assert.Equal(t, "first-a", lines[0].Checks[0].Comments[0].Summary)
+
+	assert.Equal(t, "Service/v1//foo", lines[1].ObjectName)
+	assert.Equal(t, scorecard.GradeCritical, lines[1].Grade)
+}
+
+func TestOutputLines(t *testing.T) {
+	t.Parallel()
+
+	card := scorecard.New()
+	for _, name := range []string{"b", "a"} {
+		o := card.NewObject(v1.TypeMeta{Kind: "Pod", APIVersion: "v1"}, v1.ObjectMeta{Name: name}, false)
+		o.Checks = []scorecard.TestScore{{Check: domain.Check{ID: "check"}, Grade: scorecard.GradeAllOK}}
+	}
+
+	scanner := bufio.NewScanner(OutputLines(&card))
+	var names []string
+	for scanner.Scan() {
+		var l ScoredObjectLine
+		assert.Nil(t, json.Unmarshal(scanner.Bytes(), &l))
+		assert.Equal(t, scorecard.GradeAllOK, l.Grade)
+		names = append(names, l.ObjectMeta.Name)
+	}
+	assert.Nil(t, scanner.Err())
+	assert.Equal(t, []string{"a", "b"}, names)
+}
@@ -0,0 +1,53 @@
+package json_v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// ObjectReport is an object in the report output, with the file that it was read from and the number of checks that
+// were not skipped
+type ObjectReport struct {
+	Object     string `json:"object"`
+	APIVersion string `json:"api_version"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	FileName   string `json:"file_name"`
+	FileRow    int    `json:"file_row"`
+	ChecksRun  int    `json:"checks_run"`
+}
+
+// Report returns all objects in the scorecard, as a JSON array sorted by object name
+func Report(input *scorecard.Scorecard) io.Reader {
+	var keys []string
+	for k := range *input {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	objs := make([]ObjectReport, 0, len(keys))
+	for _, k := range keys {
+		o := (*input)[k]
+		objs = append(objs, ObjectReport{
+			Object:     k,
+			APIVersion: o.TypeMeta.APIVersion,
+			Kind:       o.TypeMeta.Kind,
+			Namespace:  o.ObjectMeta.Namespace,
+			Name:       o.ObjectMeta.Name,
+			FileName:   o.FileLocation.Name,
+			FileRow:    o.FileLocation.Line,
+			ChecksRun:  o.ChecksRun(),
+		})
+	}
+
+	j, err := json.MarshalIndent(objs, "", "    ")
+	if err != nil {
+		panic(err)
+	}
+	return bytes.NewBuffer(j)
+}
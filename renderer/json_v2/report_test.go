@@ -0,0 +1,45 @@
+package json_v2
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestReport(t *testing.T) {
+	t.Parallel()
+
+	card := scorecard.New()
+	pod := card.NewObject(v1.TypeMeta{Kind: "Pod", APIVersion: "v1"}, v1.ObjectMeta{Name: "foo", Namespace: "bar"}, false)
+	pod.FileLocation = domain.FileLocation{Name: "pod.yaml", Line: 3}
+	pod.Checks = []scorecard.TestScore{
+		{Check: domain.Check{ID: "first"}, Grade: scorecard.GradeWarning},
+		{Check: domain.Check{ID: "second"}, Grade: scorecard.GradeAllOK},
+		{Check: domain.Check{ID: "skipped"}, Grade: scorecard.GradeCritical, Skipped: true},
+	}
+	crd := card.NewObject(v1.TypeMeta{Kind: "Widget", APIVersion: "example.com/v1"}, v1.ObjectMeta{Name: "foo"}, false)
+	crd.FileLocation = domain.FileLocation{Name: "widget.yaml", Line: 1}
+
+	// The ServiceAccount has not been scored, and the Pod is not listed twice
+	documents := []domain.Document{
+		{TypeMeta: v1.TypeMeta{Kind: "ServiceAccount", APIVersion: "v1"}, ObjectMeta: v1.ObjectMeta{Name: "foo", Namespace: "bar"}, Location: domain.FileLocation{Name: "sa.yaml", Line: 1}},
+		{TypeMeta: v1.TypeMeta{Kind: "Pod", APIVersion: "v1"}, ObjectMeta: v1.ObjectMeta{Name: "foo", Namespace: "bar"}, Location: domain.FileLocation{Name: "pod.yaml", Line: 3}},
+	}
+
+	all, err := ioutil.ReadAll(Report(card.WithDocuments(documents)))
+	assert.Nil(t, err)
+
+	var objs []ObjectReport
+	assert.Nil(t, json.Unmarshal(all, &objs))
+	assert.Equal(t, []ObjectReport{
+		{Object: "Pod/v1/bar/foo", APIVersion: "v1", Kind: "Pod", Namespace: "bar", Name: "foo", FileName: "pod.yaml", FileRow: 3, ChecksRun: 2},
+		{Object: "ServiceAccount/v1/bar/foo", APIVersion: "v1", Kind: "ServiceAccount", Namespace: "bar", Name: "foo", FileName: "sa.yaml", FileRow: 1, ChecksRun: 0},
+		{Object: "Widget/example.com/v1//foo", APIVersion: "example.com/v1", Kind: "Widget", Name: "foo", FileName: "widget.yaml", FileRow: 1, ChecksRun: 0},
+	}, objs)
+}
@@ -0,0 +1,39 @@
+package json_v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// ObjectSummary is an object in the summary output, with only the worst grade of all checks that were not skipped
+type ObjectSummary struct {
+	Object     string          `json:"object"`
+	WorstGrade scorecard.Grade `json:"worst_grade"`
+}
+
+// Summary returns the worst grade of each object in the scorecard, as a JSON array sorted by object name
+func Summary(input *scorecard.Scorecard) io.Reader {
+	var keys []string
+	for k := range *input {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	objs := make([]ObjectSummary, 0, len(keys))
+	for _, k := range keys {
+		objs = append(objs, ObjectSummary{
+			Object:     k,
+			WorstGrade: (*input)[k].WorstGrade(),
+		})
+	}
+
+	j, err := json.MarshalIndent(objs, "", "    ")
+	if err != nil {
+		panic(err)
+	}
+	return bytes.NewBuffer(j)
+}
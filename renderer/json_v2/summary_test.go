@@ -0,0 +1,40 @@
+package json_v2
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestSummary(t *testing.T) {
+	t.Parallel()
+
+	card := scorecard.New()
+	warning := card.NewObject(v1.TypeMeta{Kind: "Pod", APIVersion: "v1"}, v1.ObjectMeta{Name: "foo", Namespace: "bar"}, false)
+	warning.Checks = []scorecard.TestScore{
+		{Check: domain.Check{ID: "first"}, Grade: scorecard.GradeWarning},
+		{Check: domain.Check{ID: "second"}, Grade: scorecard.GradeAllOK},
+		{Check: domain.Check{ID: "skipped"}, Grade: scorecard.GradeCritical, Skipped: true},
+	}
+	card.NewObject(v1.TypeMeta{Kind: "Service", APIVersion: "v1"}, v1.ObjectMeta{Name: "foo", Namespace: "bar"}, false)
+
+	all, err := ioutil.ReadAll(Summary(&card))
+	assert.Nil(t, err)
+
+	var objs []ObjectSummary
+	assert.Nil(t, json.Unmarshal(all, &objs))
+	assert.Equal(t, []ObjectSummary{
+		{Object: "Pod/v1/bar/foo", WorstGrade: scorecard.GradeWarning},
+		{Object: "Service/v1/bar/foo", WorstGrade: scorecard.GradeAllOK},
+	}, objs)
+
+	all, err = ioutil.ReadAll(Summary(&scorecard.Scorecard{}))
+	assert.Nil(t, err)
+	assert.Equal(t, "[]", string(all))
+}
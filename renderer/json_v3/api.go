@@ -0,0 +1,33 @@
+package json_v3
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/zegl/kube-score/renderer/json_v2"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// Report is the layout of the v3 format. The objects have the same layout as in the v2 format, and are
+// wrapped together with the metadata of the run.
+type Report struct {
+	Metadata scorecard.Metadata     `json:"metadata"`
+	Objects  []json_v2.ScoredObject `json:"objects"`
+}
+
+func Output(input *scorecard.Scorecard, metadata scorecard.Metadata) io.Reader {
+	objects := json_v2.Objects(input)
+	if objects == nil {
+		objects = []json_v2.ScoredObject{}
+	}
+
+	j, err := json.MarshalIndent(Report{
+		Metadata: metadata,
+		Objects:  objects,
+	}, "", "    ")
+	if err != nil {
+		panic(err)
+	}
+	return bytes.NewBuffer(j)
+}
@@ -0,0 +1,63 @@
+package json_v3
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestOutput(t *testing.T) {
+	t.Parallel()
+
+	card := &scorecard.Scorecard{
+		"a": &scorecard.ScoredObject{
+			TypeMeta:     v1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+			ObjectMeta:   v1.ObjectMeta{Name: "foo"},
+			FileLocation: domain.FileLocation{Name: "/tmp/a.yaml", Line: 12},
+			Checks: []scorecard.TestScore{
+				{
+					Check: domain.Check{ID: "first"},
+					Grade: scorecard.GradeWarning,
+				},
+			},
+		},
+	}
+	metadata := scorecard.Metadata{
+		KubeScoreVersion:      "v1.2.3",
+		KubernetesVersion:     "v1.21",
+		Timestamp:             time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC),
+		EnabledOptionalChecks: []string{"container-seccomp-profile"},
+	}
+
+	r, err := ioutil.ReadAll(Output(card, metadata))
+	assert.Nil(t, err)
+
+	var report struct {
+		Metadata map[string]interface{}   `json:"metadata"`
+		Objects  []map[string]interface{} `json:"objects"`
+	}
+	assert.Nil(t, json.Unmarshal(r, &report))
+	assert.Equal(t, map[string]interface{}{
+		"kube_score_version":      "v1.2.3",
+		"kubernetes_version":      "v1.21",
+		"timestamp":               "2021-06-01T12:00:00Z",
+		"enabled_optional_checks": []interface{}{"container-seccomp-profile"},
+	}, report.Metadata)
+	assert.Len(t, report.Objects, 1)
+	assert.Equal(t, "/tmp/a.yaml", report.Objects[0]["file_name"])
+}
+
+func TestOutputEmpty(t *testing.T) {
+	t.Parallel()
+
+	r, err := ioutil.ReadAll(Output(&scorecard.Scorecard{}, scorecard.Metadata{}))
+	assert.Nil(t, err)
+	assert.Contains(t, string(r), `"objects": []`)
+}
@@ -4,32 +4,119 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"sort"
 
 	"github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/sarif"
+	"github.com/zegl/kube-score/score/checks"
 	"github.com/zegl/kube-score/scorecard"
 )
 
+// checksDocumentationURL is the list of all checks, the helpUri of each rule links to the row of the check
+const checksDocumentationURL = "https://github.com/zegl/kube-score/blob/master/README_CHECKS.md"
+
+// Options changes what is included in the SARIF output
+type Options struct {
+	// IncludeSkipped includes skipped checks as results of the kind "notApplicable"
+	IncludeSkipped bool
+
+	// Metadata is added to the run, if set
+	Metadata *scorecard.Metadata
+}
+
+// Output renders the scorecard as SARIF, checks that are skipped are not included
 func Output(input *scorecard.Scorecard) io.Reader {
+	return OutputWithOptions(input, Options{})
+}
+
+// OutputWithSkipped is the same as Output, but also includes skipped checks as results of the kind "notApplicable"
+func OutputWithSkipped(input *scorecard.Scorecard) io.Reader {
+	return OutputWithOptions(input, Options{IncludeSkipped: true})
+}
+
+// OutputWithOptions renders the scorecard as SARIF
+func OutputWithOptions(input *scorecard.Scorecard, opts Options) io.Reader {
+	includeSkipped := opts.IncludeSkipped
+
 	var results []sarif.Results
 	var rules []sarif.Rules
 
-	addRule := func(check domain.Check) {
-		for _, r := range rules {
+	// addRule adds the check as a rule (if it's not already added), and returns the index of the rule
+	addRule := func(check domain.Check) int {
+		for i, r := range rules {
 			if r.ID == check.ID {
-				return
+				return i
 			}
 		}
 
 		rules = append(rules, sarif.Rules{
 			ID:   check.ID,
 			Name: check.Name,
+			ShortDescription: sarif.Message{
+				Text: check.Name,
+			},
+			FullDescription: sarif.Message{
+				Text: check.Comment,
+			},
+			HelpURI: checksDocumentationURL + "#" + check.ID,
+			Help:    ruleHelp(check),
+			DefaultConfiguration: sarif.DefaultConfiguration{
+				Level: defaultLevel(check),
+			},
 		})
+		return len(rules) - 1
+	}
+
+	// Iterate over the objects in a stable order
+	var keys []string
+	for k := range *input {
+		keys = append(keys, k)
 	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		v := (*input)[key]
+
+		locations := []sarif.Locations{
+			{
+				PhysicalLocation: sarif.PhysicalLocation{
+					ArtifactLocation: sarif.ArtifactLocation{
+						URI: "file://" + v.FileLocation.Name,
+					},
+					Region: sarif.Region{
+						StartLine: v.FileLocation.Line,
+					},
+					ContextRegion: sarif.ContextRegion{
+						StartLine: v.FileLocation.Line,
+					},
+				},
+			},
+		}
 
-	for _, v := range *input {
 		for _, check := range v.Checks {
 			if check.Skipped {
+				if !includeSkipped {
+					continue
+				}
+
+				message := "Skipped"
+				if len(check.Comments) > 0 {
+					message = check.Comments[0].Summary
+				}
+
+				results = append(results, sarif.Results{
+					Message: sarif.Message{
+						Text: message,
+					},
+					RuleID:    check.Check.ID,
+					RuleIndex: addRule(check.Check),
+					Kind:      "notApplicable",
+					Level:     "none",
+					Properties: sarif.ResultsProperties{
+						SkipReason: string(check.SkipReason),
+					},
+					Locations: locations,
+				})
 				continue
 			}
 
@@ -43,31 +130,22 @@ func Output(input *scorecard.Scorecard) io.Reader {
 				continue
 			}
 
-			addRule(check.Check)
+			ruleIndex := addRule(check.Check)
 
 			for _, comment := range check.Comments {
 				results = append(results, sarif.Results{
 					Message: sarif.Message{
 						Text: comment.Summary,
 					},
-					RuleID: check.Check.ID,
-					Level:  level,
+					RuleID:    check.Check.ID,
+					RuleIndex: ruleIndex,
+					Level:     level,
 					Properties: sarif.ResultsProperties{
 						IssueConfidence: "HIGH",
 						IssueSeverity:   "HIGH",
+						Code:            string(comment.Code),
 					},
-					Locations: []sarif.Locations{
-						{
-							PhysicalLocation: sarif.PhysicalLocation{
-								ArtifactLocation: sarif.ArtifactLocation{
-									URI: "file://" + v.FileLocation.Name,
-								},
-								ContextRegion: sarif.ContextRegion{
-									StartLine: v.FileLocation.Line,
-								},
-							},
-						},
-					},
+					Locations: locations,
 				})
 			}
 		}
@@ -82,6 +160,20 @@ func Output(input *scorecard.Scorecard) io.Reader {
 		},
 		Results: results,
 	}
+	if opts.Metadata != nil {
+		run.Tool.Driver.Version = opts.Metadata.KubeScoreVersion
+		run.Invocations = []sarif.Invocations{
+			{
+				ExecutionSuccessful: true,
+				EndTimeUtc:          opts.Metadata.Timestamp.UTC(),
+			},
+		}
+		run.Properties = sarif.Properties{
+			KubernetesVersion:     opts.Metadata.KubernetesVersion,
+			EnabledOptionalChecks: opts.Metadata.EnabledOptionalChecks,
+		}
+	}
+
 	res := sarif.Sarif{
 		Runs:    []sarif.Run{run},
 		Version: "2.1.0",
@@ -94,3 +186,24 @@ func Output(input *scorecard.Scorecard) io.Reader {
 	}
 	return bytes.NewBuffer(j)
 }
+
+// ruleHelp returns the explanation of the check as the help text of the rule, if the check has an explanation.
+// The explanation is always included, also if it's not added to the checks with --explain.
+func ruleHelp(check domain.Check) *sarif.Message {
+	explanation, ok := checks.Explanation(check.ID)
+	if !ok {
+		return nil
+	}
+	return &sarif.Message{
+		Text: explanation.Rationale + " " + explanation.Remediation,
+	}
+}
+
+// defaultLevel returns the SARIF level that is used for a rule when no result specific level is set.
+// Checks that are enabled by default are treated as errors, and optional checks as warnings.
+func defaultLevel(check domain.Check) string {
+	if check.Optional {
+		return "warning"
+	}
+	return "error"
+}
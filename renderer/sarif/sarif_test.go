@@ -0,0 +1,185 @@
+package sarif
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/sarif"
+	"github.com/zegl/kube-score/score/checks"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestSarifOutputRules(t *testing.T) {
+	t.Parallel()
+
+	check := func(id string, optional bool) domain.Check {
+		return domain.Check{
+			Name:     id + "-name",
+			ID:       id,
+			Comment:  id + "-comment",
+			Optional: optional,
+		}
+	}
+
+	card := &scorecard.Scorecard{
+		"a": &scorecard.ScoredObject{
+			TypeMeta:     v1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+			ObjectMeta:   v1.ObjectMeta{Name: "foo"},
+			FileLocation: domain.FileLocation{Name: "/tmp/a.yaml", Line: 12},
+			Checks: []scorecard.TestScore{
+				{
+					Check:    check("first", false),
+					Grade:    scorecard.GradeCritical,
+					Comments: []scorecard.TestScoreComment{{Summary: "first-a"}, {Summary: "first-b"}},
+				},
+				{
+					Check:    check("second", true),
+					Grade:    scorecard.GradeWarning,
+					Comments: []scorecard.TestScoreComment{{Summary: "second-a", Code: scorecard.CodeContainerPrivileged}},
+				},
+				{
+					Check:    check("ok", false),
+					Grade:    scorecard.GradeAllOK,
+					Comments: []scorecard.TestScoreComment{{Summary: "ok-a"}},
+				},
+			},
+		},
+	}
+
+	r, err := ioutil.ReadAll(Output(card))
+	assert.Nil(t, err)
+
+	var res sarif.Sarif
+	assert.Nil(t, json.Unmarshal(r, &res))
+	assert.Len(t, res.Runs, 1)
+
+	rules := res.Runs[0].Tool.Driver.Rules
+	assert.Len(t, rules, 2)
+	assert.Equal(t, "first", rules[0].ID)
+	assert.Equal(t, "first-name", rules[0].ShortDescription.Text)
+	assert.Equal(t, "first-comment", rules[0].FullDescription.Text)
+	assert.Equal(t, "https://github.com/zegl/kube-score/blob/master/README_CHECKS.md#first", rules[0].HelpURI)
+	assert.Equal(t, "error", rules[0].DefaultConfiguration.Level)
+	assert.Equal(t, "second", rules[1].ID)
+	assert.Equal(t, "warning", rules[1].DefaultConfiguration.Level)
+
+	results := res.Runs[0].Results
+	assert.Len(t, results, 3)
+	assert.Equal(t, "first", results[1].RuleID)
+	assert.Equal(t, 0, results[1].RuleIndex)
+	assert.Equal(t, "second", results[2].RuleID)
+	assert.Equal(t, 1, results[2].RuleIndex)
+	assert.Equal(t, "", results[1].Properties.Code)
+	assert.Equal(t, "CONTAINER_PRIVILEGED", results[2].Properties.Code)
+	assert.Equal(t, "file:///tmp/a.yaml", results[2].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 12, results[2].Locations[0].PhysicalLocation.Region.StartLine)
+}
+
+func TestSarifOutputRuleHelp(t *testing.T) {
+	t.Parallel()
+
+	// The explanation is used as the help of the rule, also if it's not added to the check with --explain
+	card := &scorecard.Scorecard{
+		"a": &scorecard.ScoredObject{
+			TypeMeta:   v1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+			ObjectMeta: v1.ObjectMeta{Name: "foo"},
+			Checks: []scorecard.TestScore{
+				{
+					Check:    domain.Check{ID: "container-image-tag", Name: "Container Image Tag"},
+					Grade:    scorecard.GradeCritical,
+					Comments: []scorecard.TestScoreComment{{Summary: "Image with latest tag"}},
+				},
+				{
+					Check:    domain.Check{ID: "unknown-check", Name: "Unknown Check"},
+					Grade:    scorecard.GradeCritical,
+					Comments: []scorecard.TestScoreComment{{Summary: "Unknown"}},
+				},
+			},
+		},
+	}
+
+	r, err := ioutil.ReadAll(Output(card))
+	assert.Nil(t, err)
+
+	var res sarif.Sarif
+	assert.Nil(t, json.Unmarshal(r, &res))
+
+	rules := res.Runs[0].Tool.Driver.Rules
+	assert.Len(t, rules, 2)
+	explanation, ok := checks.Explanation("container-image-tag")
+	assert.True(t, ok)
+	if assert.NotNil(t, rules[0].Help) {
+		assert.Equal(t, explanation.Rationale+" "+explanation.Remediation, rules[0].Help.Text)
+	}
+	assert.Equal(t, "https://github.com/zegl/kube-score/blob/master/README_CHECKS.md#container-image-tag", rules[0].HelpURI)
+	assert.Nil(t, rules[1].Help)
+}
+
+func TestSarifOutputWithSkipped(t *testing.T) {
+	t.Parallel()
+
+	card := &scorecard.Scorecard{
+		"a": &scorecard.ScoredObject{
+			TypeMeta:   v1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+			ObjectMeta: v1.ObjectMeta{Name: "foo"},
+			Checks: []scorecard.TestScore{
+				{
+					Check:      domain.Check{ID: "skipped"},
+					Skipped:    true,
+					SkipReason: scorecard.SkipReasonDisabled,
+					Comments:   []scorecard.TestScoreComment{{Summary: "Skipped because the optional check skipped is not enabled"}},
+				},
+			},
+		},
+	}
+
+	r, err := ioutil.ReadAll(Output(card))
+	assert.Nil(t, err)
+	var res sarif.Sarif
+	assert.Nil(t, json.Unmarshal(r, &res))
+	assert.Len(t, res.Runs[0].Results, 0)
+
+	r, err = ioutil.ReadAll(OutputWithSkipped(card))
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(r, &res))
+	assert.Len(t, res.Runs[0].Results, 1)
+
+	result := res.Runs[0].Results[0]
+	assert.Equal(t, "skipped", result.RuleID)
+	assert.Equal(t, "notApplicable", result.Kind)
+	assert.Equal(t, "none", result.Level)
+	assert.Equal(t, "disabled", result.Properties.SkipReason)
+	assert.Equal(t, "Skipped because the optional check skipped is not enabled", result.Message.Text)
+}
+
+func TestSarifOutputWithMetadata(t *testing.T) {
+	t.Parallel()
+
+	metadata := &scorecard.Metadata{
+		KubeScoreVersion:      "v1.2.3",
+		KubernetesVersion:     "v1.21",
+		Timestamp:             time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC),
+		EnabledOptionalChecks: []string{"container-seccomp-profile"},
+	}
+
+	r, err := ioutil.ReadAll(OutputWithOptions(&scorecard.Scorecard{}, Options{Metadata: metadata}))
+	assert.Nil(t, err)
+
+	var res sarif.Sarif
+	assert.Nil(t, json.Unmarshal(r, &res))
+	assert.Len(t, res.Runs, 1)
+
+	run := res.Runs[0]
+	assert.Equal(t, "v1.2.3", run.Tool.Driver.Version)
+	assert.Len(t, run.Invocations, 1)
+	assert.True(t, run.Invocations[0].ExecutionSuccessful)
+	assert.Equal(t, metadata.Timestamp, run.Invocations[0].EndTimeUtc)
+	assert.Equal(t, "v1.21", run.Properties.KubernetesVersion)
+	assert.Equal(t, []string{"container-seccomp-profile"}, run.Properties.EnabledOptionalChecks)
+}
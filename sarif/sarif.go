@@ -11,14 +11,23 @@ type Sarif struct {
 }
 
 type Rules struct {
-	ID      string `json:"id,omitempty"`
-	Name    string `json:"name,omitempty"`
-	HelpURI string `json:"helpUri,omitempty"`
+	ID                   string               `json:"id,omitempty"`
+	Name                 string               `json:"name,omitempty"`
+	ShortDescription     Message              `json:"shortDescription,omitempty"`
+	FullDescription      Message              `json:"fullDescription,omitempty"`
+	HelpURI              string               `json:"helpUri,omitempty"`
+	Help                 *Message             `json:"help,omitempty"`
+	DefaultConfiguration DefaultConfiguration `json:"defaultConfiguration,omitempty"`
+}
+
+type DefaultConfiguration struct {
+	Level string `json:"level,omitempty"`
 }
 
 type Driver struct {
-	Name  string  `json:"name,omitempty"`
-	Rules []Rules `json:"rules,omitempty"`
+	Name    string  `json:"name,omitempty"`
+	Version string  `json:"version,omitempty"`
+	Rules   []Rules `json:"rules,omitempty"`
 }
 
 type Tool struct {
@@ -49,6 +58,8 @@ type Invocations struct {
 }
 
 type Properties struct {
+	KubernetesVersion     string   `json:"kubernetes_version,omitempty"`
+	EnabledOptionalChecks []string `json:"enabled_optional_checks,omitempty"`
 }
 
 type Message struct {
@@ -87,11 +98,14 @@ type Locations struct {
 type ResultsProperties struct {
 	IssueConfidence string `json:"issue_confidence,omitempty"`
 	IssueSeverity   string `json:"issue_severity,omitempty"`
+	SkipReason      string `json:"skip_reason,omitempty"`
+	Code            string `json:"code,omitempty"`
 }
 
 type Results struct {
 	Message    Message           `json:"message,omitempty"`
 	Level      string            `json:"level,omitempty"`
+	Kind       string            `json:"kind,omitempty"`
 	Locations  []Locations       `json:"locations,omitempty"`
 	Properties ResultsProperties `json:"properties,omitempty"`
 	RuleID     string            `json:"ruleId,omitempty"`
@@ -7,19 +7,38 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
+	"github.com/zegl/kube-score/config"
 	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/score/checks"
 	"github.com/zegl/kube-score/score/internal"
 	"github.com/zegl/kube-score/scorecard"
 )
 
-func Register(allChecks *checks.Checks, allHPAs []ks.HpaTargeter, allServices []ks.Service) {
+// DefaultMaxSurgePercentage is the highest maxSurge, as a percentage of the replicas, that is accepted by the
+// "Deployment MaxSurge Footprint" check if no other percentage is configured
+const DefaultMaxSurgePercentage = 50
+
+func Register(allChecks *checks.Checks, cnf config.Configuration, allHPAs []ks.HpaTargeter, allServices []ks.Service) {
+	maxSurgePercentage := cnf.MaxSurgePercentage
+	if maxSurgePercentage == 0 {
+		maxSurgePercentage = DefaultMaxSurgePercentage
+	}
+
 	allChecks.RegisterDeploymentCheck("Deployment has host PodAntiAffinity", "Makes sure that a podAntiAffinity has been set that prevents multiple pods from being scheduled on the same node. https://kubernetes.io/docs/concepts/configuration/assign-pod-node/", deploymentHasAntiAffinity)
 	allChecks.RegisterStatefulSetCheck("StatefulSet has host PodAntiAffinity", "Makes sure that a podAntiAffinity has been set that prevents multiple pods from being scheduled on the same node. https://kubernetes.io/docs/concepts/configuration/assign-pod-node/", statefulsetHasAntiAffinity)
+	allChecks.RegisterOptionalStatefulSetCheck("StatefulSet Pod AntiAffinity", "Makes sure that StatefulSets with 3 or more replicas, such as quorum based systems, have a podAntiAffinity that spreads the pods over nodes or zones", statefulsetPodAntiAffinity)
 
+	allChecks.RegisterOptionalDeploymentCheck("Deployment MaxSurge Footprint", "Makes sure that the maxSurge of Deployments is not larger than 50% of the replicas, which temporarily increases the resource usage of the Deployment during rollouts. The percentage can be changed with --max-surge-percentage", deploymentMaxSurgeFootprint(maxSurgePercentage))
+	allChecks.RegisterOptionalDeploymentCheck("Deployment Min Ready Seconds", "Makes sure that Deployments that are targeted by a Service have minReadySeconds set, so that pods that crash right after becoming ready stop the rollout", deploymentMinReadySeconds(allServices))
+	allChecks.CrossObject("Deployment Min Ready Seconds")
 	allChecks.RegisterDeploymentCheck("Deployment targeted by HPA does not have replicas configured", "Makes sure that Deployments using a HorizontalPodAutoscaler doesn't have a statically configured replica count set", hpaDeploymentNoReplicas(allHPAs))
+	allChecks.CrossObject("Deployment targeted by HPA does not have replicas configured")
 	allChecks.RegisterStatefulSetCheck("StatefulSet has ServiceName", "Makes sure that StatefulSets have an existing headless serviceName.", statefulsetHasServiceName(allServices))
+	allChecks.CrossObject("StatefulSet has ServiceName")
+
+	allChecks.RegisterDaemonSetCheck("DaemonSet UpdateStrategy", "Makes sure that the update strategy of DaemonSets can roll out new pods, maxUnavailable can only be 0 together with maxSurge on Kubernetes v1.22 and later", daemonSetUpdateStrategy(cnf.KubernetesVersion))
 
 	allChecks.RegisterDeploymentCheck("Deployment Pod Selector labels match template metadata labels", "Ensure the StatefulSet selector labels match the template metadata labels.", deploymentSelectorLabelsMatching)
 	allChecks.RegisterStatefulSetCheck("StatefulSet Pod Selector labels match template metadata labels", "Ensure the StatefulSet selector labels match the template metadata labels.", statefulSetSelectorLabelsMatching)
@@ -53,6 +72,91 @@ func hpaDeploymentNoReplicas(allHPAs []ks.HpaTargeter) func(deployment appsv1.De
 	}
 }
 
+// deploymentMaxSurgeFootprint returns a function that checks that the maxSurge of a Deployment is not larger than
+// maxSurgePercentage of the replicas. Both the maxSurge and the threshold are resolved against the replicas and rounded
+// up, the same way as Kubernetes resolves maxSurge, so a Deployment with a single replica may always surge with one pod.
+func deploymentMaxSurgeFootprint(maxSurgePercentage int) func(appsv1.Deployment) (scorecard.TestScore, error) {
+	return func(deployment appsv1.Deployment) (score scorecard.TestScore, err error) {
+		strategy := deployment.Spec.Strategy
+		if strategy.Type == appsv1.RecreateDeploymentStrategyType {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", "Skipped because the deployment uses the Recreate strategy", "")
+			return
+		}
+
+		// Default values from the Deployment API
+		replicas := 1
+		if deployment.Spec.Replicas != nil {
+			replicas = int(*deployment.Spec.Replicas)
+		}
+		maxSurge := intstr.FromString("25%")
+		if strategy.RollingUpdate != nil && strategy.RollingUpdate.MaxSurge != nil {
+			maxSurge = *strategy.RollingUpdate.MaxSurge
+		}
+
+		surge, err := intstr.GetScaledValueFromIntOrPercent(&maxSurge, replicas, true)
+		if err != nil {
+			return score, fmt.Errorf("invalid maxSurge %q: %w", maxSurge.String(), err)
+		}
+		threshold := intstr.FromString(fmt.Sprintf("%d%%", maxSurgePercentage))
+		maxAllowedSurge, err := intstr.GetScaledValueFromIntOrPercent(&threshold, replicas, true)
+		if err != nil {
+			return score, err
+		}
+
+		if surge <= maxAllowedSurge {
+			score.Grade = scorecard.GradeAllOK
+			return
+		}
+
+		score.Grade = scorecard.GradeWarning
+		score.AddComment("spec.strategy.rollingUpdate.maxSurge",
+			fmt.Sprintf("The maxSurge of %s allows more than %d%% additional pods during rollouts", maxSurge.String(), maxSurgePercentage),
+			fmt.Sprintf("During a rollout, up to %d pods (%d replicas + %d surge) can be running at the same time, and the resource usage of the Deployment temporarily increases by the same amount. "+
+				"This can fail the rollout on clusters with little spare capacity. Lower spec.strategy.rollingUpdate.maxSurge.", replicas+surge, replicas, surge),
+		)
+		return
+	}
+}
+
+// deploymentMinReadySeconds returns a function that checks that Deployments that serve traffic, that are targeted by a
+// Service in the same namespace, have minReadySeconds set
+func deploymentMinReadySeconds(allServices []ks.Service) func(appsv1.Deployment) (scorecard.TestScore, error) {
+	return func(deployment appsv1.Deployment) (score scorecard.TestScore, err error) {
+		targeted := false
+		for _, service := range allServices {
+			s := service.Service()
+			if s.Namespace != deployment.Namespace || len(s.Spec.Selector) == 0 {
+				continue
+			}
+			if internal.LabelSelectorMatchesLabels(s.Spec.Selector, deployment.Spec.Template.GetObjectMeta().GetLabels()) {
+				targeted = true
+				break
+			}
+		}
+
+		if !targeted {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", "Skipped because the deployment is not targeted by a Service", "")
+			return
+		}
+
+		if deployment.Spec.MinReadySeconds > 0 {
+			score.Grade = scorecard.GradeAllOK
+			return
+		}
+
+		score.Grade = scorecard.GradeWarning
+		score.AddComment("spec.minReadySeconds", "The deployment does not have minReadySeconds set",
+			"Without minReadySeconds, a new pod counts as available as soon as it's ready, even if it crashes right after, and the rollout continues to replace the old pods. "+
+				"Set minReadySeconds to a small value, such as 10, so that pods have to stay ready for a while before the rollout proceeds.",
+		)
+		return
+	}
+}
+
 func deploymentHasAntiAffinity(deployment appsv1.Deployment) (score scorecard.TestScore, err error) {
 	// Ignore if the deployment only has a single replica
 	// If replicas is not explicitly set, we'll still warn if the anti affinity is missing
@@ -117,9 +221,54 @@ func statefulsetHasAntiAffinity(statefulset appsv1.StatefulSet) (score scorecard
 	return
 }
 
+// spreadTopologyKeys are the topology keys that are accepted by the "StatefulSet Pod AntiAffinity" check
+var spreadTopologyKeys = map[string]struct{}{
+	"kubernetes.io/hostname":                 {},
+	"topology.kubernetes.io/zone":            {},
+	"failure-domain.beta.kubernetes.io/zone": {},
+}
+
+// statefulsetPodAntiAffinity checks that StatefulSets with 3 or more replicas spread their pods over nodes or zones.
+// Quorum based systems lose the quorum if a majority of the replicas are running on the same node.
+func statefulsetPodAntiAffinity(statefulset appsv1.StatefulSet) (score scorecard.TestScore, err error) {
+	// replicas defaults to 1
+	replicas := int32(1)
+	if statefulset.Spec.Replicas != nil {
+		replicas = *statefulset.Spec.Replicas
+	}
+
+	if replicas < 3 {
+		score.Skipped = true
+		score.AddComment("", "Skipped because the statefulset has less than 3 replicas", "")
+		return
+	}
+
+	affinity := statefulset.Spec.Template.Spec.Affinity
+	lables := internal.MapLables(statefulset.Spec.Template.GetObjectMeta().GetLabels())
+
+	if affinity != nil && affinity.PodAntiAffinity != nil && hasPodAntiAffinityForTopology(lables, affinity, spreadTopologyKeys) {
+		score.Grade = scorecard.GradeAllOK
+		return
+	}
+
+	score.Grade = scorecard.GradeWarning
+	score.AddComment("spec.template", "StatefulSet replicas are not spread over nodes or zones",
+		fmt.Sprintf("The StatefulSet has %d replicas, but no podAntiAffinity on a node or zone topology. "+
+			"If multiple replicas of a quorum based system are scheduled on the same node, a single node failure can break the quorum. "+
+			"Set a preferred or required podAntiAffinity with the topologyKey kubernetes.io/hostname or topology.kubernetes.io/zone.", replicas),
+	)
+	return
+}
+
 func hasPodAntiAffinity(selfLables internal.MapLables, affinity *corev1.Affinity) bool {
+	return hasPodAntiAffinityForTopology(selfLables, affinity, map[string]struct{}{"kubernetes.io/hostname": {}})
+}
+
+// hasPodAntiAffinityForTopology returns true if the affinity has a preferred or required podAntiAffinity that
+// matches selfLables, with any of the topologyKeys
+func hasPodAntiAffinityForTopology(selfLables internal.MapLables, affinity *corev1.Affinity, topologyKeys map[string]struct{}) bool {
 	for _, pref := range affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
-		if pref.PodAffinityTerm.TopologyKey == "kubernetes.io/hostname" {
+		if _, ok := topologyKeys[pref.PodAffinityTerm.TopologyKey]; ok {
 			if selector, err := metav1.LabelSelectorAsSelector(pref.PodAffinityTerm.LabelSelector); err == nil {
 				if selector.Matches(internal.MapLables(selfLables)) {
 					return true
@@ -129,7 +278,7 @@ func hasPodAntiAffinity(selfLables internal.MapLables, affinity *corev1.Affinity
 	}
 
 	for _, req := range affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
-		if req.TopologyKey == "kubernetes.io/hostname" {
+		if _, ok := topologyKeys[req.TopologyKey]; ok {
 			if selector, err := metav1.LabelSelectorAsSelector(req.LabelSelector); err == nil {
 				if selector.Matches(internal.MapLables(selfLables)) {
 					return true
@@ -143,6 +292,12 @@ func hasPodAntiAffinity(selfLables internal.MapLables, affinity *corev1.Affinity
 
 func statefulsetHasServiceName(allServices []ks.Service) func(statefulset appsv1.StatefulSet) (scorecard.TestScore, error) {
 	return func(statefulset appsv1.StatefulSet) (score scorecard.TestScore, err error) {
+		if len(allServices) == 0 {
+			score.Skipped = true
+			score.AddComment("", "Skipped because no Services are defined", "")
+			return
+		}
+
 		for _, service := range allServices {
 			if service.Service().Namespace != statefulset.Namespace ||
 				service.Service().Name != statefulset.Spec.ServiceName ||
@@ -159,8 +314,13 @@ func statefulsetHasServiceName(allServices []ks.Service) func(statefulset appsv1
 			}
 		}
 
+		summary := "StatefulSet does not have a serviceName set"
+		if statefulset.Spec.ServiceName != "" {
+			summary = fmt.Sprintf("The headless Service %s that targets the StatefulSet was not found", statefulset.Spec.ServiceName)
+		}
+
 		score.Grade = scorecard.GradeCritical
-		score.AddComment("", "StatefulSet does not have a valid serviceName", "StatefulSets currently require a Headless Service to be responsible for the network identity of the Pods. You are responsible for creating this Service. https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/#limitations")
+		score.AddComment("", summary, "StatefulSets currently require a Headless Service to be responsible for the network identity of the Pods. You are responsible for creating this Service. https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/#limitations")
 		return
 	}
 }
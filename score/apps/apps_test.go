@@ -8,6 +8,7 @@ import (
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/scorecard"
@@ -295,6 +296,14 @@ func (d hpav1) GetObjectMeta() metav1.ObjectMeta {
 	return d.ObjectMeta
 }
 
+func (d hpav1) MinReplicas() *int32 {
+	return d.Spec.MinReplicas
+}
+
+func (d hpav1) MaxReplicas() int32 {
+	return d.Spec.MaxReplicas
+}
+
 func (d hpav1) HpaTarget() autoscalingv1.CrossVersionObjectReference {
 	return d.Spec.ScaleTargetRef
 }
@@ -901,3 +910,125 @@ func (d service) Service() corev1.Service {
 func (d service) FileLocation() ks.FileLocation {
 	return ks.FileLocation{}
 }
+
+func TestStatefulsetPodAntiAffinity(t *testing.T) {
+	t.Parallel()
+
+	statefulSet := func(replicas *int32, topologyKey string) appsv1.StatefulSet {
+		s := appsv1.StatefulSet{
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: replicas,
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{"app": "foo"},
+					},
+				},
+			},
+		}
+		if topologyKey != "" {
+			s.Spec.Template.Spec.Affinity = &corev1.Affinity{
+				PodAntiAffinity: &corev1.PodAntiAffinity{
+					PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+						{
+							Weight: 100,
+							PodAffinityTerm: corev1.PodAffinityTerm{
+								LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+								TopologyKey:   topologyKey,
+							},
+						},
+					},
+				},
+			}
+		}
+		return s
+	}
+
+	score, err := statefulsetPodAntiAffinity(statefulSet(nil, ""))
+	assert.Nil(t, err)
+	assert.True(t, score.Skipped)
+
+	score, err = statefulsetPodAntiAffinity(statefulSet(i(2), ""))
+	assert.Nil(t, err)
+	assert.True(t, score.Skipped)
+
+	score, err = statefulsetPodAntiAffinity(statefulSet(i(3), ""))
+	assert.Nil(t, err)
+	assert.Equal(t, scorecard.GradeWarning, score.Grade)
+	assert.Len(t, score.Comments, 1)
+	assert.Equal(t, "spec.template", score.Comments[0].Path)
+	assert.Contains(t, score.Comments[0].Description, "The StatefulSet has 3 replicas")
+
+	score, err = statefulsetPodAntiAffinity(statefulSet(i(5), "example.com/rack"))
+	assert.Nil(t, err)
+	assert.Equal(t, scorecard.GradeWarning, score.Grade)
+
+	score, err = statefulsetPodAntiAffinity(statefulSet(i(3), "topology.kubernetes.io/zone"))
+	assert.Nil(t, err)
+	assert.Equal(t, scorecard.GradeAllOK, score.Grade)
+
+	score, err = statefulsetPodAntiAffinity(statefulSet(i(3), "kubernetes.io/hostname"))
+	assert.Nil(t, err)
+	assert.Equal(t, scorecard.GradeAllOK, score.Grade)
+}
+
+func TestDeploymentMaxSurgeFootprint(t *testing.T) {
+	t.Parallel()
+
+	i := func(i int32) *int32 { return &i }
+	deployment := func(replicas *int32, maxSurge *intstr.IntOrString) appsv1.Deployment {
+		d := appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: replicas}}
+		if maxSurge != nil {
+			d.Spec.Strategy = appsv1.DeploymentStrategy{
+				Type:          appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{MaxSurge: maxSurge},
+			}
+		}
+		return d
+	}
+	surge := func(s intstr.IntOrString) *intstr.IntOrString { return &s }
+
+	check := deploymentMaxSurgeFootprint(DefaultMaxSurgePercentage)
+
+	cases := []struct {
+		deployment    appsv1.Deployment
+		expectedGrade scorecard.Grade
+	}{
+		// Default maxSurge of 25%
+		{deployment(i(10), nil), scorecard.GradeAllOK},
+		// A single replica can always surge with one pod
+		{deployment(nil, surge(intstr.FromString("100%"))), scorecard.GradeAllOK},
+		{deployment(i(1), surge(intstr.FromInt(1))), scorecard.GradeAllOK},
+		{deployment(i(4), surge(intstr.FromString("50%"))), scorecard.GradeAllOK},
+		{deployment(i(4), surge(intstr.FromString("100%"))), scorecard.GradeWarning},
+		{deployment(i(4), surge(intstr.FromInt(2))), scorecard.GradeAllOK},
+		{deployment(i(4), surge(intstr.FromInt(3))), scorecard.GradeWarning},
+		{deployment(i(3), surge(intstr.FromString("60%"))), scorecard.GradeAllOK},
+	}
+
+	for idx, tc := range cases {
+		score, err := check(tc.deployment)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.expectedGrade, score.Grade, "case %d", idx)
+	}
+
+	score, err := check(deployment(i(4), surge(intstr.FromString("100%"))))
+	assert.Nil(t, err)
+	assert.Len(t, score.Comments, 1)
+	assert.Equal(t, "spec.strategy.rollingUpdate.maxSurge", score.Comments[0].Path)
+	assert.Equal(t, "The maxSurge of 100% allows more than 50% additional pods during rollouts", score.Comments[0].Summary)
+	assert.Contains(t, score.Comments[0].Description, "up to 8 pods (4 replicas + 4 surge)")
+
+	// The threshold is configurable
+	score, err = deploymentMaxSurgeFootprint(100)(deployment(i(4), surge(intstr.FromString("100%"))))
+	assert.Nil(t, err)
+	assert.Equal(t, scorecard.GradeAllOK, score.Grade)
+
+	recreate := deployment(i(4), nil)
+	recreate.Spec.Strategy.Type = appsv1.RecreateDeploymentStrategyType
+	score, err = check(recreate)
+	assert.Nil(t, err)
+	assert.True(t, score.Skipped)
+
+	_, err = check(deployment(i(4), surge(intstr.FromString("lots"))))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,67 @@
+package apps
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/zegl/kube-score/config"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// daemonSetMaxSurgeSince is the first version of Kubernetes where maxSurge of DaemonSets is enabled by default
+var daemonSetMaxSurgeSince = config.Semver{Major: 1, Minor: 22}
+
+// daemonSetUpdateStrategy returns a function that checks that the update strategy of DaemonSets can roll out new pods.
+// With maxUnavailable set to 0, an old pod can only be replaced if a new pod can be started next to it with maxSurge,
+// which is only possible from Kubernetes v1.22.
+func daemonSetUpdateStrategy(kubernetesVersion config.Semver) func(appsv1.DaemonSet) scorecard.TestScore {
+	return func(daemonset appsv1.DaemonSet) (score scorecard.TestScore) {
+		score.Grade = scorecard.GradeAllOK
+
+		strategy := daemonset.Spec.UpdateStrategy
+		if strategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+			score.Grade = scorecard.GradeWarning
+			score.AddComment("spec.updateStrategy", "The DaemonSet uses the OnDelete update strategy",
+				"Pods are only updated when they are deleted manually, so changes to the pod template are not rolled out. "+
+					"Use the RollingUpdate strategy, or ignore this check with the kube-score/ignore annotation if the pods are replaced on purpose.",
+			)
+			return
+		}
+
+		if strategy.RollingUpdate == nil || !isZero(strategy.RollingUpdate.MaxUnavailable) {
+			return
+		}
+
+		if kubernetesVersion.LessThan(daemonSetMaxSurgeSince) {
+			score.Grade = scorecard.GradeCritical
+			score.AddComment("spec.updateStrategy.rollingUpdate.maxUnavailable", "The DaemonSet has maxUnavailable set to 0",
+				fmt.Sprintf("A DaemonSet runs a single pod per node, and maxSurge is not supported before Kubernetes %s, so the old pod has to be stopped before the new one can start. "+
+					"With maxUnavailable set to 0, no pod can ever be replaced and the rollout stalls. Set maxUnavailable to at least 1.", daemonSetMaxSurgeSince),
+			)
+			return
+		}
+
+		if strategy.RollingUpdate.MaxSurge == nil || isZero(strategy.RollingUpdate.MaxSurge) {
+			score.Grade = scorecard.GradeCritical
+			score.AddComment("spec.updateStrategy.rollingUpdate.maxUnavailable", "The DaemonSet has maxUnavailable set to 0 without maxSurge",
+				"A DaemonSet runs a single pod per node. With both maxUnavailable and maxSurge set to 0, the old pod can't be stopped and no new pod can be started next to it, so the rollout stalls. "+
+					"Set maxSurge to at least 1 to start the new pod before the old one is stopped, or set maxUnavailable to at least 1.",
+			)
+		}
+
+		return
+	}
+}
+
+// isZero returns true if the value is set to 0 or 0%
+func isZero(v *intstr.IntOrString) bool {
+	if v == nil {
+		return false
+	}
+	if v.Type == intstr.Int {
+		return v.IntVal == 0
+	}
+	return v.StrVal == "0" || v.StrVal == "0%"
+}
@@ -0,0 +1,58 @@
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/zegl/kube-score/config"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestDaemonSetUpdateStrategy(t *testing.T) {
+	t.Parallel()
+
+	intOrStr := func(v intstr.IntOrString) *intstr.IntOrString {
+		return &v
+	}
+	rollingUpdate := func(maxUnavailable, maxSurge *intstr.IntOrString) appsv1.DaemonSetUpdateStrategy {
+		return appsv1.DaemonSetUpdateStrategy{
+			Type:          appsv1.RollingUpdateDaemonSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateDaemonSet{MaxUnavailable: maxUnavailable, MaxSurge: maxSurge},
+		}
+	}
+
+	v121 := config.Semver{Major: 1, Minor: 21}
+	v122 := config.Semver{Major: 1, Minor: 22}
+
+	cases := []struct {
+		name     string
+		version  config.Semver
+		strategy appsv1.DaemonSetUpdateStrategy
+		grade    scorecard.Grade
+		path     string
+	}{
+		{"default", v122, appsv1.DaemonSetUpdateStrategy{}, scorecard.GradeAllOK, ""},
+		{"max unavailable", v121, rollingUpdate(intOrStr(intstr.FromInt(1)), nil), scorecard.GradeAllOK, ""},
+		{"max unavailable percentage", v122, rollingUpdate(intOrStr(intstr.FromString("10%")), nil), scorecard.GradeAllOK, ""},
+		{"surge", v122, rollingUpdate(intOrStr(intstr.FromInt(0)), intOrStr(intstr.FromInt(1))), scorecard.GradeAllOK, ""},
+		{"no surge", v122, rollingUpdate(intOrStr(intstr.FromInt(0)), nil), scorecard.GradeCritical, "spec.updateStrategy.rollingUpdate.maxUnavailable"},
+		{"zero surge", v122, rollingUpdate(intOrStr(intstr.FromString("0%")), intOrStr(intstr.FromString("0%"))), scorecard.GradeCritical, "spec.updateStrategy.rollingUpdate.maxUnavailable"},
+		{"surge not supported", v121, rollingUpdate(intOrStr(intstr.FromInt(0)), intOrStr(intstr.FromInt(1))), scorecard.GradeCritical, "spec.updateStrategy.rollingUpdate.maxUnavailable"},
+		{"on delete", v122, appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType}, scorecard.GradeWarning, "spec.updateStrategy"},
+	}
+
+	for _, tc := range cases {
+		s := daemonSetUpdateStrategy(tc.version)(appsv1.DaemonSet{
+			Spec: appsv1.DaemonSetSpec{UpdateStrategy: tc.strategy},
+		})
+		assert.Equal(t, tc.grade, s.Grade, tc.name)
+		if tc.path == "" {
+			assert.Len(t, s.Comments, 0, tc.name)
+		} else if assert.Len(t, s.Comments, 1, tc.name) {
+			assert.Equal(t, tc.path, s.Comments[0].Path, tc.name)
+		}
+	}
+}
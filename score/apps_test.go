@@ -3,6 +3,10 @@ package score
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/scorecard"
 )
 
@@ -80,7 +84,28 @@ func TestStatefulsetHasServiceName(t *testing.T) {
 
 func TestStatefulsetHasServiceNameDifferentName(t *testing.T) {
 	t.Parallel()
-	testExpectedScore(t, "statefulset-service-name-different-name.yaml", "StatefulSet has ServiceName", scorecard.GradeCritical)
+	comments := testExpectedScore(t, "statefulset-service-name-different-name.yaml", "StatefulSet has ServiceName", scorecard.GradeCritical)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "The headless Service svc-test-1 that targets the StatefulSet was not found", comments[0].Summary)
+}
+
+func TestStatefulsetHasServiceNameNoServices(t *testing.T) {
+	t.Parallel()
+	s, err := testScore(config.Configuration{
+		AllFiles: []ks.NamedReader{testFile("statefulset-pvc-storageclass-not-set.yaml")},
+	})
+	assert.Nil(t, err)
+
+	tested := false
+	for _, o := range s {
+		for _, c := range o.Checks {
+			if c.Check.ID == "statefulset-has-servicename" {
+				assert.True(t, c.Skipped)
+				tested = true
+			}
+		}
+	}
+	assert.True(t, tested)
 }
 
 func TestStatefulsetHasServiceNameDifferentNamespace(t *testing.T) {
@@ -102,3 +127,88 @@ func TestStatefulsetSelectorLabels(t *testing.T) {
 	t.Parallel()
 	testExpectedScore(t, "statefulset-different-labels.yaml", "StatefulSet Pod Selector labels match template metadata labels", scorecard.GradeCritical)
 }
+
+func TestDaemonSetUpdateStrategyNoSurge(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "daemonset-updatestrategy-no-surge.yaml", "DaemonSet UpdateStrategy", scorecard.GradeCritical)
+}
+
+func TestDaemonSetUpdateStrategyDefault(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "daemonset-appsv1.yaml", "DaemonSet UpdateStrategy", scorecard.GradeAllOK)
+}
+
+func TestDeploymentMinReadySeconds(t *testing.T) {
+	t.Parallel()
+	s, err := testScore(config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("deployment-min-ready-seconds.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"deployment-min-ready-seconds": {}},
+	})
+	assert.Nil(t, err)
+
+	grades := make(map[string]scorecard.Grade)
+	skipped := make(map[string]bool)
+	for _, o := range s {
+		for _, c := range o.Checks {
+			if c.Check.ID == "deployment-min-ready-seconds" {
+				grades[o.ObjectMeta.Name] = c.Grade
+				skipped[o.ObjectMeta.Name] = c.Skipped
+			}
+		}
+	}
+
+	assert.Equal(t, scorecard.GradeWarning, grades["web"])
+	assert.Equal(t, scorecard.GradeAllOK, grades["web-ready"])
+	assert.False(t, skipped["web-ready"])
+	assert.True(t, skipped["worker"])
+}
+
+func TestDaemonSetResourceFootprintOnlyDaemonSets(t *testing.T) {
+	t.Parallel()
+	sc, err := testScore(config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("daemonset-resource-footprint.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"daemonset-resource-footprint": {}},
+	})
+	assert.Nil(t, err)
+
+	kinds := make(map[string]scorecard.TestScore)
+	for _, o := range sc {
+		for _, c := range o.Checks {
+			if c.Check.ID == "daemonset-resource-footprint" {
+				kinds[o.TypeMeta.Kind] = c
+			}
+		}
+	}
+
+	// The threshold is raised with the annotation on the DaemonSet, and the Deployment is not scored
+	assert.Len(t, kinds, 1)
+	assert.Equal(t, scorecard.GradeWarning, kinds["DaemonSet"].Grade)
+	if assert.Len(t, kinds["DaemonSet"].Comments, 1) {
+		assert.Equal(t, "The memory request 1Gi is higher than 512Mi", kinds["DaemonSet"].Comments[0].Summary)
+	}
+}
+
+func TestPodPriorityClassTierAnnotationOnObject(t *testing.T) {
+	t.Parallel()
+	sc, err := testScore(config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("pod-priority-class-tier.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"pod-priority-class": {}},
+	})
+	assert.Nil(t, err)
+
+	scores := make(map[string]scorecard.TestScore)
+	for _, o := range sc {
+		for _, c := range o.Checks {
+			if c.Check.ID == "pod-priority-class" {
+				scores[o.ObjectMeta.Name] = c
+			}
+		}
+	}
+
+	// The pod templates are identical, only the annotation on the Deployment differs
+	assert.Equal(t, scorecard.GradeAllOK, scores["not-critical"].Grade)
+	assert.Equal(t, scorecard.GradeWarning, scores["critical"].Grade)
+	if assert.Len(t, scores["critical"].Comments, 1) {
+		assert.Equal(t, "spec.template.spec.priorityClassName", scores["critical"].Comments[0].Path)
+	}
+}
@@ -0,0 +1,133 @@
+package score
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/logging"
+	"github.com/zegl/kube-score/score/checks"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// resultCache looks up and stores the results of the checks of each object in config.Cache.
+// The results of checks that are marked as cross-object are never cached, as they depend on other objects than
+// the one that is checked. All methods are no-ops if the cache is nil.
+type resultCache struct {
+	cache     config.ResultCache
+	allChecks *checks.Checks
+	logger    *logging.Logger
+
+	// configKey is unique for the configuration and the set of enabled checks
+	configKey string
+}
+
+func newResultCache(cnf config.Configuration, allChecks *checks.Checks) *resultCache {
+	if cnf.Cache == nil {
+		return nil
+	}
+
+	// Fields that don't affect the results of the checks are not part of the key
+	keyCnf := cnf
+	keyCnf.AllFiles = nil
+	keyCnf.Logger = nil
+	keyCnf.CheckTimings = nil
+	keyCnf.Cache = nil
+
+	var enabled []string
+	for _, check := range allChecks.Enabled() {
+		enabled = append(enabled, check.TargetType+"/"+check.ID)
+	}
+	sort.Strings(enabled)
+
+	configKey, err := hashJSON(keyCnf, enabled)
+	if err != nil {
+		cnf.Logger.Warn("Failed to create cache key, results are not cached", "error", err)
+		return nil
+	}
+
+	return &resultCache{
+		cache:     cnf.Cache,
+		allChecks: allChecks,
+		logger:    cnf.Logger,
+		configKey: configKey,
+	}
+}
+
+// object returns the cached results of an object, identified by the target type of the checks and the content
+// that is passed to the checks
+func (c *resultCache) object(targetType string, content ...interface{}) *cachedObject {
+	if c == nil {
+		return nil
+	}
+
+	key, err := hashJSON(c.configKey, targetType, content)
+	if err != nil {
+		c.logger.Warn("Failed to create cache key, results are not cached", "error", err)
+		return nil
+	}
+
+	o := &cachedObject{cache: c, key: key, results: make(map[string]scorecard.TestScore)}
+	if value, ok := c.cache.Get(key); ok {
+		// Entries that can't be decoded are replaced when the object has been scored
+		if err := json.Unmarshal(value, &o.results); err != nil {
+			o.results = make(map[string]scorecard.TestScore)
+		}
+	}
+	return o
+}
+
+// cachedObject is the cached results of a single object, by check ID
+type cachedObject struct {
+	cache   *resultCache
+	key     string
+	results map[string]scorecard.TestScore
+	changed bool
+}
+
+// runWithError returns the cached result of the check, or runs fn and caches the result if there is none. Results of
+// checks that fail are not cached.
+func (o *cachedObject) runWithError(check ks.Check, fn func() (scorecard.TestScore, error)) (scorecard.TestScore, error) {
+	if o == nil || o.cache.allChecks.IsCrossObject(check.ID) {
+		return fn()
+	}
+
+	if res, ok := o.results[check.ID]; ok {
+		return res, nil
+	}
+
+	res, err := fn()
+	if err != nil {
+		return res, err
+	}
+	o.results[check.ID] = res
+	o.changed = true
+	return res, nil
+}
+
+// save stores the results in the cache, if any check has been executed
+func (o *cachedObject) save() {
+	if o == nil || !o.changed {
+		return
+	}
+
+	value, err := json.Marshal(o.results)
+	if err == nil {
+		err = o.cache.cache.Put(o.key, value)
+	}
+	if err != nil {
+		o.cache.logger.Warn("Failed to cache results", "error", err)
+	}
+}
+
+func hashJSON(v ...interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
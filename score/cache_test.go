@@ -0,0 +1,188 @@
+package score
+
+import (
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zegl/kube-score/cache"
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/parser"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// memoryCache is a config.ResultCache that counts the number of lookups that found an entry
+type memoryCache struct {
+	entries map[string][]byte
+	hits    int
+}
+
+func (c *memoryCache) Get(key string) ([]byte, bool) {
+	value, ok := c.entries[key]
+	if ok {
+		c.hits++
+	}
+	return value, ok
+}
+
+func (c *memoryCache) Put(key string, value []byte) error {
+	c.entries[key] = value
+	return nil
+}
+
+func testScoreCached(t *testing.T, resultCache config.ResultCache, enabledOptionalTests map[string]struct{}, files ...ks.NamedReader) scorecard.Scorecard {
+	sc, err := testScore(config.Configuration{
+		AllFiles:             files,
+		KubernetesVersion:    config.Semver{1, 18},
+		EnabledOptionalTests: enabledOptionalTests,
+		Cache:                resultCache,
+	})
+	assert.NoError(t, err)
+
+	// The checks are executed in a random order
+	for _, o := range sc {
+		sort.Slice(o.Checks, func(i, j int) bool {
+			return o.Checks[i].Check.ID < o.Checks[j].Check.ID
+		})
+	}
+	return sc
+}
+
+func TestCachedResultsAreEqual(t *testing.T) {
+	t.Parallel()
+
+	files := []string{
+		"all-ok.yaml",
+		"deployment-poddisruptionbudget-v1-no-match.yaml",
+		"ingress-targets-service.yaml",
+		"cronjob-batchv1-deadline-not-set.yaml",
+		"secret-tls-opaque.yaml",
+		"networkpolicy-statefulset-matching.yaml",
+	}
+	optional := map[string]struct{}{"container-security-context": {}, "secret-tls-type": {}, "deployment-has-poddisruptionbudget": {}}
+
+	for _, file := range files {
+		file := file
+		t.Run(file, func(t *testing.T) {
+			resultCache := &memoryCache{entries: make(map[string][]byte)}
+
+			uncached := testScoreCached(t, nil, optional, testFile(file))
+			cold := testScoreCached(t, resultCache, optional, testFile(file))
+			assert.Equal(t, 0, resultCache.hits)
+			warm := testScoreCached(t, resultCache, optional, testFile(file))
+			assert.NotZero(t, resultCache.hits)
+
+			assert.Equal(t, uncached, cold)
+			assert.Equal(t, uncached, warm)
+		})
+	}
+}
+
+func TestCacheConfigChangeIsMiss(t *testing.T) {
+	t.Parallel()
+	resultCache := &memoryCache{entries: make(map[string][]byte)}
+
+	testScoreCached(t, resultCache, nil, testFile("all-ok.yaml"))
+	testScoreCached(t, resultCache, map[string]struct{}{"container-security-context": {}}, testFile("all-ok.yaml"))
+	assert.Equal(t, 0, resultCache.hits)
+}
+
+func TestCacheCrossObjectChecksAreNotCached(t *testing.T) {
+	t.Parallel()
+	resultCache := &memoryCache{entries: make(map[string][]byte)}
+
+	podProbes := func(sc scorecard.Scorecard) scorecard.TestScore {
+		for _, o := range sc {
+			for _, c := range o.Checks {
+				if o.TypeMeta.Kind == "Pod" && c.Check.ID == "pod-probes" {
+					return c
+				}
+			}
+		}
+		t.Fatal("pod-probes was not tested")
+		return scorecard.TestScore{}
+	}
+
+	// The Pod is targeted by the Service
+	withService := testScoreCached(t, resultCache, nil, testFile("pod-probes-targeted-by-service.yaml"))
+	assert.Equal(t, scorecard.GradeCritical, podProbes(withService).Grade)
+
+	// The same Pod, without the Service
+	b, err := ioutil.ReadFile("testdata/pod-probes-targeted-by-service.yaml")
+	assert.NoError(t, err)
+	pod := strings.Split(string(b), "---")[0]
+	withoutService := testScoreCached(t, resultCache, nil, unnamedReader{strings.NewReader(pod)})
+	assert.NotZero(t, resultCache.hits)
+	assert.Equal(t, scorecard.GradeAllOK, podProbes(withoutService).Grade)
+}
+
+// BenchmarkScoreCache compares scoring without a cache, with the results of the previous run in the cache
+// (re-runs without changes), and with an empty cache.
+func BenchmarkScoreCache(b *testing.B) {
+	var files []ks.NamedReader
+	for _, name := range []string{
+		"all-ok.yaml",
+		"deployment-poddisruptionbudget-v1-no-match.yaml",
+		"pod-probes-targeted-by-service.yaml",
+		"ingress-targets-service.yaml",
+		"networkpolicy-statefulset-matching.yaml",
+		"cronjob-batchv1-deadline-not-set.yaml",
+	} {
+		files = append(files, testFile(name))
+	}
+	parsed, err := parser.ParseFiles(config.Configuration{AllFiles: files})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	newDir := func(b *testing.B) (*cache.Dir, func()) {
+		path, err := ioutil.TempDir("", "kube-score-cache")
+		if err != nil {
+			b.Fatal(err)
+		}
+		dir, err := cache.NewDir(path, "bench")
+		if err != nil {
+			b.Fatal(err)
+		}
+		return dir, func() { os.RemoveAll(path) }
+	}
+
+	score := func(b *testing.B, resultCache config.ResultCache) {
+		if _, err := Score(parsed, config.Configuration{KubernetesVersion: config.Semver{1, 18}, Cache: resultCache}); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			score(b, nil)
+		}
+	})
+
+	b.Run("cold", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			dir, cleanup := newDir(b)
+			b.StartTimer()
+			score(b, dir)
+			b.StopTimer()
+			cleanup()
+			b.StartTimer()
+		}
+	})
+
+	b.Run("warm", func(b *testing.B) {
+		dir, cleanup := newDir(b)
+		defer cleanup()
+		score(b, dir)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			score(b, dir)
+		}
+	})
+}
@@ -23,10 +23,18 @@ func New(cnf config.Configuration) *Checks {
 		services:                 make(map[string]ServiceCheck),
 		statefulsets:             make(map[string]StatefulSetCheck),
 		deployments:              make(map[string]DeploymentCheck),
+		daemonsets:               make(map[string]DaemonSetCheck),
 		networkpolicies:          make(map[string]NetworkPolicyCheck),
 		ingresses:                make(map[string]IngressCheck),
 		cronjobs:                 make(map[string]CronJobCheck),
 		horizontalPodAutoscalers: make(map[string]HorizontalPodAutoscalerCheck),
+		persistentVolumeClaims:   make(map[string]PersistentVolumeClaimCheck),
+		configMaps:               make(map[string]ConfigMapCheck),
+		secrets:                  make(map[string]SecretCheck),
+		namespaces:               make(map[string]NamespaceCheck),
+		roles:                    make(map[string]RoleCheck),
+		unknownObjects:           make(map[string]UnknownObjectCheck),
+		crossObject:              make(map[string]struct{}),
 	}
 }
 
@@ -53,9 +61,13 @@ type MetaCheck struct {
 }
 
 type PodCheckFn = func(corev1.PodTemplateSpec, metav1.TypeMeta) scorecard.TestScore
+
+// PodObjectCheckFn is a PodCheckFn that also gets the metadata of the object that is checked, such as the Deployment
+// that the pod template is a part of, for checks that are configured with annotations on the object
+type PodObjectCheckFn = func(metav1.ObjectMeta, corev1.PodTemplateSpec, metav1.TypeMeta) scorecard.TestScore
 type PodCheck struct {
 	ks.Check
-	Fn PodCheckFn
+	Fn PodObjectCheckFn
 }
 
 type ServiceCheckFn = func(corev1.Service) scorecard.TestScore
@@ -76,6 +88,12 @@ type DeploymentCheck struct {
 	Fn DeploymentCheckFn
 }
 
+type DaemonSetCheckFn = func(appsv1.DaemonSet) scorecard.TestScore
+type DaemonSetCheck struct {
+	ks.Check
+	Fn DaemonSetCheckFn
+}
+
 type NetworkPolicyCheckFn = func(networkingv1.NetworkPolicy) scorecard.TestScore
 type NetworkPolicyCheck struct {
 	ks.Check
@@ -100,6 +118,42 @@ type HorizontalPodAutoscalerCheck struct {
 	Fn HorizontalPodAutoscalerCheckFn
 }
 
+type PersistentVolumeClaimCheckFn = func(corev1.PersistentVolumeClaim) scorecard.TestScore
+type PersistentVolumeClaimCheck struct {
+	ks.Check
+	Fn PersistentVolumeClaimCheckFn
+}
+
+type ConfigMapCheckFn = func(corev1.ConfigMap) scorecard.TestScore
+type ConfigMapCheck struct {
+	ks.Check
+	Fn ConfigMapCheckFn
+}
+
+type SecretCheckFn = func(corev1.Secret) scorecard.TestScore
+type SecretCheck struct {
+	ks.Check
+	Fn SecretCheckFn
+}
+
+type NamespaceCheckFn = func(corev1.Namespace) scorecard.TestScore
+type NamespaceCheck struct {
+	ks.Check
+	Fn NamespaceCheckFn
+}
+
+type RoleCheckFn = func(ks.Role) scorecard.TestScore
+type RoleCheck struct {
+	ks.Check
+	Fn RoleCheckFn
+}
+
+type UnknownObjectCheckFn = func(ks.UnknownObject) scorecard.TestScore
+type UnknownObjectCheck struct {
+	ks.Check
+	Fn UnknownObjectCheckFn
+}
+
 type Checks struct {
 	all                      []ks.Check
 	metas                    map[string]MetaCheck
@@ -107,20 +161,45 @@ type Checks struct {
 	services                 map[string]ServiceCheck
 	statefulsets             map[string]StatefulSetCheck
 	deployments              map[string]DeploymentCheck
+	daemonsets               map[string]DaemonSetCheck
 	networkpolicies          map[string]NetworkPolicyCheck
 	ingresses                map[string]IngressCheck
 	cronjobs                 map[string]CronJobCheck
 	horizontalPodAutoscalers map[string]HorizontalPodAutoscalerCheck
+	persistentVolumeClaims   map[string]PersistentVolumeClaimCheck
+	configMaps               map[string]ConfigMapCheck
+	secrets                  map[string]SecretCheck
+	namespaces               map[string]NamespaceCheck
+	roles                    map[string]RoleCheck
+	unknownObjects           map[string]UnknownObjectCheck
+	crossObject              map[string]struct{}
 
 	cnf config.Configuration
 }
 
+// CrossObject marks the check as depending on other objects than the one that is checked, such as a Pod check
+// that looks for a Service that targets the Pod. The results of these checks are never cached.
+func (c *Checks) CrossObject(name string) {
+	c.crossObject[machineFriendlyName(name)] = struct{}{}
+}
+
+// IsCrossObject returns true if the check with the ID has been marked with CrossObject
+func (c *Checks) IsCrossObject(id string) bool {
+	_, ok := c.crossObject[id]
+	return ok
+}
+
 func (c Checks) isIgnored(id string) bool {
 	_, ok := c.cnf.IgnoredTests[id]
 	return ok
 }
 
 func (c Checks) isEnabled(check ks.Check) bool {
+	if len(c.cnf.OnlyChecks) > 0 {
+		_, ok := c.cnf.OnlyChecks[check.ID]
+		return ok
+	}
+
 	if c.isIgnored(check.ID) {
 		return false
 	}
@@ -133,6 +212,29 @@ func (c Checks) isEnabled(check ks.Check) bool {
 	return ok
 }
 
+// Enabled returns all checks that are enabled, with one entry per check ID like All
+func (c Checks) Enabled() []ks.Check {
+	var res []ks.Check
+	for _, check := range c.All() {
+		if c.isEnabled(check) {
+			res = append(res, check)
+		}
+	}
+	return res
+}
+
+// Disabled returns all checks for the target type that are not enabled, either because they are ignored,
+// because they are optional and not enabled, or because they are not selected with OnlyChecks
+func (c Checks) Disabled(targetType string) []ks.Check {
+	var res []ks.Check
+	for _, check := range c.all {
+		if check.TargetType == targetType && !c.isEnabled(check) {
+			res = append(res, check)
+		}
+	}
+	return res
+}
+
 func (c *Checks) RegisterMetaCheck(name, comment string, fn MetaCheckFn) {
 	ch := NewCheck(name, "all", comment, false)
 	c.registerMetaCheck(MetaCheck{ch, fn})
@@ -158,14 +260,30 @@ func (c *Checks) Metas() map[string]MetaCheck {
 
 func (c *Checks) RegisterPodCheck(name, comment string, fn PodCheckFn) {
 	ch := NewCheck(name, "Pod", comment, false)
-	c.registerPodCheck(PodCheck{ch, fn})
+	c.registerPodCheck(PodCheck{ch, withoutObjectMeta(fn)})
 }
 
 func (c *Checks) RegisterOptionalPodCheck(name, comment string, fn PodCheckFn) {
 	ch := NewCheck(name, "Pod", comment, true)
+	c.registerPodCheck(PodCheck{ch, withoutObjectMeta(fn)})
+}
+
+func (c *Checks) RegisterPodObjectCheck(name, comment string, fn PodObjectCheckFn) {
+	ch := NewCheck(name, "Pod", comment, false)
 	c.registerPodCheck(PodCheck{ch, fn})
 }
 
+func (c *Checks) RegisterOptionalPodObjectCheck(name, comment string, fn PodObjectCheckFn) {
+	ch := NewCheck(name, "Pod", comment, true)
+	c.registerPodCheck(PodCheck{ch, fn})
+}
+
+func withoutObjectMeta(fn PodCheckFn) PodObjectCheckFn {
+	return func(_ metav1.ObjectMeta, podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) scorecard.TestScore {
+		return fn(podTemplate, typeMeta)
+	}
+}
+
 func (c *Checks) registerPodCheck(ch PodCheck) {
 	c.all = append(c.all, ch.Check)
 
@@ -271,6 +389,29 @@ func (c *Checks) Deployments() map[string]DeploymentCheck {
 	return c.deployments
 }
 
+func (c *Checks) RegisterDaemonSetCheck(name, comment string, fn DaemonSetCheckFn) {
+	ch := NewCheck(name, "DaemonSet", comment, false)
+	c.registerDaemonSetCheck(DaemonSetCheck{ch, fn})
+}
+
+func (c *Checks) RegisterOptionalDaemonSetCheck(name, comment string, fn DaemonSetCheckFn) {
+	ch := NewCheck(name, "DaemonSet", comment, true)
+	c.registerDaemonSetCheck(DaemonSetCheck{ch, fn})
+}
+
+func (c *Checks) registerDaemonSetCheck(ch DaemonSetCheck) {
+	c.all = append(c.all, ch.Check)
+
+	if !c.isEnabled(ch.Check) {
+		return
+	}
+	c.daemonsets[machineFriendlyName(ch.Name)] = ch
+}
+
+func (c *Checks) DaemonSets() map[string]DaemonSetCheck {
+	return c.daemonsets
+}
+
 func (c *Checks) RegisterIngressCheck(name, comment string, fn IngressCheckFn) {
 	ch := NewCheck(name, "Ingress", comment, false)
 	c.registerIngressCheck(IngressCheck{ch, fn})
@@ -340,6 +481,162 @@ func (c *Checks) Services() map[string]ServiceCheck {
 	return c.services
 }
 
+func (c *Checks) RegisterPersistentVolumeClaimCheck(name, comment string, fn PersistentVolumeClaimCheckFn) {
+	ch := NewCheck(name, "PersistentVolumeClaim", comment, false)
+	c.registerPersistentVolumeClaimCheck(PersistentVolumeClaimCheck{ch, fn})
+}
+
+func (c *Checks) RegisterOptionalPersistentVolumeClaimCheck(name, comment string, fn PersistentVolumeClaimCheckFn) {
+	ch := NewCheck(name, "PersistentVolumeClaim", comment, true)
+	c.registerPersistentVolumeClaimCheck(PersistentVolumeClaimCheck{ch, fn})
+}
+
+func (c *Checks) registerPersistentVolumeClaimCheck(ch PersistentVolumeClaimCheck) {
+	c.all = append(c.all, ch.Check)
+
+	if !c.isEnabled(ch.Check) {
+		return
+	}
+	c.persistentVolumeClaims[machineFriendlyName(ch.Name)] = ch
+}
+
+func (c *Checks) PersistentVolumeClaims() map[string]PersistentVolumeClaimCheck {
+	return c.persistentVolumeClaims
+}
+
+func (c *Checks) RegisterConfigMapCheck(name, comment string, fn ConfigMapCheckFn) {
+	ch := NewCheck(name, "ConfigMap", comment, false)
+	c.registerConfigMapCheck(ConfigMapCheck{ch, fn})
+}
+
+func (c *Checks) RegisterOptionalConfigMapCheck(name, comment string, fn ConfigMapCheckFn) {
+	ch := NewCheck(name, "ConfigMap", comment, true)
+	c.registerConfigMapCheck(ConfigMapCheck{ch, fn})
+}
+
+func (c *Checks) registerConfigMapCheck(ch ConfigMapCheck) {
+	c.all = append(c.all, ch.Check)
+
+	if !c.isEnabled(ch.Check) {
+		return
+	}
+	c.configMaps[machineFriendlyName(ch.Name)] = ch
+}
+
+func (c *Checks) ConfigMaps() map[string]ConfigMapCheck {
+	return c.configMaps
+}
+
+func (c *Checks) RegisterSecretCheck(name, comment string, fn SecretCheckFn) {
+	ch := NewCheck(name, "Secret", comment, false)
+	c.registerSecretCheck(SecretCheck{ch, fn})
+}
+
+func (c *Checks) RegisterOptionalSecretCheck(name, comment string, fn SecretCheckFn) {
+	ch := NewCheck(name, "Secret", comment, true)
+	c.registerSecretCheck(SecretCheck{ch, fn})
+}
+
+func (c *Checks) registerSecretCheck(ch SecretCheck) {
+	c.all = append(c.all, ch.Check)
+
+	if !c.isEnabled(ch.Check) {
+		return
+	}
+	c.secrets[machineFriendlyName(ch.Name)] = ch
+}
+
+func (c *Checks) Secrets() map[string]SecretCheck {
+	return c.secrets
+}
+
+func (c *Checks) RegisterNamespaceCheck(name, comment string, fn NamespaceCheckFn) {
+	ch := NewCheck(name, "Namespace", comment, false)
+	c.registerNamespaceCheck(NamespaceCheck{ch, fn})
+}
+
+func (c *Checks) RegisterOptionalNamespaceCheck(name, comment string, fn NamespaceCheckFn) {
+	ch := NewCheck(name, "Namespace", comment, true)
+	c.registerNamespaceCheck(NamespaceCheck{ch, fn})
+}
+
+func (c *Checks) registerNamespaceCheck(ch NamespaceCheck) {
+	c.all = append(c.all, ch.Check)
+
+	if !c.isEnabled(ch.Check) {
+		return
+	}
+	c.namespaces[machineFriendlyName(ch.Name)] = ch
+}
+
+func (c *Checks) Namespaces() map[string]NamespaceCheck {
+	return c.namespaces
+}
+
+func (c *Checks) RegisterRoleCheck(name, comment string, fn RoleCheckFn) {
+	ch := NewCheck(name, "Role", comment, false)
+	c.registerRoleCheck(RoleCheck{ch, fn})
+}
+
+func (c *Checks) RegisterOptionalRoleCheck(name, comment string, fn RoleCheckFn) {
+	ch := NewCheck(name, "Role", comment, true)
+	c.registerRoleCheck(RoleCheck{ch, fn})
+}
+
+func (c *Checks) registerRoleCheck(ch RoleCheck) {
+	c.all = append(c.all, ch.Check)
+
+	if !c.isEnabled(ch.Check) {
+		return
+	}
+	c.roles[machineFriendlyName(ch.Name)] = ch
+}
+
+func (c *Checks) Roles() map[string]RoleCheck {
+	return c.roles
+}
+
+func (c *Checks) RegisterUnknownObjectCheck(name, comment string, fn UnknownObjectCheckFn) {
+	ch := NewCheck(name, "UnknownObject", comment, false)
+	c.registerUnknownObjectCheck(UnknownObjectCheck{ch, fn})
+}
+
+func (c *Checks) registerUnknownObjectCheck(ch UnknownObjectCheck) {
+	c.all = append(c.all, ch.Check)
+
+	if !c.isEnabled(ch.Check) {
+		return
+	}
+	c.unknownObjects[machineFriendlyName(ch.Name)] = ch
+}
+
+func (c *Checks) UnknownObjects() map[string]UnknownObjectCheck {
+	return c.unknownObjects
+}
+
+// All returns all registered checks, in the order that they are registered. A check that is registered for multiple
+// target types, such as pvc-storageclass, is only returned once, see TargetTypes.
 func (c *Checks) All() []ks.Check {
-	return c.all
+	var res []ks.Check
+	seen := make(map[string]struct{})
+	for _, check := range c.all {
+		if _, ok := seen[check.ID]; ok {
+			continue
+		}
+		seen[check.ID] = struct{}{}
+		res = append(res, check)
+	}
+	return res
+}
+
+// TargetTypes returns the target types that the check with the ID is registered for, in the order that they are
+// registered
+func (c *Checks) TargetTypes(id string) []string {
+	var res []string
+	for _, check := range c.all {
+		if check.ID == id {
+			res = append(res, check.TargetType)
+		}
+	}
+	return res
 }
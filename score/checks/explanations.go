@@ -0,0 +1,387 @@
+package checks
+
+import (
+	ks "github.com/zegl/kube-score/domain"
+)
+
+// Explanation returns the long form documentation of the check with the ID
+func Explanation(id string) (ks.CheckExplanation, bool) {
+	e, ok := explanations[id]
+	return e, ok
+}
+
+// explanations are the long form documentation of all checks, by check ID. They are printed with --explain.
+var explanations = map[string]ks.CheckExplanation{
+	"ingress-targets-service": {
+		Rationale:   "An Ingress that routes to a Service that doesn't exist, or to a port that the Service doesn't expose, returns errors for all requests to that path.",
+		Remediation: "Make sure that the backend of every rule references a Service in the same namespace, and a port that is defined on that Service.",
+	},
+	"ingress-host-path-collision": {
+		Rationale:   "When multiple Ingresses define the same host and path, the ingress controller picks one of them, and which one can change between controllers and versions.",
+		Remediation: "Merge the rules into a single Ingress, or give each Ingress a unique host or path.",
+	},
+	"ingress-path-type": {
+		Rationale:   "The pathType is required on networking.k8s.io/v1 Ingresses, and an Ingress without it is rejected by the API server. Paths with the ImplementationSpecific pathType are matched differently by each Ingress controller.",
+		Remediation: "Set pathType to Prefix or Exact on all paths of the Ingress.",
+	},
+	"ingress-class-name": {
+		Rationale:   "The IngressClass decides which Ingress controller serves the Ingress. Without one, the Ingress depends on the default IngressClass of the cluster, and can be served by the wrong controller, or not at all, when multiple controllers are installed.",
+		Remediation: "Set spec.ingressClassName to the name of the IngressClass that should serve the Ingress.",
+	},
+	"cronjob-has-deadline": {
+		Rationale:   "Without startingDeadlineSeconds, a CronJob that missed too many scheduled runs, for example while the controller was down, may never be started again.",
+		Remediation: "Set spec.startingDeadlineSeconds to the longest delay that a run can start with and still be useful.",
+	},
+	"cronjob-schedule-valid": {
+		Rationale:   "A CronJob with an invalid schedule is never started. A CronJob that runs every minute with the default concurrencyPolicy starts new Jobs while the previous ones are still running.",
+		Remediation: "Use a schedule with five fields or a predefined schedule such as @hourly, and set spec.concurrencyPolicy to Forbid or Replace for CronJobs that run every minute.",
+	},
+	"container-resources": {
+		Rationale:   "The scheduler places pods based on their requests, and the limits protect the node from a container that uses more than expected. Without them, pods can be placed on nodes that can't run them, and a single container can starve the others on the node.",
+		Remediation: "Set resources.requests and resources.limits for CPU and memory on all containers, based on the observed usage of the application.",
+	},
+	"container-resource-requests-equal-limits": {
+		Rationale:   "Pods where the requests are equal to the limits get the Guaranteed QoS class, are the last to be evicted, and always have the resources that they have requested available.",
+		Remediation: "Set resources.requests to the same values as resources.limits for CPU and memory.",
+	},
+	"container-cpu-requests-equal-limits": {
+		Rationale:   "A CPU limit that is higher than the request lets the container burst into CPU time that is not reserved for it, which makes the performance depend on the other pods on the node.",
+		Remediation: "Set resources.requests.cpu to the same value as resources.limits.cpu.",
+	},
+	"container-memory-requests-equal-limits": {
+		Rationale:   "A container that uses more memory than it has requested is the first to be killed when the node runs out of memory.",
+		Remediation: "Set resources.requests.memory to the same value as resources.limits.memory.",
+	},
+	"container-resource-request-limit-pairing": {
+		Rationale:   "A limit without a request implicitly sets the request to the limit, which can reserve much more than is needed. A request without a limit lets the container use all of the resources of the node.",
+		Remediation: "Set both the request and the limit for CPU and memory, or neither of them.",
+	},
+	"container-image-tag": {
+		Rationale:   "The latest tag, or no tag, points to different images over time. Pods of the same workload can then run different versions, and a rollback doesn't restore the previous version.",
+		Remediation: "Use an explicit version tag, or a digest, for all images.",
+	},
+	"container-image-mutable-tag": {
+		Rationale:   "Tags such as stable, main or a bare major version are moved to new images over time, just like latest. The image that is running can change when a pod is restarted, and the same manifest doesn't always deploy the same image.",
+		Remediation: "Pin the image to a full version, such as 1.4.2, or to a digest.",
+	},
+	"container-implicit-dockerhub": {
+		Rationale:   "Images without a registry host are pulled from Docker Hub, which limits the number of pulls. When the limit is reached, for example during a rollout or when a node is replaced, new pods can't start.",
+		Remediation: "Pull the image from a mirror or from another registry, and set the registry host explicitly in the image, such as registry.example.com/nginx:1.25.",
+	},
+	"container-image-pull-policy": {
+		Rationale:   "With any other pull policy than Always, a node can start a cached image without validating the imagePullSecrets, so pods can run private images that they don't have access to.",
+		Remediation: "Set imagePullPolicy to Always on all containers.",
+	},
+	"image-pull-policy-consistency": {
+		Rationale:   "Images that are cached on a node are only pulled again with the imagePullPolicy Always. When workloads disagree on the policy for the same image, which version runs depends on the node and on which workload pulled the image first.",
+		Remediation: "Use the same imagePullPolicy for the image in all workloads, preferably with a pinned tag or digest.",
+	},
+	"container-resource-quantity-valid": {
+		Rationale:   "A quantity such as 512MB looks correct in review, but is not a valid Kubernetes quantity. The API server rejects the whole object, and the change is never applied.",
+		Remediation: "Use a decimal suffix such as M or G, or a binary suffix such as Mi or Gi, for example 512Mi.",
+	},
+	"container-extended-resource-request-equals-limit": {
+		Rationale:   "Extended resources, such as GPUs, can't be overcommitted, and Kubernetes rejects pods where the request is different from the limit.",
+		Remediation: "Set the request of the extended resource to the same value as the limit, or only set the limit.",
+	},
+	"container-fieldref-valid": {
+		Rationale:   "The downward API only supports a fixed set of fields. A pod with an environment variable that references any other field, for example because of a typo such as metadata.namspace, is rejected by the API server, and the workload never starts.",
+		Remediation: "Correct the fieldPath or resource of the environment variable, see https://kubernetes.io/docs/concepts/workloads/pods/downward-api/ for the supported fields.",
+	},
+	"container-env-plaintext-secret": {
+		Rationale:   "Secrets that are set as plaintext environment variables are stored in the manifest, and are visible to everyone that can read the workload.",
+		Remediation: "Store the value in a Secret, and read it with valueFrom.secretKeyRef.",
+	},
+	"pod-duplicate-container-names": {
+		Rationale:   "All containers in a pod must have unique names, and Kubernetes rejects pods where two containers share the same name.",
+		Remediation: "Give every container, init container and ephemeral container in the pod a unique name.",
+	},
+	"pod-restartpolicy-for-controller": {
+		Rationale:   "Controllers that keep pods running, such as Deployments, only support the restartPolicy Always, and Jobs only support OnFailure and Never. Manifests with any other restartPolicy are rejected by the API server, often after a pod template has been copied between kinds.",
+		Remediation: "Remove the restartPolicy from Deployments, StatefulSets and DaemonSets, and set it to OnFailure or Never on Jobs and CronJobs.",
+	},
+	"pod-affinity-topologykey": {
+		Rationale:   "The topologyKey of a pod affinity term is the label that groups the nodes into domains. Nodes without the label are not part of any domain, so a misspelled key, such as kubernets.io/hostname, makes pods with a required term unschedulable.",
+		Remediation: "Use a well-known key, such as kubernetes.io/hostname or topology.kubernetes.io/zone. Allow custom node labels with --topology-key.",
+	},
+	"pod-hostport-conflict": {
+		Rationale:   "A hostPort is bound on the node that the pod runs on. Only one pod that binds the port can run on each node, and two containers in the same pod that bind the same port can never start.",
+		Remediation: "Expose the containers with a Service instead of a hostPort. If the pod has to be reachable on the address of the node, make sure that each hostPort is only used by one container.",
+	},
+	"container-volumemount-exists": {
+		Rationale:   "A volumeMount that references a volume that is not defined in the pod is rejected by Kubernetes.",
+		Remediation: "Add the volume to spec.volumes, or to the volumeClaimTemplates of the StatefulSet, or remove the volumeMount.",
+	},
+	"pod-emptydir-sizelimit": {
+		Rationale:   "An emptyDir volume without a sizeLimit can fill the disk of the node, which affects all pods on that node.",
+		Remediation: "Set sizeLimit on all emptyDir volumes.",
+	},
+	"container-resource-unit-style": {
+		Rationale:   "CPU quantities with more precision than 1m are rounded, and memory quantities with mixed units are easy to misread, so the resources that are used are not what they look like in the manifest.",
+		Remediation: "Use millicores or whole cores for CPU, and the same kind of units for all memory quantities in the pod.",
+	},
+	"pod-guaranteed-qos": {
+		Rationale:   "A pod only gets the Guaranteed QoS class if all containers have requests equal to limits for both CPU and memory. If any container doesn't, the pod is silently downgraded.",
+		Remediation: "Set resources.requests equal to resources.limits for CPU and memory in all containers of the pod.",
+	},
+	"container-memory-limit-required": {
+		Rationale:   "Unlike CPU, memory can't be throttled. A container without a memory limit can use all of the memory on the node, and when the node runs out, the kernel kills processes in other pods, or the kubelet itself becomes unstable.",
+		Remediation: "Set resources.limits.memory on all containers and init containers, based on the observed peak usage of the application.",
+	},
+	"init-container-resources": {
+		Rationale:   "The scheduler reserves the largest request of any init container. An init container without requests is not accounted for, and can run without the resources that it needs.",
+		Remediation: "Set CPU and memory requests on all init containers.",
+	},
+	"container-shell-wrapped-entrypoint": {
+		Rationale:   "When the process is started as a child of sh -c, the shell runs as PID 1 and doesn't forward SIGTERM, so the process is killed without a graceful shutdown.",
+		Remediation: "Run the process directly as the command, or use exec in the shell script.",
+	},
+	"container-prestop-command-sanity": {
+		Rationale:   "A preStop hook whose command is empty or does not exist in the image fails, and the container is stopped right away instead of shutting down gracefully. The failure is only visible as an event on the pod.",
+		Remediation: "Set a command that exists in the image. Distroless images have no shell or sleep binary, so run the application itself, or use a debug variant of the image.",
+	},
+	"container-termination-message-policy": {
+		Rationale:   "The termination message is shown in the status of the pod when a container exits. Most applications don't write one to /dev/termination-log, so with the default policy File, the reason for a crash can only be found in the logs.",
+		Remediation: "Set terminationMessagePolicy to FallbackToLogsOnError on all containers, to use the last lines of the logs as the termination message when the container fails.",
+	},
+	"container-tty-stdin": {
+		Rationale:   "stdin and tty are used by interactive containers, and are often left over from manifests that were generated with kubectl run. Nothing is attached to them in a long-running workload, and they hide that the manifest was copied from an experiment.",
+		Remediation: "Remove stdin and tty from the containers, or set them to false.",
+	},
+	"container-port-naming": {
+		Rationale:   "Services and probes can only reference a port by name if it has one. When a container has multiple ports, names also make it clear what each port is used for.",
+		Remediation: "Set a name on all ports of the container.",
+	},
+	"container-port-unexposed": {
+		Rationale:   "A container port that no Service targets is often left over from an old configuration, or is missing from a Service that was meant to expose it.",
+		Remediation: "Add the port to a Service that selects the pod, or remove it from the container if it's no longer used.",
+	},
+	"pod-native-sidecar": {
+		Rationale:   "Sidecars that are declared as regular containers are started and stopped together with the main container, and can stop while it is still shutting down, or not be ready when it starts.",
+		Remediation: "Move the sidecar to initContainers, and set restartPolicy: Always on it.",
+	},
+	"daemonset-resource-footprint": {
+		Rationale:   "A DaemonSet runs on every node, so its requests are reserved many times over, and reduce the capacity of the whole cluster.",
+		Remediation: "Lower the requests of the DaemonSet, or raise the thresholds with the kube-score/daemonset-max-cpu-request and kube-score/daemonset-max-memory-request annotations.",
+	},
+	"statefulset-has-poddisruptionbudget": {
+		Rationale:   "Without a PodDisruptionBudget, voluntary disruptions such as node drains can evict all pods of the StatefulSet at the same time.",
+		Remediation: "Create a PodDisruptionBudget that selects the pods of the StatefulSet.",
+	},
+	"daemonset-updatestrategy": {
+		Rationale:   "A DaemonSet runs one pod per node, so a rollout has to either stop the old pod before the new one starts (maxUnavailable), or start the new pod next to the old one (maxSurge). If neither is allowed, the rollout never makes progress. With OnDelete, changes are only rolled out when the pods are deleted manually.",
+		Remediation: "Use the RollingUpdate strategy, with maxUnavailable of at least 1, or with maxSurge of at least 1 on Kubernetes v1.22 and later.",
+	},
+	"deployment-has-poddisruptionbudget": {
+		Rationale:   "Without a PodDisruptionBudget, voluntary disruptions such as node drains can evict all pods of the Deployment at the same time.",
+		Remediation: "Create a PodDisruptionBudget that selects the pods of the Deployment.",
+	},
+	"pod-networkpolicy": {
+		Rationale:   "Pods that are not selected by any NetworkPolicy accept traffic from, and can send traffic to, any other pod in the cluster.",
+		Remediation: "Create a NetworkPolicy that selects the pod, and allows only the traffic that the pod needs.",
+	},
+	"networkpolicy-targets-pod": {
+		Rationale:   "A NetworkPolicy that doesn't select any pods has no effect, which usually means that the podSelector is wrong.",
+		Remediation: "Change the podSelector to match the labels of the pods that the policy is meant for.",
+	},
+	"pod-probes": {
+		Rationale:   "The readinessProbe decides when a pod receives traffic, and the livenessProbe restarts containers that are stuck. Using the same probe for both restarts containers that are only temporarily unable to serve traffic.",
+		Remediation: "Add a readinessProbe to pods that are targeted by a Service, and make the livenessProbe different from the readinessProbe.",
+	},
+	"port-name-consistency": {
+		Rationale:   "Probes and Services that reference a named port that no container defines can never succeed.",
+		Remediation: "Define the named port on the container, or reference the port by number.",
+	},
+	"pod-readiness-probe-for-service": {
+		Rationale:   "Without a readinessProbe, a container receives traffic from the Service as soon as it starts, before it's ready to handle it.",
+		Remediation: "Add a readinessProbe to all containers that receive traffic from a Service.",
+	},
+	"pod-prestop-for-graceful-shutdown": {
+		Rationale:   "A terminating pod keeps receiving traffic from its Services until the endpoints have been updated. A container that exits as soon as it receives SIGTERM fails those requests during every rollout.",
+		Remediation: "Add a preStop hook that sleeps for a few seconds to the containers that receive traffic, and keep terminationGracePeriodSeconds longer than the sleep and the shutdown of the application.",
+	},
+	"probe-threshold-tuning": {
+		Rationale:   "A livenessProbe that fails once, or for a few seconds, restarts the container. Short pauses such as garbage collection or a slow dependency then cause restarts, which make the problem worse by moving the load to the other replicas.",
+		Remediation: "Raise failureThreshold or periodSeconds of the livenessProbe, or lower the thresholds with the kube-score/probe-min-failure-threshold and kube-score/probe-min-time-to-restart-seconds annotations.",
+	},
+	"probe-prefer-http": {
+		Rationale:   "A tcpSocket probe only verifies that the port accepts connections, while an httpGet probe verifies that the application can respond to requests.",
+		Remediation: "Use an httpGet probe against a health endpoint of the application.",
+	},
+	"container-security-context": {
+		Rationale:   "Containers that run as root, with a low user ID, or with a writable root filesystem, make it easier for an attacker to escalate from a compromised container.",
+		Remediation: "Set a securityContext with runAsNonRoot, a high runAsUser and runAsGroup, and readOnlyRootFilesystem.",
+	},
+	"container-security-context-user-group-id": {
+		Rationale:   "Low user and group IDs can overlap with privileged users on the host, which makes a container escape more dangerous.",
+		Remediation: "Set securityContext.runAsUser and securityContext.runAsGroup to values above 10000.",
+	},
+	"container-security-context-privileged": {
+		Rationale:   "A privileged container has all capabilities and access to the devices of the host, and is effectively root on the node.",
+		Remediation: "Remove securityContext.privileged, and add only the capabilities that the container needs.",
+	},
+	"container-security-context-readonlyrootfilesystem": {
+		Rationale:   "A writable root filesystem lets an attacker modify the binaries and configuration of the container.",
+		Remediation: "Set securityContext.readOnlyRootFilesystem to true, and mount volumes for the paths that the application writes to.",
+	},
+	"container-seccomp-profile": {
+		Rationale:   "Without a seccomp profile, containers can use all system calls, including those that are only needed to attack the kernel.",
+		Remediation: "Set a seccomp profile, such as RuntimeDefault, on the pod or the containers.",
+	},
+	"container-seccomp-profile-field": {
+		Rationale:   "Containers without a seccomp profile, or with the Unconfined profile, can use all system calls, including those that are only needed to attack the kernel. Since Kubernetes v1.19 the profile is set with securityContext.seccompProfile instead of annotations.",
+		Remediation: "Set securityContext.seccompProfile.type to RuntimeDefault on the pod, or to Localhost with a custom profile on the containers that need it.",
+	},
+	"container-token-mount": {
+		Rationale:   "Mounting a volume at the path of the service account token while the token is also automounted makes it unclear which credentials the container uses.",
+		Remediation: "Disable automountServiceAccountToken, or remove the volumeMount at the token path.",
+	},
+	"pod-host-namespaces": {
+		Rationale:   "Pods that share the network, PID or IPC namespace of the host can see and interact with the processes and network traffic of the node.",
+		Remediation: "Remove hostNetwork, hostPID and hostIPC from the pod, unless the pod is a system component that needs them.",
+	},
+	"container-runasuser-root": {
+		Rationale:   "A runAsUser of 0 runs the container as root even if the image is built to run as a non-root user. It's rarely an accident, and a process that escapes the container gets root access to the host.",
+		Remediation: "Remove runAsUser from the container and the pod, or set it to a non-zero value above 10000. Set runAsNonRoot to true to make sure that the container never runs as root.",
+	},
+	"pod-fsgroup": {
+		Rationale:   "Pods that run as non-root may not have permission to write to mounted volumes, unless fsGroup gives the group ownership of the volume.",
+		Remediation: "Set securityContext.fsGroup on the pod.",
+	},
+	"service-targets-pod": {
+		Rationale:   "A Service with a selector that doesn't match any pods has no endpoints, and all connections to it fail.",
+		Remediation: "Change the selector of the Service to match the labels of the pods that it's meant for.",
+	},
+	"service-type": {
+		Rationale:   "NodePort Services open the port on every node in the cluster, and can't be restricted with NetworkPolicies.",
+		Remediation: "Use a Service of type ClusterIP together with an Ingress, or a Service of type LoadBalancer.",
+	},
+	"service-container-protocol-match": {
+		Rationale:   "A Service port that uses a different protocol than the container port that it targets sends traffic that the container never receives.",
+		Remediation: "Set the same protocol on the Service port and on the container port.",
+	},
+	"service-hardcoded-nodeport": {
+		Rationale:   "Each nodePort can only be allocated to one Service in the cluster. A hardcoded nodePort makes the Service fail to apply when the port is already in use, for example when the same manifest is deployed to multiple environments in the same cluster.",
+		Remediation: "Remove the nodePort from the ports of the Service, and let the cluster allocate it. Use the kube-score/ignore annotation on the Service if the nodePort is required.",
+	},
+	"service-insecure-exposed-port": {
+		Rationale:   "Ports of well-known services such as SSH and databases are commonly scanned for and attacked when they are reachable from outside of the cluster.",
+		Remediation: "Remove the port from the Service, use a Service of type ClusterIP, or make the load balancer internal.",
+	},
+	"stable-version": {
+		Rationale:   "Deprecated API versions are removed in later Kubernetes versions, and the objects can then no longer be applied.",
+		Remediation: "Migrate the object to the stable API version.",
+	},
+	"deployment-has-host-podantiaffinity": {
+		Rationale:   "Without a podAntiAffinity, all replicas of the Deployment can be scheduled on the same node, and are all lost if that node fails.",
+		Remediation: "Add a podAntiAffinity with the topologyKey kubernetes.io/hostname that selects the pods of the Deployment.",
+	},
+	"statefulset-has-host-podantiaffinity": {
+		Rationale:   "Without a podAntiAffinity, all replicas of the StatefulSet can be scheduled on the same node, and are all lost if that node fails.",
+		Remediation: "Add a podAntiAffinity with the topologyKey kubernetes.io/hostname that selects the pods of the StatefulSet.",
+	},
+	"statefulset-pod-antiaffinity": {
+		Rationale:   "Quorum based systems lose their quorum if a majority of the replicas are on the same node or in the same zone when it fails.",
+		Remediation: "Add a podAntiAffinity with the topologyKey kubernetes.io/hostname or topology.kubernetes.io/zone.",
+	},
+	"deployment-maxsurge-footprint": {
+		Rationale:   "During a rollout, maxSurge additional pods run at the same time as the old ones. A large maxSurge temporarily requires that much more capacity in the cluster.",
+		Remediation: "Lower spec.strategy.rollingUpdate.maxSurge, or raise the threshold with --max-surge-percentage.",
+	},
+	"deployment-min-ready-seconds": {
+		Rationale:   "A pod is counted as available as soon as its readinessProbe succeeds. Without minReadySeconds, a new version that crashes shortly after starting is rolled out to all replicas, as each new pod is briefly ready.",
+		Remediation: "Set spec.minReadySeconds on the Deployment to a small value, such as 10, that is longer than the time it usually takes for a broken pod to crash.",
+	},
+	"deployment-targeted-by-hpa-does-not-have-replicas-configured": {
+		Rationale:   "When both the Deployment and the HorizontalPodAutoscaler set the replicas, every apply resets the replicas to the static count, even if the autoscaler has scaled up.",
+		Remediation: "Remove spec.replicas from the Deployment.",
+	},
+	"statefulset-has-servicename": {
+		Rationale:   "The pods of a StatefulSet get their stable network identity from the headless Service in serviceName. Without it, the pods can't be reached by their names.",
+		Remediation: "Set serviceName to the name of a headless Service that selects the pods of the StatefulSet.",
+	},
+	"deployment-pod-selector-labels-match-template-metadata-labels": {
+		Rationale:   "A Deployment with a selector that doesn't match the labels of its pod template is rejected by Kubernetes.",
+		Remediation: "Make spec.selector match the labels in spec.template.metadata.labels.",
+	},
+	"statefulset-pod-selector-labels-match-template-metadata-labels": {
+		Rationale:   "A StatefulSet with a selector that doesn't match the labels of its pod template is rejected by Kubernetes.",
+		Remediation: "Make spec.selector match the labels in spec.template.metadata.labels.",
+	},
+	"label-values": {
+		Rationale:   "Kubernetes rejects objects with label values that are longer than 63 characters, or that contain other characters than alphanumerics, '-', '_' and '.'.",
+		Remediation: "Change the label value to a valid value.",
+	},
+	"duplicate-object-identity": {
+		Rationale:   "When two objects have the same apiVersion, kind, namespace and name, the last one that is applied overwrites the other.",
+		Remediation: "Rename one of the objects, or remove the duplicate.",
+	},
+	"serviceaccount-cluster-admin": {
+		Rationale:   "A pod can use the permissions of its ServiceAccount. If the ServiceAccount is bound to cluster-admin, anyone that can run code in the pod, for example through a vulnerability in the application, has full access to the cluster.",
+		Remediation: "Create a Role or ClusterRole with only the permissions that the workload needs, and bind the ServiceAccount to it instead.",
+	},
+	"rbac-wildcard": {
+		Rationale:   "Wildcards grant access to all current and future resources and verbs, including ones that are added by CRDs later. Write access to Secrets and RBAC objects, and the bind, escalate and impersonate verbs, can be used to gain more permissions.",
+		Remediation: "List the apiGroups, resources and verbs that are needed, and limit rules for sensitive resources to specific resourceNames or to read-only verbs.",
+	},
+	"object-kind-known": {
+		Rationale:   "Objects with a misspelled kind or apiVersion are not scored by kube-score, and are rejected by Kubernetes when they are applied.",
+		Remediation: "Correct the apiVersion and kind. Custom resources are never reported, as their group always contains a dot.",
+	},
+	"object-metadata-size": {
+		Rationale:   "Labels and annotations are stored in etcd and returned in every response, including in watches and list calls. Objects with very large annotations, or hundreds of labels, often added by tooling, slow down the API server and its clients.",
+		Remediation: "Move large data from annotations to a ConfigMap, remove annotations and labels that are added by tooling, and use annotations instead of labels for metadata that is not used by selectors.",
+	},
+	"object-namespace-set": {
+		Rationale:   "Objects without a namespace are created in the default namespace of whoever applies them, which can differ between users and CI systems, and mixes the objects of different tenants.",
+		Remediation: "Set metadata.namespace on all namespaced objects.",
+	},
+	"object-no-last-applied-annotation": {
+		Rationale:   "kubectl apply stores the applied manifest in the kubectl.kubernetes.io/last-applied-configuration annotation of the object in the cluster. A manifest with the annotation has been copied from the cluster, and the stale annotation can make kubectl apply remove or keep fields in unexpected ways.",
+		Remediation: "Remove the annotation from the manifest, together with other fields that are set by the cluster, such as status, metadata.uid and metadata.resourceVersion.",
+	},
+	"object-recommended-labels": {
+		Rationale:   "The recommended app.kubernetes.io/ labels describe which application an object belongs to, and are used by tools to show and manage applications.",
+		Remediation: "Set the missing labels, or change the required labels with --recommended-label.",
+	},
+	"horizontalpodautoscaler-has-target": {
+		Rationale:   "A HorizontalPodAutoscaler that targets an object that doesn't exist has no effect.",
+		Remediation: "Change scaleTargetRef to reference an existing object in the same namespace.",
+	},
+	"hpa-minmax-replicas": {
+		Rationale:   "An HPA with the same minReplicas and maxReplicas never changes the number of replicas, and an HPA with a minReplicas of 0 is rejected by clusters that don't have the alpha HPAScaleToZero feature gate enabled.",
+		Remediation: "Set minReplicas to at least 1, and maxReplicas to the largest number of replicas that the target may scale up to.",
+	},
+	"pvc-storageclass": {
+		Rationale:   "Without an explicit storageClassName, the default StorageClass of the cluster is used, which can be different between clusters.",
+		Remediation: "Set storageClassName on the PersistentVolumeClaim, or on the volumeClaimTemplates of the StatefulSet.",
+	},
+	"statefulset-volumeclaim-accessmodes": {
+		Rationale:   "A volumeClaimTemplate without accessModes can't be provisioned, and the pods of the StatefulSet never start. Most block storage can only be mounted by a single node, so ReadWriteMany volumes often fail to provision or attach.",
+		Remediation: "Set accessModes to ReadWriteOnce on the volumeClaimTemplates, and only use ReadWriteMany with a StorageClass that supports it, such as NFS.",
+	},
+	"pod-priority-class": {
+		Rationale:   "Critical pods without a priorityClassName have the same priority as all other pods, and can be preempted, or fail to schedule, when the cluster is full.",
+		Remediation: "Set priorityClassName to a PriorityClass with a high priority.",
+	},
+	"pod-nodeselector-toleration": {
+		Rationale:   "Pods that select control plane nodes, but don't tolerate the control plane taint, can never be scheduled.",
+		Remediation: "Add a toleration for the node-role.kubernetes.io/control-plane and node-role.kubernetes.io/master taints.",
+	},
+	"secret-double-encoded": {
+		Rationale:   "The values in the data of a Secret are base64 decoded by Kubernetes. Values that are encoded twice, often by encoding a value that was already encoded, are passed to the containers still base64 encoded.",
+		Remediation: "Encode the values in data only once, or put the plain values in stringData. Ignore this check with the kube-score/ignore annotation if the application expects base64 encoded values.",
+	},
+	"secret-tls-type": {
+		Rationale:   "TLS certificates and keys in Opaque Secrets are not validated, and are not recognized by Ingress controllers and other tools that expect Secrets of type kubernetes.io/tls.",
+		Remediation: "Set the type of the Secret to kubernetes.io/tls, and store the certificate in tls.crt and the key in tls.key.",
+	},
+	"namespace-pod-security-labels": {
+		Rationale:   "Pod Security Admission only rejects pods that don't follow a Pod Security Standard in Namespaces that have the pod-security.kubernetes.io/enforce label, pods in all other Namespaces can run as privileged.",
+		Remediation: "Set the pod-security.kubernetes.io/enforce label of the Namespace to restricted, or to baseline if the pods need more privileges.",
+	},
+	"configmap-secret-immutable": {
+		Rationale:   "The kubelet watches all ConfigMaps and Secrets that are used by pods. Immutable objects are not watched, which reduces the load on the API server, and can't be changed by mistake.",
+		Remediation: "Set immutable to true, and create a new object with a new name when the data changes.",
+	},
+}
@@ -0,0 +1,52 @@
+package configmap
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/zegl/kube-score/config"
+	"github.com/zegl/kube-score/score/checks"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// immutableAvailableSince is the first version of Kubernetes where the immutable field is GA
+var immutableAvailableSince = config.Semver{Major: 1, Minor: 21}
+
+func Register(allChecks *checks.Checks, kubernetesVersion config.Semver) {
+	allChecks.RegisterOptionalConfigMapCheck("ConfigMap Secret Immutable", `Makes sure that all ConfigMaps and Secrets have immutable set to true`, configMapImmutable(kubernetesVersion))
+	allChecks.RegisterOptionalSecretCheck("ConfigMap Secret Immutable", `Makes sure that all ConfigMaps and Secrets have immutable set to true`, secretImmutable(kubernetesVersion))
+}
+
+func configMapImmutable(kubernetesVersion config.Semver) func(corev1.ConfigMap) scorecard.TestScore {
+	return func(configMap corev1.ConfigMap) scorecard.TestScore {
+		return immutable("ConfigMap", configMap.Immutable, kubernetesVersion)
+	}
+}
+
+func secretImmutable(kubernetesVersion config.Semver) func(corev1.Secret) scorecard.TestScore {
+	return func(secret corev1.Secret) scorecard.TestScore {
+		return immutable("Secret", secret.Immutable, kubernetesVersion)
+	}
+}
+
+func immutable(kind string, isImmutable *bool, kubernetesVersion config.Semver) (score scorecard.TestScore) {
+	if kubernetesVersion.LessThan(immutableAvailableSince) {
+		score.Grade = scorecard.GradeAllOK
+		score.Skipped = true
+		score.AddComment("", fmt.Sprintf("Skipped because immutable %ss are not supported before Kubernetes %s", kind, immutableAvailableSince), "")
+		return
+	}
+
+	if isImmutable != nil && *isImmutable {
+		score.Grade = scorecard.GradeAllOK
+		return
+	}
+
+	score.Grade = scorecard.GradeWarning
+	score.AddComment("", fmt.Sprintf("The %s is not immutable", kind),
+		fmt.Sprintf("Changes to a mutable %s are picked up by running Pods without a rollout. "+
+			"Set immutable to true, and create a new %s with a new name when the data needs to change.", kind, kind),
+	)
+	return
+}
@@ -0,0 +1,60 @@
+package score
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func testImmutableConfig(filename string, kubernetesVersion config.Semver) config.Configuration {
+	return config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile(filename)},
+		EnabledOptionalTests: map[string]struct{}{"configmap-secret-immutable": {}},
+		KubernetesVersion:    kubernetesVersion,
+	}
+}
+
+func TestConfigMapImmutableNotSet(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, testImmutableConfig("configmap-immutable-not-set.yaml", config.Semver{1, 21}), "ConfigMap Secret Immutable", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "The ConfigMap is not immutable", comments[0].Summary)
+}
+
+func TestConfigMapImmutableSet(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, testImmutableConfig("configmap-immutable-set.yaml", config.Semver{1, 21}), "ConfigMap Secret Immutable", scorecard.GradeAllOK)
+}
+
+func TestSecretImmutableNotSet(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, testImmutableConfig("secret-immutable-not-set.yaml", config.Semver{1, 22}), "ConfigMap Secret Immutable", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "The Secret is not immutable", comments[0].Summary)
+}
+
+func TestSecretImmutableSet(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, testImmutableConfig("secret-immutable-set.yaml", config.Semver{1, 21}), "ConfigMap Secret Immutable", scorecard.GradeAllOK)
+}
+
+func TestConfigMapImmutableOldKubernetesVersion(t *testing.T) {
+	t.Parallel()
+	sc, err := testScore(testImmutableConfig("configmap-immutable-not-set.yaml", config.Semver{1, 20}))
+	assert.Nil(t, err)
+
+	for _, o := range sc {
+		for _, c := range o.Checks {
+			if c.Check.ID == "configmap-secret-immutable" {
+				assert.True(t, c.Skipped)
+				assert.Equal(t, scorecard.SkipReasonNotApplicable, c.SkipReason)
+				return
+			}
+		}
+	}
+	t.Error("Was not tested")
+}
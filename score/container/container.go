@@ -1,27 +1,119 @@
 package container
 
 import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/score/checks"
+	"github.com/zegl/kube-score/score/internal"
 	"github.com/zegl/kube-score/scorecard"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func Register(allChecks *checks.Checks, cnf config.Configuration) {
-	allChecks.RegisterPodCheck("Container Resources", `Makes sure that all pods have resource limits and requests set. The --ignore-container-cpu-limit flag can be used to disable the requirement of having a CPU limit`, containerResources(!cnf.IgnoreContainerCpuLimitRequirement, !cnf.IgnoreContainerMemoryLimitRequirement))
+func Register(allChecks *checks.Checks, cnf config.Configuration, statefulSets ks.StatefulSets, pods ks.Pods, podspecers ks.PodSpeccers, invalidQuantities ks.InvalidQuantities) {
+	allChecks.RegisterPodCheck("Container Resources", `Makes sure that all pods have resource limits and requests set. The --ignore-container-cpu-limit flag can be used to disable the requirement of having a CPU limit`, containerResources(cnf))
 	allChecks.RegisterOptionalPodCheck("Container Resource Requests Equal Limits", `Makes sure that all pods have the same requests as limits on resources set.`, containerResourceRequestsEqualLimits)
 	allChecks.RegisterOptionalPodCheck("Container CPU Requests Equal Limits", `Makes sure that all pods have the same CPU requests as limits set.`, containerCPURequestsEqualLimits)
 	allChecks.RegisterOptionalPodCheck("Container Memory Requests Equal Limits", `Makes sure that all pods have the same memory requests as limits set.`, containerMemoryRequestsEqualLimits)
+	allChecks.RegisterOptionalPodCheck("Container Resource Request Limit Pairing", `Makes sure that CPU and memory either have both a request and a limit set, or neither`, containerResourceRequestLimitPairing)
+	allChecks.RegisterOptionalPodCheck("Container Memory Limit Required", `Makes sure that all containers have a memory limit set, regardless of the --ignore-container-memory-limit flag`, containerMemoryLimitRequired)
 	allChecks.RegisterPodCheck("Container Image Tag", `Makes sure that a explicit non-latest tag is used`, containerImageTag)
+	allChecks.RegisterOptionalPodCheck("Container Image Mutable Tag", `Makes sure that no container uses an image with a mutable tag, such as latest, stable or a bare major version, or without a tag. The tags can be changed with --mutable-image-tag`, containerImageMutableTag(cnf.MutableImageTags))
+	allChecks.RegisterOptionalPodCheck("Container Implicit DockerHub", `Makes sure that no container pulls its image from Docker Hub, either explicitly or because the image has no registry host`, containerImplicitDockerHub)
 	allChecks.RegisterPodCheck("Container Image Pull Policy", `Makes sure that the pullPolicy is set to Always. This makes sure that imagePullSecrets are always validated.`, containerImagePullPolicy)
+	allChecks.RegisterOptionalPodCheck("Image Pull Policy Consistency", `Makes sure that all workloads that use the same image pull it with the same imagePullPolicy`, imagePullPolicyConsistency(pods.Pods(), podspecers.PodSpeccers()))
+	allChecks.CrossObject("Image Pull Policy Consistency")
+	allChecks.RegisterMetaCheck("Container Resource Quantity Valid", `Makes sure that all quantities of resource requests and limits, such as cpu, memory and storage, can be parsed`, containerResourceQuantityValid(invalidQuantities.InvalidQuantities()))
+	allChecks.CrossObject("Container Resource Quantity Valid")
+	allChecks.RegisterPodCheck("Container Extended Resource Request Equals Limit", `Makes sure that extended resources, such as GPUs, have the same requests as limits set`, containerExtendedResourceRequestEqualsLimit)
+	allChecks.RegisterPodCheck("Container FieldRef Valid", `Makes sure that the fieldRef and resourceFieldRef of all environment variables reference fields that are supported by the downward API`, containerFieldRefValid)
+	allChecks.RegisterOptionalPodCheck("Container Env Plaintext Secret", `Makes sure that environment variables that look like secrets are read from a Secret instead of being set in plaintext`, containerEnvPlaintextSecret)
+	allChecks.RegisterPodCheck("Pod Duplicate Container Names", `Makes sure that all containers, init containers and ephemeral containers in a pod have unique names`, podDuplicateContainerNames)
+	allChecks.RegisterPodCheck("Pod HostPort Conflict", `Makes sure that no two containers in a pod bind the same hostPort, and warns about containers that bind a hostPort`, podHostPortConflict)
+	allChecks.RegisterPodCheck("Pod RestartPolicy For Controller", `Makes sure that the restartPolicy of the pod is supported by its controller, Always for Deployments, StatefulSets and DaemonSets, and OnFailure or Never for Jobs and CronJobs`, podRestartPolicyForController)
+	allChecks.RegisterPodCheck("Container VolumeMount Exists", `Makes sure that all volumeMounts reference a volume that is defined in the pod`, containerVolumeMountExists(statefulSets.StatefulSets()))
+	allChecks.CrossObject("Container VolumeMount Exists")
+	allChecks.RegisterOptionalPodCheck("Pod EmptyDir SizeLimit", `Makes sure that all emptyDir volumes have a sizeLimit set`, podEmptyDirSizeLimit)
+	allChecks.RegisterOptionalPodCheck("Container Resource Unit Style", `Makes sure that CPU and memory quantities don't get rounded, and that memory quantities use the same kind of units in the whole pod`, containerResourceUnitStyle)
+	allChecks.RegisterOptionalPodObjectCheck("Pod Guaranteed QoS", `Makes sure that objects annotated with kube-score/qos: guaranteed have requests equal to limits for CPU and memory in all containers`, podGuaranteedQoS)
+	allChecks.RegisterOptionalPodCheck("Init Container Resources", `Makes sure that init containers have CPU and memory requests set when the regular containers of the pod have`, initContainerResources)
+	allChecks.RegisterOptionalPodCheck("Container Shell Wrapped Entrypoint", `Makes sure that containers don't run their process as a child of sh -c, where it doesn't receive SIGTERM`, containerShellWrappedEntrypoint)
+	allChecks.RegisterOptionalPodCheck("Container PreStop Command Sanity", `Makes sure that preStop exec hooks have a command, and don't run a shell or coreutils binary such as sleep in a distroless image, where it does not exist`, containerPreStopCommandSanity)
+	allChecks.RegisterOptionalPodCheck("Container TTY Stdin", `Makes sure that containers in workloads that are managed by a controller don't have stdin or tty enabled`, containerTTYStdin)
+	allChecks.RegisterOptionalPodCheck("Container Termination Message Policy", `Makes sure that all containers have terminationMessagePolicy set to FallbackToLogsOnError, so that the logs are used as the termination message of crashed containers`, containerTerminationMessagePolicy)
+	allChecks.RegisterOptionalPodCheck("Container Port Naming", `Makes sure that all ports have a name, in containers that declare more than one port`, containerPortNaming)
+	allChecks.RegisterOptionalPodObjectCheck("Pod Native Sidecar", `Makes sure that sidecar containers are declared as native sidecars, init containers with restartPolicy: Always, on Kubernetes v1.29 and later. Containers named *-sidecar, or listed in the kube-score/sidecars annotation, are considered to be sidecars`, podNativeSidecar(cnf.KubernetesVersion))
+	allChecks.RegisterOptionalDaemonSetCheck("DaemonSet Resource Footprint", `Makes sure that the containers of DaemonSets don't request more than 500m CPU or 512Mi memory, the thresholds can be changed with the kube-score/daemonset-max-cpu-request and kube-score/daemonset-max-memory-request annotations on the DaemonSet`, daemonSetResourceFootprint)
 }
 
+const (
+	// qosAnnotation declares the QoS class that the pods of an object are expected to get, the "Pod Guaranteed QoS"
+	// check is verifying objects with the value "guaranteed"
+	qosAnnotation = "kube-score/qos"
+	qosGuaranteed = "guaranteed"
+
+	// daemonSetMaxCPUAnnotation and daemonSetMaxMemoryAnnotation overrides the thresholds used by the
+	// "DaemonSet Resource Footprint" check
+	daemonSetMaxCPUAnnotation    = "kube-score/daemonset-max-cpu-request"
+	daemonSetMaxMemoryAnnotation = "kube-score/daemonset-max-memory-request"
+
+	// sidecarsAnnotation is a comma separated list of the names of the containers in the pods that are sidecars, used
+	// by the "Pod Native Sidecar" check in addition to the containers that are named *-sidecar
+	sidecarsAnnotation = "kube-score/sidecars"
+)
+
+// nativeSidecarsEnabledSince is the first Kubernetes version where native sidecars are enabled by default
+var nativeSidecarsEnabledSince = config.Semver{Major: 1, Minor: 29}
+
+// DaemonSetMaxCPURequest and DaemonSetMaxMemoryRequest are the default thresholds of the "DaemonSet Resource Footprint" check
+var (
+	DaemonSetMaxCPURequest    = resource.MustParse("500m")
+	DaemonSetMaxMemoryRequest = resource.MustParse("512Mi")
+)
+
+// Shells are the names of the executables that are considered to be shells by the "Container Shell Wrapped Entrypoint" check
+var Shells = map[string]struct{}{
+	"sh":   {},
+	"bash": {},
+	"ash":  {},
+	"dash": {},
+	"zsh":  {},
+}
+
+// DistrolessBinaries are the names of the executables that are assumed to not exist in distroless images by the
+// "Container PreStop Command Sanity" check, in addition to the Shells
+var DistrolessBinaries = map[string]struct{}{
+	"sleep":   {},
+	"kill":    {},
+	"killall": {},
+	"pkill":   {},
+	"curl":    {},
+	"wget":    {},
+}
+
+// shellExecPattern matches scripts that use exec to replace the shell with the process
+var shellExecPattern = regexp.MustCompile(`(^|[;&|\n])\s*exec\s`)
+
+// PlaintextSecretEnvPatterns is the list of (case insensitive) words in environment variable names that are
+// considered to be secrets by the "Container Env Plaintext Secret" check. The words are separated by "_", so that
+// API_KEY is matched by KEY, but MONKEY and KEYCLOAK_URL are not. A trailing "S" is ignored, KEYS is matched by KEY.
+var PlaintextSecretEnvPatterns = []string{"PASSWORD", "PASSWD", "TOKEN", "SECRET", "KEY", "APIKEY", "CREDENTIAL"}
+
 // containerResources makes sure that the container has resource requests and limits set
-// The check for a CPU limit requirement can be enabled via the requireCPULimit flag parameter
-func containerResources(requireCPULimit bool, requireMemoryLimit bool) func(corev1.PodTemplateSpec, metav1.TypeMeta) scorecard.TestScore {
+// The requirement of CPU and memory limits can be disabled with IgnoreContainerCpuLimitRequirement and
+// IgnoreContainerMemoryLimitRequirement. The requests are also compared to the thresholds in the config, if they are set.
+func containerResources(cnf config.Configuration) func(corev1.PodTemplateSpec, metav1.TypeMeta) scorecard.TestScore {
+	requireCPULimit := !cnf.IgnoreContainerCpuLimitRequirement
+	requireMemoryLimit := !cnf.IgnoreContainerMemoryLimitRequirement
+
 	return func(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
 		pod := podTemplate.Spec
 
@@ -48,6 +140,9 @@ func containerResources(requireCPULimit bool, requireMemoryLimit bool) func(core
 				score.AddComment(container.Name, "Memory request is not set", "Resource requests are recommended to make sure that the application can start and run without crashing. Set resources.requests.memory")
 				hasMissingRequest = true
 			}
+			if containerResourcesOutsideThresholds(&score, container, cnf) {
+				hasMissingRequest = true
+			}
 		}
 
 		if len(allContainers) == 0 {
@@ -65,6 +160,51 @@ func containerResources(requireCPULimit bool, requireMemoryLimit bool) func(core
 	}
 }
 
+// containerResourcesOutsideThresholds adds a comment for each request that is smaller than the configured minimum, and
+// for each limit that is more than the configured ratio larger than the request. Requests that are not set are ignored.
+func containerResourcesOutsideThresholds(score *scorecard.TestScore, container corev1.Container, cnf config.Configuration) bool {
+	outside := false
+
+	resources := []struct {
+		name       corev1.ResourceName
+		minRequest resource.Quantity
+	}{
+		{corev1.ResourceCPU, cnf.MinContainerCPURequest},
+		{corev1.ResourceMemory, cnf.MinContainerMemoryRequest},
+	}
+
+	for _, r := range resources {
+		request, ok := container.Resources.Requests[r.name]
+		if !ok || request.IsZero() {
+			continue
+		}
+
+		if !r.minRequest.IsZero() && request.Cmp(r.minRequest) < 0 {
+			score.AddComment(container.Name,
+				fmt.Sprintf("The %s request %s is lower than %s", r.name, request.String(), r.minRequest.String()),
+				fmt.Sprintf("Requests that are too small can make the container get throttled or evicted. Set resources.requests.%s to at least %s", r.name, r.minRequest.String()),
+			)
+			outside = true
+		}
+
+		limit, ok := container.Resources.Limits[r.name]
+		if !ok || limit.IsZero() || cnf.MaxContainerLimitRequestRatio <= 0 {
+			continue
+		}
+
+		ratio := limit.AsApproximateFloat64() / request.AsApproximateFloat64()
+		if ratio > cnf.MaxContainerLimitRequestRatio {
+			score.AddComment(container.Name,
+				fmt.Sprintf("The %s limit is %.1f times the request", r.name, ratio),
+				fmt.Sprintf("A limit that is much larger than the request overcommits the node, and the container can be throttled or evicted when the node runs out of %s. Keep resources.limits.%s at most %g times resources.requests.%s", r.name, r.name, cnf.MaxContainerLimitRequestRatio, r.name),
+			)
+			outside = true
+		}
+	}
+
+	return outside
+}
+
 // containerResourceRequestsEqualLimits checks that all containers have equal requests and limits for CPU and memory resources
 func containerResourceRequestsEqualLimits(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
 	cpuScore := containerCPURequestsEqualLimits(podTemplate, typeMeta)
@@ -137,6 +277,63 @@ func containerMemoryRequestsEqualLimits(podTemplate corev1.PodTemplateSpec, type
 	return
 }
 
+// containerResourceRequestLimitPairing checks that the CPU and memory of all containers have either both a request
+// and a limit, or neither. A limit without a request implicitly sets the request to the limit, and a request without
+// a limit lets the container use all of the resources available on the node.
+func containerResourceRequestLimitPairing(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	allContainers := append([]corev1.Container{}, podTemplate.Spec.InitContainers...)
+	allContainers = append(allContainers, podTemplate.Spec.Containers...)
+
+	for _, container := range allContainers {
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			_, hasRequest := container.Resources.Requests[name]
+			_, hasLimit := container.Resources.Limits[name]
+
+			if hasRequest && !hasLimit {
+				score.Grade = scorecard.GradeWarning
+				score.AddComment(container.Name,
+					fmt.Sprintf("%s request is set without a limit", name),
+					fmt.Sprintf("The container can use more %s than it requests, up to all of the %s available on the node, which makes its usage hard to predict. ", name, name)+
+						fmt.Sprintf("Set resources.limits.%s, or remove resources.requests.%s if the container is intentionally unbounded", name, name),
+				)
+			} else if hasLimit && !hasRequest {
+				score.Grade = scorecard.GradeWarning
+				score.AddComment(container.Name,
+					fmt.Sprintf("%s limit is set without a request", name),
+					fmt.Sprintf("When only a limit is set, Kubernetes implicitly sets the request to the same value as the limit, which can reserve much more %s than the container needs. ", name)+
+						fmt.Sprintf("Set resources.requests.%s explicitly", name),
+				)
+			}
+		}
+	}
+
+	return
+}
+
+// containerMemoryLimitRequired checks that all containers have a memory limit. Memory can't be throttled like CPU,
+// and a container without a limit can use all of the memory on the node, until the kernel starts killing processes.
+func containerMemoryLimitRequired(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	allContainers := append([]corev1.Container{}, podTemplate.Spec.InitContainers...)
+	allContainers = append(allContainers, podTemplate.Spec.Containers...)
+
+	for _, container := range allContainers {
+		if _, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
+			continue
+		}
+		score.Grade = scorecard.GradeCritical
+		score.AddComment(container.Name,
+			"Memory limit is not set",
+			"Memory is incompressible, a container without a memory limit can use all of the memory on the node and cause other pods, or the node itself, to be OOM killed. Set resources.limits.memory",
+		)
+	}
+
+	return
+}
+
 // containerImageTag checks that no container is using the ":latest" tag
 func containerImageTag(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
 	pod := podTemplate.Spec
@@ -202,3 +399,599 @@ func containerTag(image string) string {
 	}
 	return ""
 }
+
+// containerExtendedResourceRequestEqualsLimit checks that all extended resources (all resources except for cpu, memory
+// and ephemeral-storage) have equal requests and limits, which is required by Kubernetes
+func containerExtendedResourceRequestEqualsLimit(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	pod := podTemplate.Spec
+
+	allContainers := pod.InitContainers
+	allContainers = append(allContainers, pod.Containers...)
+
+	score.Grade = scorecard.GradeAllOK
+
+	for _, container := range allContainers {
+		requests := container.Resources.Requests
+		limits := container.Resources.Limits
+
+		var names []string
+		seen := make(map[corev1.ResourceName]struct{})
+		for _, list := range []corev1.ResourceList{requests, limits} {
+			for name := range list {
+				if _, ok := seen[name]; ok || !isExtendedResource(name) {
+					continue
+				}
+				seen[name] = struct{}{}
+				names = append(names, string(name))
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			request, hasRequest := requests[corev1.ResourceName(name)]
+			limit, hasLimit := limits[corev1.ResourceName(name)]
+
+			switch {
+			case !hasLimit:
+				score.AddComment(container.Name, fmt.Sprintf("The extended resource %s has no limit set", name),
+					fmt.Sprintf("Extended resources must have equal requests and limits. Set resources.limits.%s to %s", name, request.String()))
+			case !hasRequest:
+				score.AddComment(container.Name, fmt.Sprintf("The extended resource %s has no request set", name),
+					fmt.Sprintf("Extended resources must have equal requests and limits. Set resources.requests.%s to %s", name, limit.String()))
+			case !request.Equal(limit):
+				score.AddComment(container.Name, fmt.Sprintf("The extended resource %s request does not match the limit", name),
+					fmt.Sprintf("Extended resources must have equal requests and limits, the request is %s and the limit is %s. Set resources.requests.%s == resources.limits.%s", request.String(), limit.String(), name, name))
+			default:
+				continue
+			}
+
+			score.Grade = scorecard.GradeCritical
+		}
+	}
+
+	return
+}
+
+func isExtendedResource(name corev1.ResourceName) bool {
+	switch name {
+	case corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage:
+		return false
+	default:
+		return true
+	}
+}
+
+// containerEnvPlaintextSecret checks that no environment variable with a name that looks like a secret has a literal value
+func containerEnvPlaintextSecret(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	pod := podTemplate.Spec
+
+	allContainers := pod.InitContainers
+	allContainers = append(allContainers, pod.Containers...)
+
+	score.Grade = scorecard.GradeAllOK
+
+	for _, container := range allContainers {
+		for _, env := range container.Env {
+			if env.Value == "" || env.ValueFrom != nil || !looksLikeSecret(env.Name) {
+				continue
+			}
+
+			score.Grade = scorecard.GradeWarning
+			score.AddComment(container.Name,
+				fmt.Sprintf("The environment variable %s looks like a secret, and is set in plaintext", env.Name),
+				"Plaintext values are visible to anyone that can read the workload, for example via kubectl describe. Store the value in a Secret and use valueFrom.secretKeyRef instead.",
+			)
+		}
+	}
+
+	return
+}
+
+func looksLikeSecret(envName string) bool {
+	words := strings.FieldsFunc(strings.ToUpper(envName), func(r rune) bool {
+		return r == '_' || r == '-' || r == '.'
+	})
+	for _, word := range words {
+		for _, pattern := range PlaintextSecretEnvPatterns {
+			pattern = strings.ToUpper(pattern)
+			if word == pattern || word == pattern+"S" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// podDuplicateContainerNames checks that no two containers in the pod share the same name
+func podDuplicateContainerNames(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	pod := podTemplate.Spec
+
+	var names []string
+	for _, c := range pod.InitContainers {
+		names = append(names, c.Name)
+	}
+	for _, c := range pod.Containers {
+		names = append(names, c.Name)
+	}
+	for _, c := range pod.EphemeralContainers {
+		names = append(names, c.Name)
+	}
+
+	seen := make(map[string]int)
+	var duplicates []string
+	for _, name := range names {
+		seen[name]++
+		if seen[name] == 2 {
+			duplicates = append(duplicates, name)
+		}
+	}
+
+	if len(duplicates) == 0 {
+		score.Grade = scorecard.GradeAllOK
+		return
+	}
+
+	score.Grade = scorecard.GradeCritical
+	for _, name := range duplicates {
+		score.AddComment(name,
+			fmt.Sprintf("The container name %s is used by %d containers", name, seen[name]),
+			"Container names must be unique within a pod, including init containers and ephemeral containers. Kubernetes will reject the pod.",
+		)
+	}
+
+	return
+}
+
+// containerVolumeMountExists returns a function that checks that all volumeMounts in all containers reference a
+// volume defined in the pod. Pods of a StatefulSet can also mount the volumeClaimTemplates of the StatefulSet.
+func containerVolumeMountExists(allStatefulSets []ks.StatefulSet) func(corev1.PodTemplateSpec, metav1.TypeMeta) scorecard.TestScore {
+	return func(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+		pod := podTemplate.Spec
+
+		volumes := make(map[string]struct{})
+		for _, volume := range pod.Volumes {
+			volumes[volume.Name] = struct{}{}
+		}
+
+		if typeMeta.Kind == "StatefulSet" {
+			for _, name := range volumeClaimTemplateNames(allStatefulSets, podTemplate) {
+				volumes[name] = struct{}{}
+			}
+		}
+
+		type namedMounts struct {
+			name   string
+			mounts []corev1.VolumeMount
+		}
+
+		var allMounts []namedMounts
+		for _, c := range pod.InitContainers {
+			allMounts = append(allMounts, namedMounts{c.Name, c.VolumeMounts})
+		}
+		for _, c := range pod.Containers {
+			allMounts = append(allMounts, namedMounts{c.Name, c.VolumeMounts})
+		}
+		for _, c := range pod.EphemeralContainers {
+			allMounts = append(allMounts, namedMounts{c.Name, c.VolumeMounts})
+		}
+
+		score.Grade = scorecard.GradeAllOK
+
+		for _, container := range allMounts {
+			for _, mount := range container.mounts {
+				if _, ok := volumes[mount.Name]; ok {
+					continue
+				}
+
+				score.Grade = scorecard.GradeCritical
+				score.AddComment(container.name,
+					fmt.Sprintf("The volumeMount %s does not match any volume in the pod", mount.Name),
+					"The name of a volumeMount must match the name of a volume in the pod, otherwise the pod can not be created. Add the volume to the pod, or correct the name of the volumeMount.",
+				)
+			}
+		}
+
+		return
+	}
+}
+
+// volumeClaimTemplateNames returns the names of the volumeClaimTemplates of all StatefulSets that the pod belongs to
+func volumeClaimTemplateNames(allStatefulSets []ks.StatefulSet, podTemplate corev1.PodTemplateSpec) (names []string) {
+	for _, s := range allStatefulSets {
+		statefulSet := s.StatefulSet()
+		if statefulSet.Namespace != podTemplate.Namespace || statefulSet.Spec.Selector == nil {
+			continue
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(statefulSet.Spec.Selector)
+		if err != nil || !selector.Matches(internal.MapLables(podTemplate.Labels)) {
+			continue
+		}
+
+		for _, template := range statefulSet.Spec.VolumeClaimTemplates {
+			names = append(names, template.Name)
+		}
+	}
+	return
+}
+
+// podEmptyDirSizeLimit checks that all emptyDir volumes have a sizeLimit set
+func podEmptyDirSizeLimit(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	var memoryVolumes []string
+	var diskVolumes []string
+
+	for _, volume := range podTemplate.Spec.Volumes {
+		if volume.EmptyDir == nil || volume.EmptyDir.SizeLimit != nil {
+			continue
+		}
+		if volume.EmptyDir.Medium == corev1.StorageMediumMemory {
+			memoryVolumes = append(memoryVolumes, volume.Name)
+		} else {
+			diskVolumes = append(diskVolumes, volume.Name)
+		}
+	}
+
+	score.Grade = scorecard.GradeAllOK
+
+	if len(diskVolumes) > 0 {
+		score.Grade = scorecard.GradeWarning
+		score.AddComment("", "The pod has emptyDir volumes without a sizeLimit",
+			fmt.Sprintf("The emptyDir volumes %s can use all ephemeral storage on the node. Set emptyDir.sizeLimit to limit how much storage the volume can use.", strings.Join(diskVolumes, ", ")),
+		)
+	}
+
+	if len(memoryVolumes) > 0 {
+		score.Grade = scorecard.GradeCritical
+		score.AddComment("", "The pod has memory backed emptyDir volumes without a sizeLimit",
+			fmt.Sprintf("The emptyDir volumes %s are stored in memory, and can use all memory on the node. Set emptyDir.sizeLimit to limit how much memory the volume can use.", strings.Join(memoryVolumes, ", ")),
+		)
+	}
+
+	return
+}
+
+// containerResourceUnitStyle checks that CPU and memory quantities are specified in a way that does not get rounded,
+// and that decimal (M, G) and binary (Mi, Gi) memory units are not mixed in the same pod
+func containerResourceUnitStyle(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	pod := podTemplate.Spec
+
+	allContainers := pod.InitContainers
+	allContainers = append(allContainers, pod.Containers...)
+
+	score.Grade = scorecard.GradeAllOK
+
+	var decimalMemory []string
+	var binaryMemory []string
+
+	for _, container := range allContainers {
+		resources := []struct {
+			name string
+			list corev1.ResourceList
+		}{
+			{"requests", container.Resources.Requests},
+			{"limits", container.Resources.Limits},
+		}
+
+		for _, r := range resources {
+			if cpu, ok := r.list[corev1.ResourceCPU]; ok {
+				canonical := resource.NewMilliQuantity(cpu.MilliValue(), resource.DecimalSI)
+				if cpu.Cmp(*canonical) != 0 {
+					score.Grade = scorecard.GradeWarning
+					score.AddComment(container.Name,
+						fmt.Sprintf("The CPU %s %s is more precise than 1m, and is rounded to %s", r.name, cpu.String(), canonical.String()),
+						"CPU is allocated in millicores. Use the m suffix, for example 500m instead of 0.5, to make the intended amount of CPU clear.",
+					)
+				}
+			}
+
+			if memory, ok := r.list[corev1.ResourceMemory]; ok {
+				canonical := resource.NewQuantity(memory.Value(), memory.Format)
+				if memory.Cmp(*canonical) != 0 {
+					score.Grade = scorecard.GradeWarning
+					score.AddComment(container.Name,
+						fmt.Sprintf("The memory %s %s is a fraction of a byte, and is rounded to %s", r.name, memory.String(), canonical.String()),
+						"Memory is specified in bytes. The m suffix means milli, use Mi or M for mega(bi)bytes.",
+					)
+				}
+
+				switch memory.Format {
+				case resource.BinarySI:
+					binaryMemory = append(binaryMemory, fmt.Sprintf("%s %s.memory %s", container.Name, r.name, memory.String()))
+				default:
+					decimalMemory = append(decimalMemory, fmt.Sprintf("%s %s.memory %s", container.Name, r.name, memory.String()))
+				}
+			}
+		}
+	}
+
+	if len(decimalMemory) > 0 && len(binaryMemory) > 0 {
+		score.Grade = scorecard.GradeWarning
+		score.AddComment("", "Memory quantities mix decimal and binary units",
+			fmt.Sprintf("Decimal units (%s) and binary units (%s) are used in the same pod. 1G is 1000000000 bytes while 1Gi is 1073741824 bytes, use the same kind of unit everywhere to make the quantities easy to compare.",
+				strings.Join(decimalMemory, ", "), strings.Join(binaryMemory, ", ")),
+		)
+	}
+
+	return
+}
+
+// podGuaranteedQoS checks that pods that are expected to have the Guaranteed QoS class fulfills the requirements.
+// All containers must have CPU and memory limits set, and requests that are either unset or equal to the limits.
+func podGuaranteedQoS(meta metav1.ObjectMeta, podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	if qos, _ := internal.Annotation(meta, qosAnnotation); qos != qosGuaranteed {
+		return
+	}
+
+	pod := podTemplate.Spec
+
+	allContainers := pod.InitContainers
+	allContainers = append(allContainers, pod.Containers...)
+
+	const description = "The pod is annotated with kube-score/qos: guaranteed, but will get the Burstable or BestEffort QoS class. " +
+		"Set resources.limits and resources.requests to the same values for both CPU and memory in all containers."
+
+	for _, container := range allContainers {
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			limit, hasLimit := container.Resources.Limits[name]
+			request, hasRequest := container.Resources.Requests[name]
+
+			if !hasLimit || limit.IsZero() {
+				score.Grade = scorecard.GradeCritical
+				score.AddComment(container.Name, fmt.Sprintf("The %s limit is not set", name), description)
+				continue
+			}
+
+			// Requests default to the limits if they are not set
+			if hasRequest && !request.Equal(limit) {
+				score.Grade = scorecard.GradeCritical
+				score.AddComment(container.Name, fmt.Sprintf("The %s request (%s) does not match the limit (%s)", name, request.String(), limit.String()), description)
+			}
+		}
+	}
+
+	return
+}
+
+// daemonSetResourceFootprint checks that no container in a DaemonSet requests more CPU or memory than the thresholds.
+// The thresholds can be changed per DaemonSet with annotations on the DaemonSet.
+func daemonSetResourceFootprint(daemonSet appsv1.DaemonSet) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	thresholds := []struct {
+		name       corev1.ResourceName
+		annotation string
+		max        resource.Quantity
+	}{
+		{corev1.ResourceCPU, daemonSetMaxCPUAnnotation, DaemonSetMaxCPURequest},
+		{corev1.ResourceMemory, daemonSetMaxMemoryAnnotation, DaemonSetMaxMemoryRequest},
+	}
+
+	for i, t := range thresholds {
+		thresholds[i].max = internal.QuantityAnnotation(&score, daemonSet.ObjectMeta, t.annotation, t.max)
+	}
+
+	allContainers := daemonSet.Spec.Template.Spec.InitContainers
+	allContainers = append(allContainers, daemonSet.Spec.Template.Spec.Containers...)
+
+	for _, container := range allContainers {
+		for _, t := range thresholds {
+			request, ok := container.Resources.Requests[t.name]
+			if !ok || request.Cmp(t.max) <= 0 {
+				continue
+			}
+
+			score.Grade = scorecard.GradeWarning
+			score.AddComment(container.Name,
+				fmt.Sprintf("The %s request %s is higher than %s", t.name, request.String(), t.max.String()),
+				fmt.Sprintf("DaemonSets run a pod on every node, so the requests are reserved on all nodes in the cluster. Keep per-node agents lean, or set the %s annotation on the DaemonSet to allow a higher request.", t.annotation),
+			)
+		}
+	}
+
+	return
+}
+
+// initContainerResources checks that init containers request CPU and memory if the regular containers do.
+// The scheduler reserves the largest of the highest request of any init container and the sum of the requests of all
+// regular containers, so an init container without requests can run with fewer resources than it needs.
+func initContainerResources(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	var requestedResources []corev1.ResourceName
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		for _, container := range podTemplate.Spec.Containers {
+			if request, ok := container.Resources.Requests[name]; ok && !request.IsZero() {
+				requestedResources = append(requestedResources, name)
+				break
+			}
+		}
+	}
+
+	for _, container := range podTemplate.Spec.InitContainers {
+		var missing []string
+		for _, name := range requestedResources {
+			if request, ok := container.Resources.Requests[name]; !ok || request.IsZero() {
+				missing = append(missing, string(name))
+			}
+		}
+
+		if len(missing) == 0 {
+			continue
+		}
+
+		score.Grade = scorecard.GradeWarning
+		score.AddComment(container.Name,
+			fmt.Sprintf("Init container has no %s request", strings.Join(missing, " or ")),
+			"The scheduler reserves the highest request of any init container, or the sum of the requests of the regular containers if that is larger. "+
+				"An init container without requests is not accounted for, and can run with fewer resources than it needs, or get its request from a LimitRange that over-provisions the pod. "+
+				fmt.Sprintf("Set resources.requests.%s", strings.Join(missing, " and resources.requests.")),
+		)
+	}
+
+	return
+}
+
+// containerPortNaming checks that all ports of containers with more than one port have a name
+func containerPortNaming(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	for _, container := range podTemplate.Spec.Containers {
+		if len(container.Ports) < 2 {
+			continue
+		}
+
+		var unnamed []string
+		for _, port := range container.Ports {
+			if port.Name == "" {
+				unnamed = append(unnamed, fmt.Sprintf("%d", port.ContainerPort))
+			}
+		}
+		if len(unnamed) == 0 {
+			continue
+		}
+
+		score.Grade = scorecard.GradeWarning
+		score.AddComment(container.Name,
+			fmt.Sprintf("The ports %s have no name", strings.Join(unnamed, ", ")),
+			"The container declares multiple ports. Named ports can be referenced by name from Services and probes, and make it clear what each port is used for. Set a name on all ports.",
+		)
+	}
+
+	return
+}
+
+// podNativeSidecar returns a function that checks that no regular container looks like a sidecar. Sidecars should be
+// declared as init containers with restartPolicy: Always, so that they are started before, and stopped after, the
+// regular containers. Native sidecars are enabled by default since Kubernetes v1.29.
+func podNativeSidecar(kubernetesVersion config.Semver) func(metav1.ObjectMeta, corev1.PodTemplateSpec, metav1.TypeMeta) scorecard.TestScore {
+	return func(meta metav1.ObjectMeta, podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+		if kubernetesVersion.LessThan(nativeSidecarsEnabledSince) {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", fmt.Sprintf("Skipped because native sidecars are not enabled by default before Kubernetes %s", nativeSidecarsEnabledSince), "")
+			return
+		}
+
+		score.Grade = scorecard.GradeAllOK
+
+		annotatedSidecars := make(map[string]struct{})
+		sidecars, _ := internal.Annotation(meta, sidecarsAnnotation)
+		for _, name := range strings.Split(sidecars, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				annotatedSidecars[name] = struct{}{}
+			}
+		}
+
+		for _, container := range podTemplate.Spec.Containers {
+			_, annotated := annotatedSidecars[container.Name]
+			if !annotated && !strings.HasSuffix(container.Name, "-sidecar") {
+				continue
+			}
+
+			score.Grade = scorecard.GradeWarning
+			score.AddComment(container.Name, "The sidecar is not declared as a native sidecar",
+				"Regular containers are started and stopped in no particular order, so the sidecar can stop before the main container has finished shutting down, or start after it. "+
+					"Move the container to initContainers and set restartPolicy: Always, to start it before and stop it after the regular containers.",
+			)
+		}
+
+		return
+	}
+}
+
+// containerShellWrappedEntrypoint checks if the command of a container runs the process through "sh -c" without exec.
+// The shell is then running as PID 1 and doesn't forward SIGTERM to the process, which prevents graceful shutdown.
+// This is a heuristic, only the command and args in the pod are checked, and not the entrypoint of the image.
+func containerShellWrappedEntrypoint(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	for _, container := range podTemplate.Spec.Containers {
+		if len(container.Command) == 0 {
+			continue
+		}
+
+		argv := append(append([]string{}, container.Command...), container.Args...)
+		script, ok := shellScript(argv)
+		if !ok || shellExecPattern.MatchString(script) {
+			continue
+		}
+
+		score.Grade = scorecard.GradeWarning
+		score.AddComment(container.Name,
+			"The process is started by a shell without exec",
+			fmt.Sprintf("The command %q runs the process as a child of the shell, which does not forward SIGTERM to it, and the process can't shut down gracefully. "+
+				"Use the exec form of the command without a shell, or prefix the last command in the script with exec.", strings.Join(argv, " ")),
+		)
+	}
+
+	return
+}
+
+// shellScript returns the script if argv runs a shell with -c, such as ["/bin/sh", "-c", "script"] or ["bash", "-ec", "script"]
+func shellScript(argv []string) (string, bool) {
+	if len(argv) < 3 {
+		return "", false
+	}
+	if _, ok := Shells[path.Base(argv[0])]; !ok {
+		return "", false
+	}
+
+	for i, arg := range argv[1 : len(argv)-1] {
+		if !strings.HasPrefix(arg, "-") || strings.HasPrefix(arg, "--") {
+			return "", false
+		}
+		if strings.Contains(arg, "c") {
+			return argv[i+2], true
+		}
+	}
+	return "", false
+}
+
+// containerPreStopCommandSanity checks that the exec preStop hooks of the containers have a command, and that the command
+// doesn't obviously fail. The image can't be inspected, so the check is conservative: only images with distroless in
+// their name, and without a debug tag, are assumed to lack a shell and the other DistrolessBinaries.
+func containerPreStopCommandSanity(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	for _, container := range podTemplate.Spec.Containers {
+		if container.Lifecycle == nil || container.Lifecycle.PreStop == nil || container.Lifecycle.PreStop.Exec == nil {
+			continue
+		}
+
+		command := container.Lifecycle.PreStop.Exec.Command
+		if len(command) == 0 || strings.TrimSpace(command[0]) == "" {
+			score.Grade = scorecard.GradeWarning
+			score.AddComment(container.Name, "The preStop hook has an empty command",
+				"The preStop exec hook fails without running anything, and the container is stopped right away. Set lifecycle.preStop.exec.command, or remove the hook.")
+			continue
+		}
+
+		binary := path.Base(command[0])
+		_, isShell := Shells[binary]
+		_, isDistrolessBinary := DistrolessBinaries[binary]
+		if (isShell || isDistrolessBinary) && isDistrolessImage(container.Image) {
+			score.Grade = scorecard.GradeWarning
+			score.AddComment(container.Name, fmt.Sprintf("The preStop hook runs %s, which is likely missing in the distroless image", binary),
+				fmt.Sprintf("The image %s looks like a distroless image, based on its name, and distroless images don't have a shell or tools like %s. "+
+					"The preStop hook fails, and the container is stopped right away. Run a binary that exists in the image, such as the application itself, "+
+					"or use a debug variant of the image.", container.Image, binary))
+		}
+	}
+
+	return
+}
+
+// isDistrolessImage returns true if the name of the image contains "distroless", and the tag is not a debug tag, which
+// has a busybox shell
+func isDistrolessImage(image string) bool {
+	name, tag := image, ""
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, tag = name[:i], name[i+1:]
+	}
+	return strings.Contains(name, "distroless") && !strings.Contains(tag, "debug")
+}
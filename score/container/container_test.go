@@ -5,9 +5,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/zegl/kube-score/config"
 	"github.com/zegl/kube-score/scorecard"
 )
 
@@ -557,3 +559,791 @@ func TestFailInitContainerMemoryRequestsEqualLimits(t *testing.T) {
 	assert.Equal(t, "Memory requests does not match limits", s.Comments[0].Summary)
 	assert.Equal(t, "Having equal requests and limits is recommended to avoid resource DDOS of the node during spikes. Set resources.requests.memory == resources.limits.memory", s.Comments[0].Description)
 }
+
+func TestContainerEnvPlaintextSecret(t *testing.T) {
+	t.Parallel()
+	s := containerEnvPlaintextSecret(
+		corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "foo",
+						Env: []corev1.EnvVar{
+							{Name: "LOG_LEVEL", Value: "debug"},
+							{Name: "DB_PASSWORD", Value: "hunter2"},
+							{Name: "API_TOKEN", ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+									Key:                  "token",
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+		metav1.TypeMeta{})
+
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 1)
+	assert.Equal(t, "foo", s.Comments[0].Path)
+	assert.Equal(t, "The environment variable DB_PASSWORD looks like a secret, and is set in plaintext", s.Comments[0].Summary)
+}
+
+func TestLooksLikeSecret(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"DB_PASSWORD", "API_KEY", "api_key", "APIKEY", "AWS_SECRET_ACCESS_KEY", "GITHUB_TOKEN", "SSH_KEYS", "PASSWORD", "db-passwd"} {
+		assert.True(t, looksLikeSecret(name), name)
+	}
+	for _, name := range []string{"MONKEY", "KEYCLOAK_URL", "KEYBOARD_LAYOUT", "LOG_LEVEL", "TOKENIZER_MODEL", "SECRETARY_NAME"} {
+		assert.False(t, looksLikeSecret(name), name)
+	}
+}
+
+func TestContainerEnvPlaintextSecretFromSecret(t *testing.T) {
+	t.Parallel()
+	s := containerEnvPlaintextSecret(
+		corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "foo",
+						Env: []corev1.EnvVar{
+							{Name: "DB_PASSWORD", ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "db"},
+									Key:                  "password",
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+		metav1.TypeMeta{})
+
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+}
+
+func TestContainerExtendedResourceRequestEqualsLimit(t *testing.T) {
+	t.Parallel()
+
+	podWithResources := func(requests, limits corev1.ResourceList) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "foo",
+						Resources: corev1.ResourceRequirements{
+							Requests: requests,
+							Limits:   limits,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	// Equal
+	s := containerExtendedResourceRequestEqualsLimit(podWithResources(
+		corev1.ResourceList{"cpu": resource.MustParse("1"), "nvidia.com/gpu": resource.MustParse("1")},
+		corev1.ResourceList{"cpu": resource.MustParse("2"), "nvidia.com/gpu": resource.MustParse("1")},
+	), metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+
+	// Not equal
+	s = containerExtendedResourceRequestEqualsLimit(podWithResources(
+		corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+		corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("2")},
+	), metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeCritical, s.Grade)
+	assert.Len(t, s.Comments, 1)
+	assert.Equal(t, "The extended resource nvidia.com/gpu request does not match the limit", s.Comments[0].Summary)
+	assert.Contains(t, s.Comments[0].Description, "the request is 1 and the limit is 2")
+
+	// Only request
+	s = containerExtendedResourceRequestEqualsLimit(podWithResources(
+		corev1.ResourceList{"example.com/foo": resource.MustParse("1")},
+		nil,
+	), metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeCritical, s.Grade)
+	assert.Equal(t, "The extended resource example.com/foo has no limit set", s.Comments[0].Summary)
+
+	// Only limit
+	s = containerExtendedResourceRequestEqualsLimit(podWithResources(
+		nil,
+		corev1.ResourceList{"example.com/foo": resource.MustParse("1")},
+	), metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeCritical, s.Grade)
+	assert.Equal(t, "The extended resource example.com/foo has no request set", s.Comments[0].Summary)
+}
+
+func TestPodDuplicateContainerNames(t *testing.T) {
+	t.Parallel()
+	s := podDuplicateContainerNames(
+		corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "foo"}},
+				Containers:     []corev1.Container{{Name: "foo"}, {Name: "bar"}},
+				EphemeralContainers: []corev1.EphemeralContainer{
+					{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "bar"}},
+				},
+			},
+		},
+		metav1.TypeMeta{})
+
+	assert.Equal(t, scorecard.GradeCritical, s.Grade)
+	assert.Len(t, s.Comments, 2)
+	assert.Equal(t, "foo", s.Comments[0].Path)
+	assert.Equal(t, "bar", s.Comments[1].Path)
+}
+
+func TestPodUniqueContainerNames(t *testing.T) {
+	t.Parallel()
+	s := podDuplicateContainerNames(
+		corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "init"}},
+				Containers:     []corev1.Container{{Name: "foo"}, {Name: "bar"}},
+			},
+		},
+		metav1.TypeMeta{})
+
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+}
+
+func TestContainerVolumeMountExists(t *testing.T) {
+	t.Parallel()
+	s := containerVolumeMountExists(nil)(
+		corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{{Name: "data"}},
+				InitContainers: []corev1.Container{
+					{Name: "init", VolumeMounts: []corev1.VolumeMount{{Name: "data"}}},
+				},
+				Containers: []corev1.Container{
+					{Name: "foo", VolumeMounts: []corev1.VolumeMount{{Name: "data"}, {Name: "dta"}}},
+				},
+				EphemeralContainers: []corev1.EphemeralContainer{
+					{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug", VolumeMounts: []corev1.VolumeMount{{Name: "cache"}}}},
+				},
+			},
+		},
+		metav1.TypeMeta{})
+
+	assert.Equal(t, scorecard.GradeCritical, s.Grade)
+	assert.Len(t, s.Comments, 2)
+	assert.Equal(t, "foo", s.Comments[0].Path)
+	assert.Equal(t, "The volumeMount dta does not match any volume in the pod", s.Comments[0].Summary)
+	assert.Equal(t, "debug", s.Comments[1].Path)
+}
+
+func TestContainerVolumeMountExistsOK(t *testing.T) {
+	t.Parallel()
+	s := containerVolumeMountExists(nil)(
+		corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{{Name: "data"}},
+				Containers: []corev1.Container{
+					{Name: "foo", VolumeMounts: []corev1.VolumeMount{{Name: "data"}}},
+				},
+			},
+		},
+		metav1.TypeMeta{})
+
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+}
+
+func TestPodEmptyDirSizeLimit(t *testing.T) {
+	t.Parallel()
+	limit := resource.MustParse("1Gi")
+
+	s := podEmptyDirSizeLimit(
+		corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{
+					{Name: "limited", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: &limit}}},
+					{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+					{Name: "tmp", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+				},
+			},
+		},
+		metav1.TypeMeta{})
+
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 1)
+	assert.Contains(t, s.Comments[0].Description, "cache, tmp")
+}
+
+func TestPodEmptyDirSizeLimitMemory(t *testing.T) {
+	t.Parallel()
+	s := podEmptyDirSizeLimit(
+		corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{
+					{Name: "shm", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}}},
+				},
+			},
+		},
+		metav1.TypeMeta{})
+
+	assert.Equal(t, scorecard.GradeCritical, s.Grade)
+	assert.Len(t, s.Comments, 1)
+	assert.Contains(t, s.Comments[0].Description, "shm")
+}
+
+func TestPodEmptyDirSizeLimitOK(t *testing.T) {
+	t.Parallel()
+	limit := resource.MustParse("64Mi")
+
+	s := podEmptyDirSizeLimit(
+		corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{
+					{Name: "shm", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory, SizeLimit: &limit}}},
+					{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
+				},
+			},
+		},
+		metav1.TypeMeta{})
+
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+}
+
+func TestContainerResourceUnitStyle(t *testing.T) {
+	t.Parallel()
+	s := containerResourceUnitStyle(
+		corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "foo",
+						Resources: corev1.ResourceRequirements{
+							Requests: map[corev1.ResourceName]resource.Quantity{
+								"cpu":    resource.MustParse("0.0005"),
+								"memory": resource.MustParse("500M"),
+							},
+							Limits: map[corev1.ResourceName]resource.Quantity{
+								"cpu":    resource.MustParse("0.5"),
+								"memory": resource.MustParse("512Mi"),
+							},
+						},
+					},
+					{
+						Name: "bar",
+						Resources: corev1.ResourceRequirements{
+							Requests: map[corev1.ResourceName]resource.Quantity{
+								"memory": resource.MustParse("500m"),
+							},
+						},
+					},
+				},
+			},
+		},
+		metav1.TypeMeta{})
+
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 3)
+	assert.Equal(t, "foo", s.Comments[0].Path)
+	assert.Equal(t, "The CPU requests 500u is more precise than 1m, and is rounded to 1m", s.Comments[0].Summary)
+	assert.Equal(t, "bar", s.Comments[1].Path)
+	assert.Equal(t, "The memory requests 500m is a fraction of a byte, and is rounded to 1", s.Comments[1].Summary)
+	assert.Equal(t, "Memory quantities mix decimal and binary units", s.Comments[2].Summary)
+	assert.Contains(t, s.Comments[2].Description, "foo requests.memory 500M, bar requests.memory 500m")
+	assert.Contains(t, s.Comments[2].Description, "foo limits.memory 512Mi")
+}
+
+func TestContainerResourceUnitStyleOK(t *testing.T) {
+	t.Parallel()
+	s := containerResourceUnitStyle(
+		corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "foo",
+						Resources: corev1.ResourceRequirements{
+							Requests: map[corev1.ResourceName]resource.Quantity{
+								"cpu":    resource.MustParse("0.5"),
+								"memory": resource.MustParse("256Mi"),
+							},
+							Limits: map[corev1.ResourceName]resource.Quantity{
+								"cpu":    resource.MustParse("1"),
+								"memory": resource.MustParse("1Gi"),
+							},
+						},
+					},
+				},
+			},
+		},
+		metav1.TypeMeta{})
+
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+}
+
+func TestPodGuaranteedQoS(t *testing.T) {
+	t.Parallel()
+	s := podGuaranteedQoS(
+		metav1.ObjectMeta{
+			Annotations: map[string]string{"kube-score/qos": "guaranteed"},
+		},
+		corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "foo",
+						Resources: corev1.ResourceRequirements{
+							Requests: map[corev1.ResourceName]resource.Quantity{
+								"cpu":    resource.MustParse("500m"),
+								"memory": resource.MustParse("256Mi"),
+							},
+							Limits: map[corev1.ResourceName]resource.Quantity{
+								"cpu":    resource.MustParse("1"),
+								"memory": resource.MustParse("256Mi"),
+							},
+						},
+					},
+					{
+						Name: "bar",
+						Resources: corev1.ResourceRequirements{
+							Limits: map[corev1.ResourceName]resource.Quantity{
+								"cpu": resource.MustParse("1"),
+							},
+						},
+					},
+				},
+			},
+		},
+		metav1.TypeMeta{})
+
+	assert.Equal(t, scorecard.GradeCritical, s.Grade)
+	assert.Len(t, s.Comments, 2)
+	assert.Equal(t, "foo", s.Comments[0].Path)
+	assert.Equal(t, "The cpu request (500m) does not match the limit (1)", s.Comments[0].Summary)
+	assert.Equal(t, "bar", s.Comments[1].Path)
+	assert.Equal(t, "The memory limit is not set", s.Comments[1].Summary)
+}
+
+func TestPodGuaranteedQoSOK(t *testing.T) {
+	t.Parallel()
+
+	spec := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name: "foo",
+				Resources: corev1.ResourceRequirements{
+					Limits: map[corev1.ResourceName]resource.Quantity{
+						"cpu":    resource.MustParse("1"),
+						"memory": resource.MustParse("256Mi"),
+					},
+				},
+			},
+		},
+	}
+
+	s := podGuaranteedQoS(
+		metav1.ObjectMeta{Annotations: map[string]string{"kube-score/qos": "guaranteed"}},
+		corev1.PodTemplateSpec{Spec: spec},
+		metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+
+	// Objects without the annotation are not checked, the annotation on the pod template is not used
+	s = podGuaranteedQoS(metav1.ObjectMeta{}, corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"kube-score/qos": "guaranteed"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "foo"}}},
+	}, metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+}
+
+func TestDaemonSetResourceFootprint(t *testing.T) {
+	t.Parallel()
+
+	daemonSet := func(annotations map[string]string, cpu, memory string) appsv1.DaemonSet {
+		return appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+			Spec: appsv1.DaemonSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "agent",
+								Resources: corev1.ResourceRequirements{
+									Requests: map[corev1.ResourceName]resource.Quantity{
+										"cpu":    resource.MustParse(cpu),
+										"memory": resource.MustParse(memory),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	s := daemonSetResourceFootprint(daemonSet(nil, "100m", "128Mi"))
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+
+	s = daemonSetResourceFootprint(daemonSet(nil, "1", "128Mi"))
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 1)
+	assert.Equal(t, "agent", s.Comments[0].Path)
+	assert.Equal(t, "The cpu request 1 is higher than 500m", s.Comments[0].Summary)
+
+	s = daemonSetResourceFootprint(daemonSet(map[string]string{
+		"kube-score/daemonset-max-cpu-request":    "2",
+		"kube-score/daemonset-max-memory-request": "1Gi",
+	}, "1", "1Gi"))
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+}
+
+func TestInitContainerResources(t *testing.T) {
+	t.Parallel()
+
+	requests := func(cpu, memory string) corev1.ResourceRequirements {
+		r := corev1.ResourceRequirements{Requests: map[corev1.ResourceName]resource.Quantity{}}
+		if cpu != "" {
+			r.Requests["cpu"] = resource.MustParse(cpu)
+		}
+		if memory != "" {
+			r.Requests["memory"] = resource.MustParse(memory)
+		}
+		return r
+	}
+
+	podTemplate := func(initContainers ...corev1.Container) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				InitContainers: initContainers,
+				Containers: []corev1.Container{
+					{Name: "app", Resources: requests("100m", "128Mi")},
+				},
+			},
+		}
+	}
+
+	s := initContainerResources(podTemplate(), metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+
+	s = initContainerResources(podTemplate(corev1.Container{Name: "init", Resources: requests("50m", "64Mi")}), metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+
+	s = initContainerResources(podTemplate(
+		corev1.Container{Name: "migrate", Resources: requests("", "")},
+		corev1.Container{Name: "wait", Resources: requests("10m", "")},
+	), metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 2)
+	assert.Equal(t, "migrate", s.Comments[0].Path)
+	assert.Equal(t, "Init container has no cpu or memory request", s.Comments[0].Summary)
+	assert.Equal(t, "wait", s.Comments[1].Path)
+	assert.Equal(t, "Init container has no memory request", s.Comments[1].Summary)
+
+	// Nothing is required if the regular containers don't have any requests either
+	s = initContainerResources(corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init"}},
+			Containers:     []corev1.Container{{Name: "app"}},
+		},
+	}, metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+}
+
+func TestContainerShellWrappedEntrypoint(t *testing.T) {
+	t.Parallel()
+
+	podTemplate := func(command, args []string) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "app", Command: command, Args: args}},
+			},
+		}
+	}
+
+	cases := []struct {
+		command  []string
+		args     []string
+		expected scorecard.Grade
+	}{
+		{[]string{"myapp", "--port", "8080"}, nil, scorecard.GradeAllOK},
+		{nil, []string{"sh", "-c", "myapp"}, scorecard.GradeAllOK},
+		{[]string{"sh", "-c", "myapp"}, nil, scorecard.GradeWarning},
+		{[]string{"/bin/bash", "-ec", "setup && myapp"}, nil, scorecard.GradeWarning},
+		{[]string{"sh"}, []string{"-c", "myapp"}, scorecard.GradeWarning},
+		{[]string{"sh", "-c", "exec myapp"}, nil, scorecard.GradeAllOK},
+		{[]string{"sh", "-c", "setup; exec myapp --port 8080"}, nil, scorecard.GradeAllOK},
+		{[]string{"sh", "script.sh"}, nil, scorecard.GradeAllOK},
+		{[]string{"python", "-c", "print(1)"}, nil, scorecard.GradeAllOK},
+	}
+
+	for _, tc := range cases {
+		s := containerShellWrappedEntrypoint(podTemplate(tc.command, tc.args), metav1.TypeMeta{})
+		assert.Equal(t, tc.expected, s.Grade, "%v %v", tc.command, tc.args)
+	}
+
+	s := containerShellWrappedEntrypoint(podTemplate([]string{"sh", "-c", "myapp"}, nil), metav1.TypeMeta{})
+	assert.Len(t, s.Comments, 1)
+	assert.Equal(t, "app", s.Comments[0].Path)
+	assert.Contains(t, s.Comments[0].Description, `The command "sh -c myapp" runs the process`)
+}
+
+func TestContainerResourcesThresholds(t *testing.T) {
+	t.Parallel()
+
+	podTemplate := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "sidecar",
+					Resources: corev1.ResourceRequirements{
+						Requests: map[corev1.ResourceName]resource.Quantity{
+							"cpu":    resource.MustParse("5m"),
+							"memory": resource.MustParse("8Mi"),
+						},
+						Limits: map[corev1.ResourceName]resource.Quantity{
+							"cpu":    resource.MustParse("100m"),
+							"memory": resource.MustParse("16Mi"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// The default thresholds don't report anything
+	s := containerResources(config.Configuration{})(podTemplate, metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+
+	s = containerResources(config.Configuration{
+		MinContainerCPURequest:    resource.MustParse("10m"),
+		MinContainerMemoryRequest: resource.MustParse("8Mi"),
+	})(podTemplate, metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 1)
+	assert.Equal(t, "sidecar", s.Comments[0].Path)
+	assert.Equal(t, "The cpu request 5m is lower than 10m", s.Comments[0].Summary)
+
+	s = containerResources(config.Configuration{
+		MaxContainerLimitRequestRatio: 4,
+	})(podTemplate, metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 1)
+	assert.Equal(t, "The cpu limit is 20.0 times the request", s.Comments[0].Summary)
+
+	s = containerResources(config.Configuration{
+		MinContainerCPURequest:        resource.MustParse("5m"),
+		MaxContainerLimitRequestRatio: 20,
+	})(podTemplate, metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+}
+
+func TestContainerResourceRequestLimitPairing(t *testing.T) {
+	t.Parallel()
+
+	resources := func(requests, limits map[corev1.ResourceName]string) corev1.ResourceRequirements {
+		r := corev1.ResourceRequirements{Requests: corev1.ResourceList{}, Limits: corev1.ResourceList{}}
+		for name, value := range requests {
+			r.Requests[name] = resource.MustParse(value)
+		}
+		for name, value := range limits {
+			r.Limits[name] = resource.MustParse(value)
+		}
+		return r
+	}
+
+	s := containerResourceRequestLimitPairing(corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "none"},
+				{Name: "both", Resources: resources(
+					map[corev1.ResourceName]string{"cpu": "100m", "memory": "128Mi"},
+					map[corev1.ResourceName]string{"cpu": "200m", "memory": "128Mi"},
+				)},
+			},
+		},
+	}, metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+
+	s = containerResourceRequestLimitPairing(corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{Name: "init", Resources: resources(nil, map[corev1.ResourceName]string{"memory": "64Mi"})},
+			},
+			Containers: []corev1.Container{
+				{Name: "app", Resources: resources(
+					map[corev1.ResourceName]string{"cpu": "100m", "memory": "128Mi"},
+					map[corev1.ResourceName]string{"memory": "128Mi"},
+				)},
+			},
+		},
+	}, metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 2)
+	assert.Equal(t, "init", s.Comments[0].Path)
+	assert.Equal(t, "memory limit is set without a request", s.Comments[0].Summary)
+	assert.Equal(t, "app", s.Comments[1].Path)
+	assert.Equal(t, "cpu request is set without a limit", s.Comments[1].Summary)
+}
+
+func TestContainerPortNaming(t *testing.T) {
+	t.Parallel()
+
+	podTemplate := func(ports ...corev1.ContainerPort) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "app", Ports: ports}},
+			},
+		}
+	}
+
+	s := containerPortNaming(podTemplate(), metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+
+	s = containerPortNaming(podTemplate(corev1.ContainerPort{ContainerPort: 8080}), metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+
+	s = containerPortNaming(podTemplate(
+		corev1.ContainerPort{Name: "http", ContainerPort: 8080},
+		corev1.ContainerPort{Name: "metrics", ContainerPort: 9090},
+	), metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+
+	s = containerPortNaming(podTemplate(
+		corev1.ContainerPort{ContainerPort: 8080},
+		corev1.ContainerPort{Name: "metrics", ContainerPort: 9090},
+		corev1.ContainerPort{ContainerPort: 9443},
+	), metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 1)
+	assert.Equal(t, "app", s.Comments[0].Path)
+	assert.Equal(t, "The ports 8080, 9443 have no name", s.Comments[0].Summary)
+}
+
+func TestPodNativeSidecar(t *testing.T) {
+	t.Parallel()
+
+	meta := metav1.ObjectMeta{
+		Annotations: map[string]string{"kube-score/sidecars": "envoy, log-shipper"},
+	}
+	podTemplate := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "native-sidecar"}},
+			Containers: []corev1.Container{
+				{Name: "app"},
+				{Name: "envoy"},
+				{Name: "metrics-sidecar"},
+			},
+		},
+	}
+
+	s := podNativeSidecar(config.Semver{Major: 1, Minor: 28})(meta, podTemplate, metav1.TypeMeta{})
+	assert.True(t, s.Skipped)
+
+	s = podNativeSidecar(config.Semver{Major: 1, Minor: 29})(meta, podTemplate, metav1.TypeMeta{})
+	assert.False(t, s.Skipped)
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 2)
+	assert.Equal(t, "envoy", s.Comments[0].Path)
+	assert.Equal(t, "metrics-sidecar", s.Comments[1].Path)
+	assert.Equal(t, "The sidecar is not declared as a native sidecar", s.Comments[1].Summary)
+
+	s = podNativeSidecar(config.Semver{Major: 1, Minor: 30})(metav1.ObjectMeta{}, corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "log-sidecar"}},
+			Containers:     []corev1.Container{{Name: "app"}},
+		},
+	}, metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+}
+
+func TestContainerPreStopCommandSanity(t *testing.T) {
+	t.Parallel()
+
+	podTemplate := func(image string, command []string) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:      "app",
+					Image:     image,
+					Lifecycle: &corev1.Lifecycle{PreStop: &corev1.Handler{Exec: &corev1.ExecAction{Command: command}}},
+				}},
+			},
+		}
+	}
+
+	cases := []struct {
+		image    string
+		command  []string
+		expected scorecard.Grade
+	}{
+		{"foo/bar:1.0", []string{"sleep", "5"}, scorecard.GradeAllOK},
+		{"foo/bar:1.0", nil, scorecard.GradeWarning},
+		{"foo/bar:1.0", []string{" "}, scorecard.GradeWarning},
+		{"gcr.io/distroless/static:nonroot", []string{"/bin/sleep", "5"}, scorecard.GradeWarning},
+		{"gcr.io/distroless/base", []string{"sh", "-c", "sleep 5"}, scorecard.GradeWarning},
+		{"gcr.io/distroless/base@sha256:abc", []string{"sleep", "5"}, scorecard.GradeWarning},
+		{"gcr.io/distroless/base:debug", []string{"sleep", "5"}, scorecard.GradeAllOK},
+		{"gcr.io/distroless/static:nonroot", []string{"/app", "shutdown"}, scorecard.GradeAllOK},
+		{"registry:5000/distroless-app:1.0", []string{"sleep", "5"}, scorecard.GradeWarning},
+		{"registry:5000/app:distroless", []string{"sleep", "5"}, scorecard.GradeAllOK},
+	}
+
+	for _, tc := range cases {
+		s := containerPreStopCommandSanity(podTemplate(tc.image, tc.command), metav1.TypeMeta{})
+		assert.Equal(t, tc.expected, s.Grade, "%s %v", tc.image, tc.command)
+	}
+
+	s := containerPreStopCommandSanity(podTemplate("gcr.io/distroless/static", []string{"/bin/sleep", "5"}), metav1.TypeMeta{})
+	assert.Len(t, s.Comments, 1)
+	assert.Equal(t, "app", s.Comments[0].Path)
+	assert.Equal(t, "The preStop hook runs sleep, which is likely missing in the distroless image", s.Comments[0].Summary)
+
+	// Containers without an exec preStop hook are not checked
+	s = containerPreStopCommandSanity(corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}}, metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+}
+
+func TestContainerMemoryLimitRequired(t *testing.T) {
+	t.Parallel()
+
+	withLimit := corev1.ResourceRequirements{Limits: corev1.ResourceList{"memory": resource.MustParse("128Mi")}}
+	withoutLimit := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{"memory": resource.MustParse("128Mi")},
+		Limits:   corev1.ResourceList{"cpu": resource.MustParse("1")},
+	}
+
+	s := containerMemoryLimitRequired(corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init", Resources: withLimit}},
+			Containers:     []corev1.Container{{Name: "app", Resources: withLimit}},
+		},
+	}, metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+
+	s = containerMemoryLimitRequired(corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init"}},
+			Containers: []corev1.Container{
+				{Name: "app", Resources: withLimit},
+				{Name: "sidecar", Resources: withoutLimit},
+			},
+		},
+	}, metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeCritical, s.Grade)
+	assert.Len(t, s.Comments, 2)
+	assert.Equal(t, "init", s.Comments[0].Path)
+	assert.Equal(t, "sidecar", s.Comments[1].Path)
+	assert.Equal(t, "Memory limit is not set", s.Comments[1].Summary)
+}
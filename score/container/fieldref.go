@@ -0,0 +1,105 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// EnvFieldPaths are the fieldPaths that can be used in valueFrom.fieldRef of environment variables, used by the
+// "Container FieldRef Valid" check. Labels and annotations are referenced with a key, as in metadata.labels['app'].
+// https://kubernetes.io/docs/concepts/workloads/pods/downward-api/#downwardapi-fieldRef
+var EnvFieldPaths = map[string]struct{}{
+	"metadata.name":           {},
+	"metadata.namespace":      {},
+	"metadata.uid":            {},
+	"spec.nodeName":           {},
+	"spec.serviceAccountName": {},
+	"status.hostIP":           {},
+	"status.hostIPs":          {},
+	"status.podIP":            {},
+	"status.podIPs":           {},
+}
+
+// EnvFieldPathSubscripts are the fields of EnvFieldPaths that are referenced with a key
+var EnvFieldPathSubscripts = []string{"metadata.labels", "metadata.annotations"}
+
+// ResourceFieldRefResources are the resources that can be used in valueFrom.resourceFieldRef of environment
+// variables, used by the "Container FieldRef Valid" check. Huge pages are referenced by their size, as in
+// limits.hugepages-2Mi.
+var ResourceFieldRefResources = map[string]struct{}{
+	"limits.cpu":                 {},
+	"limits.memory":              {},
+	"limits.ephemeral-storage":   {},
+	"requests.cpu":               {},
+	"requests.memory":            {},
+	"requests.ephemeral-storage": {},
+}
+
+// containerFieldRefValid checks that the fieldRef and resourceFieldRef of all environment variables reference a field
+// that is supported by the downward API. Pods with an unsupported field are rejected by the API server.
+func containerFieldRefValid(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	allContainers := append([]corev1.Container{}, podTemplate.Spec.InitContainers...)
+	allContainers = append(allContainers, podTemplate.Spec.Containers...)
+
+	for _, container := range allContainers {
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+
+			if ref := env.ValueFrom.FieldRef; ref != nil && !validEnvFieldPath(ref.FieldPath) {
+				score.Grade = scorecard.GradeCritical
+				score.AddComment(container.Name,
+					fmt.Sprintf("The environment variable %s references the unsupported fieldPath %q", env.Name, ref.FieldPath),
+					"The pod is rejected by the API server. Use one of the fields that are supported by the downward API, such as metadata.name, metadata.namespace or metadata.labels['<KEY>'].",
+				)
+			}
+
+			if ref := env.ValueFrom.ResourceFieldRef; ref != nil && !validResourceFieldRef(ref.Resource) {
+				score.Grade = scorecard.GradeCritical
+				score.AddComment(container.Name,
+					fmt.Sprintf("The environment variable %s references the unsupported resource %q", env.Name, ref.Resource),
+					"The pod is rejected by the API server. Use one of the resources that are supported by the downward API, such as limits.cpu, limits.memory, requests.cpu or requests.memory.",
+				)
+			}
+		}
+	}
+
+	return
+}
+
+func validEnvFieldPath(path string) bool {
+	if _, ok := EnvFieldPaths[path]; ok {
+		return true
+	}
+
+	// metadata.labels['<KEY>'] and metadata.annotations['<KEY>']
+	for _, field := range EnvFieldPathSubscripts {
+		if strings.HasPrefix(path, field+"['") && strings.HasSuffix(path, "']") && len(path) > len(field)+4 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validResourceFieldRef(resource string) bool {
+	if _, ok := ResourceFieldRefResources[resource]; ok {
+		return true
+	}
+
+	for _, prefix := range []string{"limits.hugepages-", "requests.hugepages-"} {
+		if strings.HasPrefix(resource, prefix) && len(resource) > len(prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,65 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestContainerFieldRefValid(t *testing.T) {
+	t.Parallel()
+
+	fieldRef := func(name, path string) corev1.EnvVar {
+		return corev1.EnvVar{Name: name, ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: path}}}
+	}
+	resourceFieldRef := func(name, resource string) corev1.EnvVar {
+		return corev1.EnvVar{Name: name, ValueFrom: &corev1.EnvVarSource{ResourceFieldRef: &corev1.ResourceFieldSelector{Resource: resource}}}
+	}
+
+	s := containerFieldRefValid(corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "app",
+				Env: []corev1.EnvVar{
+					{Name: "PLAIN", Value: "value"},
+					fieldRef("NAMESPACE", "metadata.namespace"),
+					fieldRef("NODE", "spec.nodeName"),
+					fieldRef("APP", "metadata.labels['app.kubernetes.io/name']"),
+					fieldRef("ANNOTATION", "metadata.annotations['foo']"),
+					resourceFieldRef("CPU", "limits.cpu"),
+					resourceFieldRef("HUGEPAGES", "requests.hugepages-2Mi"),
+				},
+			}},
+		},
+	}, metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+
+	s = containerFieldRefValid(corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{
+				Name: "init",
+				Env:  []corev1.EnvVar{fieldRef("NAMESPACE", "metadata.namspace")},
+			}},
+			Containers: []corev1.Container{{
+				Name: "app",
+				Env: []corev1.EnvVar{
+					fieldRef("LABELS", "metadata.labels"),
+					fieldRef("EMPTY", "metadata.labels['']"),
+					resourceFieldRef("GPU", "limits.nvidia.com/gpu"),
+				},
+			}},
+		},
+	}, metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeCritical, s.Grade)
+	assert.Len(t, s.Comments, 4)
+	assert.Equal(t, "init", s.Comments[0].Path)
+	assert.Equal(t, `The environment variable NAMESPACE references the unsupported fieldPath "metadata.namspace"`, s.Comments[0].Summary)
+	assert.Equal(t, `The environment variable LABELS references the unsupported fieldPath "metadata.labels"`, s.Comments[1].Summary)
+	assert.Equal(t, `The environment variable EMPTY references the unsupported fieldPath "metadata.labels['']"`, s.Comments[2].Summary)
+	assert.Equal(t, `The environment variable GPU references the unsupported resource "limits.nvidia.com/gpu"`, s.Comments[3].Summary)
+}
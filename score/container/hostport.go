@@ -0,0 +1,87 @@
+package container
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+type hostPortBinding struct {
+	container string
+	port      corev1.ContainerPort
+}
+
+// podHostPortConflict checks that no two ports in the pod bind the same hostPort, and warns about all hostPorts, as
+// only one pod that uses the port can be scheduled to each node.
+func podHostPortConflict(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	allContainers := append([]corev1.Container{}, podTemplate.Spec.InitContainers...)
+	allContainers = append(allContainers, podTemplate.Spec.Containers...)
+
+	var bindings []hostPortBinding
+	for _, container := range allContainers {
+		for _, port := range container.Ports {
+			if port.HostPort == 0 {
+				continue
+			}
+
+			conflict := false
+			for _, other := range bindings {
+				if !hostPortsOverlap(port, other.port) {
+					continue
+				}
+				conflict = true
+				score.Grade = scorecard.GradeCritical
+				score.AddComment(container.Name,
+					fmt.Sprintf("The hostPort %s is also bound by the container %s", formatHostPort(port), other.container),
+					"Only one container can bind a port on the node, so the pod can't start. Use a different hostPort in one of the containers.",
+				)
+			}
+
+			if !conflict {
+				if score.Grade > scorecard.GradeWarning {
+					score.Grade = scorecard.GradeWarning
+				}
+				score.AddComment(container.Name,
+					fmt.Sprintf("The container binds the hostPort %s", formatHostPort(port)),
+					"Only one pod that binds the port can run on each node, which limits the number of replicas to the number of nodes, and can leave pods pending during rollouts. "+
+						"Expose the port with a Service instead, unless the pod needs to be reachable on the address of the node.",
+				)
+			}
+
+			bindings = append(bindings, hostPortBinding{container.Name, port})
+		}
+	}
+
+	return
+}
+
+// hostPortsOverlap returns true if the ports bind the same hostPort and protocol, on the same hostIP or on all addresses
+func hostPortsOverlap(a, b corev1.ContainerPort) bool {
+	if a.HostPort != b.HostPort || hostPortProtocol(a) != hostPortProtocol(b) {
+		return false
+	}
+	allAddresses := func(ip string) bool {
+		return ip == "" || ip == "0.0.0.0" || ip == "::"
+	}
+	return a.HostIP == b.HostIP || allAddresses(a.HostIP) || allAddresses(b.HostIP)
+}
+
+func hostPortProtocol(port corev1.ContainerPort) corev1.Protocol {
+	if port.Protocol == "" {
+		return corev1.ProtocolTCP
+	}
+	return port.Protocol
+}
+
+func formatHostPort(port corev1.ContainerPort) string {
+	s := fmt.Sprintf("%d/%s", port.HostPort, hostPortProtocol(port))
+	if port.HostIP != "" {
+		s = port.HostIP + ":" + s
+	}
+	return s
+}
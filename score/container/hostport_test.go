@@ -0,0 +1,57 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestPodHostPortConflict(t *testing.T) {
+	t.Parallel()
+
+	s := podHostPortConflict(corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app", Ports: []corev1.ContainerPort{{ContainerPort: 8080}}},
+			},
+		},
+	}, metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+
+	s = podHostPortConflict(corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app", Ports: []corev1.ContainerPort{{ContainerPort: 53, HostPort: 53}}},
+				{Name: "udp", Ports: []corev1.ContainerPort{{ContainerPort: 53, HostPort: 53, Protocol: corev1.ProtocolUDP}}},
+				{Name: "other-ip", Ports: []corev1.ContainerPort{
+					{ContainerPort: 80, HostPort: 80, HostIP: "10.0.0.1"},
+					{ContainerPort: 80, HostPort: 80, HostIP: "10.0.0.2"},
+				}},
+			},
+		},
+	}, metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 4)
+	assert.Equal(t, "The container binds the hostPort 53/TCP", s.Comments[0].Summary)
+	assert.Equal(t, "The container binds the hostPort 53/UDP", s.Comments[1].Summary)
+	assert.Equal(t, "The container binds the hostPort 10.0.0.1:80/TCP", s.Comments[2].Summary)
+
+	s = podHostPortConflict(corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app", Ports: []corev1.ContainerPort{{ContainerPort: 8080, HostPort: 8080}}},
+				{Name: "sidecar", Ports: []corev1.ContainerPort{{ContainerPort: 9090, HostPort: 8080, HostIP: "10.0.0.1", Protocol: corev1.ProtocolTCP}}},
+			},
+		},
+	}, metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeCritical, s.Grade)
+	assert.Len(t, s.Comments, 2)
+	assert.Equal(t, "app", s.Comments[0].Path)
+	assert.Equal(t, "sidecar", s.Comments[1].Path)
+	assert.Equal(t, "The hostPort 10.0.0.1:8080/TCP is also bound by the container app", s.Comments[1].Summary)
+}
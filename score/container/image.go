@@ -0,0 +1,131 @@
+package container
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// DefaultMutableImageTags are the regular expressions of the tags that are considered to be mutable by the
+// "Container Image Mutable Tag" check, if no tags are configured. A bare major version, such as 3 or v3, is expected
+// to be moved to each new minor and patch release.
+var DefaultMutableImageTags = []string{
+	"stable", "edge", "main", "master", "develop", "dev", "nightly", "canary", "beta", "alpha", "lts", "current", "release",
+	`v?[0-9]+`,
+}
+
+// containerImageMutableTag returns a function that checks that no container uses an image with a tag that matches one
+// of the patterns, the latest tag, or no tag at all. Images that are pinned with a digest are always allowed.
+// The patterns must match the whole tag, patterns that are not valid regular expressions are ignored.
+func containerImageMutableTag(patterns []string) func(corev1.PodTemplateSpec, metav1.TypeMeta) scorecard.TestScore {
+	if len(patterns) == 0 {
+		patterns = DefaultMutableImageTags
+	}
+	var mutableTags []*regexp.Regexp
+	for _, pattern := range append([]string{"latest"}, patterns...) {
+		if r, err := regexp.Compile("^(?:" + pattern + ")$"); err == nil {
+			mutableTags = append(mutableTags, r)
+		}
+	}
+
+	return func(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+		score.Grade = scorecard.GradeAllOK
+
+		allContainers := append([]corev1.Container{}, podTemplate.Spec.InitContainers...)
+		allContainers = append(allContainers, podTemplate.Spec.Containers...)
+
+		for _, container := range allContainers {
+			name, tag, digest := splitImage(container.Image)
+			if digest != "" {
+				continue
+			}
+
+			if tag == "" {
+				score.Grade = scorecard.GradeWarning
+				score.AddComment(container.Name, fmt.Sprintf("The image %s has no tag", name),
+					"Images without a tag use the mutable latest tag, so the image that is running can change when the pod is restarted. Pin the image to a version, such as 1.4.2, or to a digest.")
+				continue
+			}
+
+			for _, r := range mutableTags {
+				if r.MatchString(tag) {
+					score.Grade = scorecard.GradeWarning
+					score.AddComment(container.Name, fmt.Sprintf("The image %s uses the mutable tag %s", name, tag),
+						"The tag can be moved to a new image at any time, so the image that is running can change when the pod is restarted. Pin the image to a version, such as 1.4.2, or to a digest.")
+					break
+				}
+			}
+		}
+
+		return
+	}
+}
+
+// dockerHubRegistries are the hosts of Docker Hub. Images without a registry host are pulled from docker.io.
+var dockerHubRegistries = map[string]struct{}{
+	"docker.io":               {},
+	"index.docker.io":         {},
+	"registry-1.docker.io":    {},
+	"registry.hub.docker.com": {},
+}
+
+// containerImplicitDockerHub checks that no container pulls its image from Docker Hub, either because the image has
+// no registry host, or because the registry is set to docker.io. Anonymous pulls from Docker Hub are rate limited.
+func containerImplicitDockerHub(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	allContainers := append([]corev1.Container{}, podTemplate.Spec.InitContainers...)
+	allContainers = append(allContainers, podTemplate.Spec.Containers...)
+
+	for _, container := range allContainers {
+		registry, explicit := imageRegistry(container.Image)
+		if _, ok := dockerHubRegistries[registry]; !ok {
+			continue
+		}
+
+		score.Grade = scorecard.GradeWarning
+		if explicit {
+			score.AddComment(container.Name, fmt.Sprintf("The image %s is pulled from Docker Hub (%s)", container.Image, registry),
+				"Pulls from Docker Hub are rate limited, and pods can fail to start with ErrImagePull when the limit is reached. Pull the image from a mirror, or from another registry.")
+		} else {
+			score.AddComment(container.Name, fmt.Sprintf("The image %s has no registry, and is implicitly pulled from Docker Hub (%s)", container.Image, registry),
+				"Pulls from Docker Hub are rate limited, and pods can fail to start with ErrImagePull when the limit is reached. Pull the image from a mirror, or from another registry, and set the registry host explicitly in the image.")
+		}
+	}
+
+	return
+}
+
+// imageRegistry returns the registry host of an image reference, and if it's set in the reference. The first part of
+// the name is a registry host if it contains a . or a :, or if it's localhost, otherwise the image is pulled from
+// docker.io, as in nginx or library/nginx.
+func imageRegistry(image string) (registry string, explicit bool) {
+	name, _, _ := splitImage(image)
+	i := strings.Index(name, "/")
+	if i < 0 {
+		return "docker.io", false
+	}
+	host := name[:i]
+	if strings.ContainsAny(host, ".:") || host == "localhost" {
+		return host, true
+	}
+	return "docker.io", false
+}
+
+// splitImage returns the name, tag and digest of an image reference. The tag and digest are empty if they are not set.
+// A port in the registry host, as in registry:5000/app, is not mistaken for a tag.
+func splitImage(image string) (name, tag, digest string) {
+	name = image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, digest = name[:i], name[i+1:]
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, tag = name[:i], name[i+1:]
+	}
+	return
+}
@@ -0,0 +1,62 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func podWithImages(images ...string) corev1.PodTemplateSpec {
+	var containers []corev1.Container
+	for _, image := range images {
+		containers = append(containers, corev1.Container{Name: "app", Image: image})
+	}
+	return corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: containers}}
+}
+
+func TestContainerImageMutableTag(t *testing.T) {
+	t.Parallel()
+
+	check := containerImageMutableTag(nil)
+
+	s := check(podWithImages("nginx:1.21.6", "registry:5000/app:v1.2.3", "nginx:stable@sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31"), metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+
+	s = check(podWithImages("nginx:stable", "registry:5000/app", "app:v3", "app:latest"), metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 4)
+	assert.Equal(t, "The image nginx uses the mutable tag stable", s.Comments[0].Summary)
+	assert.Equal(t, "The image registry:5000/app has no tag", s.Comments[1].Summary)
+	assert.Equal(t, "The image app uses the mutable tag v3", s.Comments[2].Summary)
+	assert.Equal(t, "The image app uses the mutable tag latest", s.Comments[3].Summary)
+
+	// Configured tags replace the defaults, latest is always mutable
+	check = containerImageMutableTag([]string{"release-.*"})
+	s = check(podWithImages("app:stable", "app:release-2022"), metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 1)
+	assert.Equal(t, "The image app uses the mutable tag release-2022", s.Comments[0].Summary)
+	s = check(podWithImages("app:latest"), metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+}
+
+func TestContainerImplicitDockerHub(t *testing.T) {
+	t.Parallel()
+
+	s := containerImplicitDockerHub(podWithImages("registry.example.com/nginx:1.25", "registry:5000/app:1.0", "localhost/app:1.0", "ghcr.io/org/app@sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31"), metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+
+	s = containerImplicitDockerHub(podWithImages("nginx:1.25", "bitnami/redis:7.0", "docker.io/library/nginx:1.25", "index.docker.io/app"), metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 4)
+	assert.Equal(t, "The image nginx:1.25 has no registry, and is implicitly pulled from Docker Hub (docker.io)", s.Comments[0].Summary)
+	assert.Equal(t, "The image bitnami/redis:7.0 has no registry, and is implicitly pulled from Docker Hub (docker.io)", s.Comments[1].Summary)
+	assert.Equal(t, "The image docker.io/library/nginx:1.25 is pulled from Docker Hub (docker.io)", s.Comments[2].Summary)
+	assert.Equal(t, "The image index.docker.io/app is pulled from Docker Hub (index.docker.io)", s.Comments[3].Summary)
+}
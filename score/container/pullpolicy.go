@@ -0,0 +1,90 @@
+package container
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// imagePuller is a workload that pulls an image with an imagePullPolicy
+type imagePuller struct {
+	workload string
+	policy   corev1.PullPolicy
+}
+
+// imagePullPolicyConsistency returns a function that checks that all workloads that use the same image pull it with
+// the same imagePullPolicy. Containers without an imagePullPolicy are compared with the policy that Kubernetes
+// defaults to.
+func imagePullPolicyConsistency(pods []ks.Pod, podspecers []ks.PodSpecer) func(corev1.PodTemplateSpec, metav1.TypeMeta) scorecard.TestScore {
+	pullers := make(map[string][]imagePuller)
+	addWorkload := func(typeMeta metav1.TypeMeta, objectMeta metav1.ObjectMeta, spec corev1.PodSpec) {
+		workload := fmt.Sprintf("%s %s", typeMeta.Kind, objectMeta.Name)
+		if objectMeta.Namespace != "" {
+			workload += " in " + objectMeta.Namespace
+		}
+		for _, container := range append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...) {
+			pullers[container.Image] = append(pullers[container.Image], imagePuller{workload, effectivePullPolicy(container)})
+		}
+	}
+	for _, pod := range pods {
+		p := pod.Pod()
+		addWorkload(p.TypeMeta, p.ObjectMeta, p.Spec)
+	}
+	for _, podspecer := range podspecers {
+		addWorkload(podspecer.GetTypeMeta(), podspecer.GetObjectMeta(), podspecer.GetPodTemplateSpec().Spec)
+	}
+
+	return func(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+		score.Grade = scorecard.GradeAllOK
+
+		allContainers := append([]corev1.Container{}, podTemplate.Spec.InitContainers...)
+		allContainers = append(allContainers, podTemplate.Spec.Containers...)
+
+		for _, container := range allContainers {
+			policy := effectivePullPolicy(container)
+
+			conflicts := make(map[string]struct{})
+			for _, puller := range pullers[container.Image] {
+				if puller.policy != policy {
+					conflicts[fmt.Sprintf("%s (%s)", puller.workload, puller.policy)] = struct{}{}
+				}
+			}
+			if len(conflicts) == 0 {
+				continue
+			}
+
+			var workloads []string
+			for workload := range conflicts {
+				workloads = append(workloads, workload)
+			}
+			sort.Strings(workloads)
+
+			score.Grade = scorecard.GradeWarning
+			score.AddComment(container.Name,
+				fmt.Sprintf("The image %s is pulled with imagePullPolicy %s, but with a different policy by other workloads", container.Image, policy),
+				fmt.Sprintf("The image is also used by %s. With different policies, nodes can run different versions of the same image depending on which workload pulled it first. "+
+					"Use the same imagePullPolicy for the image in all workloads.", strings.Join(workloads, ", ")),
+			)
+		}
+
+		return
+	}
+}
+
+// effectivePullPolicy returns the imagePullPolicy of the container, or the policy that Kubernetes defaults to if it's
+// not set: Always for images with the latest tag or without a tag, and IfNotPresent for all other images
+func effectivePullPolicy(container corev1.Container) corev1.PullPolicy {
+	if container.ImagePullPolicy != "" {
+		return container.ImagePullPolicy
+	}
+	if tag := containerTag(container.Image); tag == "" || tag == "latest" {
+		return corev1.PullAlways
+	}
+	return corev1.PullIfNotPresent
+}
@@ -0,0 +1,35 @@
+package container
+
+import (
+	"fmt"
+
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// containerResourceQuantityValid returns a function that checks that all resource quantities of the object can be
+// parsed, such as the requests and limits of containers, and the storage requests of PersistentVolumeClaims.
+// Quantities that can't be parsed are removed by the parser before the object is decoded, and are looked up by the
+// identity and location of the object.
+func containerResourceQuantityValid(invalidQuantities []ks.InvalidQuantity) func(ks.BothMeta) scorecard.TestScore {
+	return func(meta ks.BothMeta) (score scorecard.TestScore) {
+		score.Grade = scorecard.GradeAllOK
+
+		for _, q := range invalidQuantities {
+			if q.TypeMeta.Kind != meta.TypeMeta.Kind ||
+				q.ObjectMeta.Namespace != meta.ObjectMeta.Namespace ||
+				q.ObjectMeta.Name != meta.ObjectMeta.Name ||
+				q.Location != meta.FileLocation() {
+				continue
+			}
+
+			score.Grade = scorecard.GradeCritical
+			score.AddComment(q.Path, fmt.Sprintf("The quantity %q is not a valid resource quantity", q.Value),
+				"The object is rejected by the API server. Quantities use decimal suffixes such as k, M and G, or binary suffixes such as Ki, Mi and Gi, "+
+					"for example 512Mi or 512M instead of 512MB. CPU can also be set in millicores, such as 500m.",
+			)
+		}
+
+		return
+	}
+}
@@ -0,0 +1,49 @@
+package container
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// podRestartPolicyForController checks that the restartPolicy of the pod template is supported by the controller.
+// Deployments, StatefulSets, DaemonSets and ReplicaSets only support Always, and Jobs and CronJobs only support
+// OnFailure and Never. An unset restartPolicy defaults to Always. Pods that are not owned by a controller can use all
+// policies.
+func podRestartPolicyForController(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	policy := podTemplate.Spec.RestartPolicy
+	if policy == "" {
+		policy = corev1.RestartPolicyAlways
+	}
+
+	switch typeMeta.Kind {
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet":
+		if policy != corev1.RestartPolicyAlways {
+			score.Grade = scorecard.GradeCritical
+			score.AddComment("spec.template.spec.restartPolicy",
+				fmt.Sprintf("The %s has restartPolicy set to %s", typeMeta.Kind, policy),
+				fmt.Sprintf("A %s only supports the restartPolicy Always, and is rejected by the API server. Remove the restartPolicy, or set it to Always.", typeMeta.Kind),
+			)
+		}
+	case "Job", "CronJob":
+		if policy == corev1.RestartPolicyAlways {
+			path := "spec.template.spec.restartPolicy"
+			if typeMeta.Kind == "CronJob" {
+				path = "spec.jobTemplate.spec.template.spec.restartPolicy"
+			}
+			score.Grade = scorecard.GradeCritical
+			score.AddComment(path,
+				fmt.Sprintf("The %s has restartPolicy set to Always, or not set", typeMeta.Kind),
+				fmt.Sprintf("A %s only supports the restartPolicy OnFailure or Never, and is rejected by the API server. An unset restartPolicy defaults to Always. "+
+					"Set the restartPolicy to OnFailure to restart failed containers in the same pod, or to Never to create a new pod.", typeMeta.Kind),
+			)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,45 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestPodRestartPolicyForController(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		kind   string
+		policy corev1.RestartPolicy
+		grade  scorecard.Grade
+	}{
+		{"Deployment", "", scorecard.GradeAllOK},
+		{"Deployment", corev1.RestartPolicyAlways, scorecard.GradeAllOK},
+		{"Deployment", corev1.RestartPolicyOnFailure, scorecard.GradeCritical},
+		{"StatefulSet", corev1.RestartPolicyNever, scorecard.GradeCritical},
+		{"DaemonSet", corev1.RestartPolicyOnFailure, scorecard.GradeCritical},
+		{"Job", corev1.RestartPolicyOnFailure, scorecard.GradeAllOK},
+		{"Job", corev1.RestartPolicyNever, scorecard.GradeAllOK},
+		{"Job", corev1.RestartPolicyAlways, scorecard.GradeCritical},
+		{"CronJob", "", scorecard.GradeCritical},
+		{"Pod", corev1.RestartPolicyNever, scorecard.GradeAllOK},
+	}
+
+	for _, tc := range tests {
+		s := podRestartPolicyForController(corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{RestartPolicy: tc.policy},
+		}, metav1.TypeMeta{Kind: tc.kind})
+		assert.Equal(t, tc.grade, s.Grade, "%s with restartPolicy %q", tc.kind, tc.policy)
+	}
+
+	s := podRestartPolicyForController(corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{RestartPolicy: corev1.RestartPolicyAlways},
+	}, metav1.TypeMeta{Kind: "CronJob"})
+	assert.Equal(t, "spec.jobTemplate.spec.template.spec.restartPolicy", s.Comments[0].Path)
+	assert.Equal(t, "The CronJob has restartPolicy set to Always, or not set", s.Comments[0].Summary)
+}
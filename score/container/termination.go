@@ -0,0 +1,31 @@
+package container
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// containerTerminationMessagePolicy checks that all containers have terminationMessagePolicy set to
+// FallbackToLogsOnError, so that the end of the logs is used as the termination message of crashed containers
+func containerTerminationMessagePolicy(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	allContainers := append([]corev1.Container{}, podTemplate.Spec.InitContainers...)
+	allContainers = append(allContainers, podTemplate.Spec.Containers...)
+
+	for _, container := range allContainers {
+		if container.TerminationMessagePolicy == corev1.TerminationMessageFallbackToLogsOnError {
+			continue
+		}
+
+		score.Grade = scorecard.GradeWarning
+		score.AddComment(container.Name, "The container does not have terminationMessagePolicy set to FallbackToLogsOnError",
+			"With the default policy File, the termination message of a container that crashes without writing to /dev/termination-log is empty. "+
+				"With FallbackToLogsOnError, the last lines of the logs are used instead, and are shown by kubectl describe pod. Set terminationMessagePolicy to FallbackToLogsOnError.",
+		)
+	}
+
+	return
+}
@@ -0,0 +1,38 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestContainerTerminationMessagePolicy(t *testing.T) {
+	t.Parallel()
+
+	s := containerTerminationMessagePolicy(corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init", TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError}},
+			Containers:     []corev1.Container{{Name: "app", TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError}},
+		},
+	}, metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+
+	s = containerTerminationMessagePolicy(corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app"},
+				{Name: "file", TerminationMessagePolicy: corev1.TerminationMessageReadFile},
+				{Name: "ok", TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError},
+			},
+		},
+	}, metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 2)
+	assert.Equal(t, "app", s.Comments[0].Path)
+	assert.Equal(t, "file", s.Comments[1].Path)
+}
@@ -0,0 +1,43 @@
+package container
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// containerTTYStdin checks that no container in a pod that is managed by a controller has stdin or tty enabled. Bare
+// pods are not checked, as they are sometimes used for interactive debugging.
+func containerTTYStdin(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	if typeMeta.Kind == "Pod" {
+		return
+	}
+
+	allContainers := append([]corev1.Container{}, podTemplate.Spec.InitContainers...)
+	allContainers = append(allContainers, podTemplate.Spec.Containers...)
+
+	for _, container := range allContainers {
+		var enabled string
+		switch {
+		case container.Stdin && container.TTY:
+			enabled = "stdin and tty"
+		case container.Stdin:
+			enabled = "stdin"
+		case container.TTY:
+			enabled = "tty"
+		default:
+			continue
+		}
+
+		score.Grade = scorecard.GradeWarning
+		score.AddComment(container.Name, "The container has "+enabled+" enabled",
+			"stdin and tty are only useful for interactive containers, such as the ones started with kubectl run -it, and nothing is attached to them in a workload that is managed by a controller. "+
+				"Remove stdin and tty from the container.",
+		)
+	}
+
+	return
+}
@@ -0,0 +1,41 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestContainerTTYStdin(t *testing.T) {
+	t.Parallel()
+
+	template := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app"},
+				{Name: "debug", Stdin: true, TTY: true},
+				{Name: "shell", TTY: true},
+			},
+		},
+	}
+
+	s := containerTTYStdin(template, metav1.TypeMeta{Kind: "Deployment"})
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 2)
+	assert.Equal(t, "debug", s.Comments[0].Path)
+	assert.Equal(t, "The container has stdin and tty enabled", s.Comments[0].Summary)
+	assert.Equal(t, "The container has tty enabled", s.Comments[1].Summary)
+
+	s = containerTTYStdin(template, metav1.TypeMeta{Kind: "Pod"})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+
+	s = containerTTYStdin(corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+	}, metav1.TypeMeta{Kind: "StatefulSet"})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+}
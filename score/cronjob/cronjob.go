@@ -1,6 +1,10 @@
 package cronjob
 
 import (
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+
 	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/score/checks"
 	"github.com/zegl/kube-score/scorecard"
@@ -8,6 +12,7 @@ import (
 
 func Register(allChecks *checks.Checks) {
 	allChecks.RegisterCronJobCheck("CronJob has deadline", `Makes sure that all CronJobs has a configured deadline`, cronJobHasDeadline)
+	allChecks.RegisterCronJobCheck("CronJob Schedule Valid", `Makes sure that the schedule of all CronJobs is valid, and that CronJobs that run every minute have a concurrencyPolicy`, cronJobScheduleValid)
 }
 
 func cronJobHasDeadline(job ks.CronJob) (score scorecard.TestScore) {
@@ -21,3 +26,24 @@ func cronJobHasDeadline(job ks.CronJob) (score scorecard.TestScore) {
 	score.Grade = scorecard.GradeAllOK
 	return
 }
+
+func cronJobScheduleValid(job ks.CronJob) (score scorecard.TestScore) {
+	schedule, err := parseSchedule(job.Schedule())
+	if err != nil {
+		score.Grade = scorecard.GradeCritical
+		score.AddComment("", fmt.Sprintf("The schedule %q is invalid", job.Schedule()),
+			fmt.Sprintf("The CronJob will never run: %s", err))
+		return
+	}
+
+	if schedule.runsEveryMinute() && (job.ConcurrencyPolicy() == "" || job.ConcurrencyPolicy() == batchv1.AllowConcurrent) {
+		score.Grade = scorecard.GradeWarning
+		score.AddComment("", fmt.Sprintf("The schedule %q runs every minute", job.Schedule()),
+			"Jobs that take longer than a minute will run concurrently and pile up. "+
+				"Set concurrencyPolicy to Forbid or Replace, or run the Job less often.")
+		return
+	}
+
+	score.Grade = scorecard.GradeAllOK
+	return
+}
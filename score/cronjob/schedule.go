@@ -0,0 +1,168 @@
+package cronjob
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is a parsed CronJob schedule
+type cronSchedule struct {
+	// minutes has a bit set for each minute of the hour that the schedule runs at
+	minutes uint64
+
+	// every is set if the schedule is defined as "@every <duration>"
+	every time.Duration
+}
+
+// runsEveryMinute returns true if the schedule starts a Job every minute, for at least a part of the day
+func (s cronSchedule) runsEveryMinute() bool {
+	if s.every > 0 {
+		return s.every <= time.Minute
+	}
+	return s.minutes == 1<<60-1
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+// cronFields are the fields of a schedule, in the same order and with the same bounds as in the CronJob controller
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}},
+	{name: "day of week", min: 0, max: 6, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}},
+}
+
+// cronMacros are the predefined schedules, and the minutes that they run at
+var cronMacros = map[string]uint64{
+	"@yearly":   1,
+	"@annually": 1,
+	"@monthly":  1,
+	"@weekly":   1,
+	"@daily":    1,
+	"@midnight": 1,
+	"@hourly":   1,
+}
+
+// parseSchedule parses a schedule in the standard cron format, five fields or one of the predefined schedules,
+// that is accepted by the CronJob controller
+func parseSchedule(schedule string) (cronSchedule, error) {
+	schedule = strings.TrimSpace(schedule)
+	if schedule == "" {
+		return cronSchedule{}, fmt.Errorf("the schedule is empty")
+	}
+
+	if strings.HasPrefix(schedule, "TZ=") || strings.HasPrefix(schedule, "CRON_TZ=") {
+		return cronSchedule{}, fmt.Errorf("time zones are not supported in the schedule, use spec.timeZone instead")
+	}
+
+	if strings.HasPrefix(schedule, "@") {
+		if strings.HasPrefix(schedule, "@every ") {
+			every, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(schedule, "@every ")))
+			if err != nil {
+				return cronSchedule{}, fmt.Errorf("invalid duration in @every: %w", err)
+			}
+			if every < time.Second {
+				return cronSchedule{}, fmt.Errorf("the duration in @every must be at least 1s")
+			}
+			return cronSchedule{every: every}, nil
+		}
+		minutes, ok := cronMacros[strings.ToLower(schedule)]
+		if !ok {
+			return cronSchedule{}, fmt.Errorf("unsupported predefined schedule %s", schedule)
+		}
+		return cronSchedule{minutes: minutes}, nil
+	}
+
+	fields := strings.Fields(schedule)
+	if len(fields) != len(cronFields) {
+		return cronSchedule{}, fmt.Errorf("expected %d fields, found %d", len(cronFields), len(fields))
+	}
+
+	var res cronSchedule
+	for i, field := range fields {
+		bits, err := cronFields[i].parse(field)
+		if err != nil {
+			return cronSchedule{}, fmt.Errorf("invalid %s %q: %w", cronFields[i].name, field, err)
+		}
+		if i == 0 {
+			res.minutes = bits
+		}
+	}
+	return res, nil
+}
+
+// parse parses a comma separated list of values, ranges and steps, and returns a bit for each matched value
+func (f cronField) parse(field string) (uint64, error) {
+	var bits uint64
+	for _, expr := range strings.Split(field, ",") {
+		rangeExpr, step := expr, 1
+		if i := strings.Index(expr, "/"); i >= 0 {
+			var err error
+			rangeExpr = expr[:i]
+			step, err = strconv.Atoi(expr[i+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", expr[i+1:])
+			}
+		}
+
+		var start, end int
+		switch {
+		case rangeExpr == "*" || rangeExpr == "?":
+			start, end = f.min, f.max
+		case strings.Contains(rangeExpr, "-"):
+			parts := strings.SplitN(rangeExpr, "-", 2)
+			var err error
+			if start, err = f.value(parts[0]); err != nil {
+				return 0, err
+			}
+			if end, err = f.value(parts[1]); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("the range %s ends before it starts", rangeExpr)
+			}
+		default:
+			var err error
+			if start, err = f.value(rangeExpr); err != nil {
+				return 0, err
+			}
+			end = start
+			// A single value with a step, such as 5/15, runs until the end of the range
+			if rangeExpr != expr {
+				end = f.max
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// value parses a single number or name, and makes sure that it's within the bounds of the field
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%d is not between %d and %d", v, f.min, f.max)
+	}
+	return v, nil
+}
@@ -0,0 +1,47 @@
+package cronjob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSchedule(t *testing.T) {
+	cases := []struct {
+		schedule    string
+		valid       bool
+		everyMinute bool
+	}{
+		{schedule: "* * * * *", valid: true, everyMinute: true},
+		{schedule: "*/1 * * * *", valid: true, everyMinute: true},
+		{schedule: "0-59 3 * * *", valid: true, everyMinute: true},
+		{schedule: "*/5 * * * *", valid: true},
+		{schedule: "1 3 * * *", valid: true},
+		{schedule: "5/15 1-5,22 ? JAN-mar sun", valid: true},
+		{schedule: "@hourly", valid: true},
+		{schedule: "@every 30s", valid: true, everyMinute: true},
+		{schedule: "@every 1h", valid: true},
+		{schedule: ""},
+		{schedule: "* * * *"},
+		{schedule: "* * * * * *"},
+		{schedule: "60 * * * *"},
+		{schedule: "* * 0 * *"},
+		{schedule: "* * * * 7"},
+		{schedule: "5-1 * * * *"},
+		{schedule: "*/0 * * * *"},
+		{schedule: "a * * * *"},
+		{schedule: "@reboot"},
+		{schedule: "@every 1x"},
+		{schedule: "TZ=UTC 0 * * * *"},
+	}
+
+	for _, tc := range cases {
+		schedule, err := parseSchedule(tc.schedule)
+		if !tc.valid {
+			assert.Error(t, err, tc.schedule)
+			continue
+		}
+		assert.NoError(t, err, tc.schedule)
+		assert.Equal(t, tc.everyMinute, schedule.runsEveryMinute(), tc.schedule)
+	}
+}
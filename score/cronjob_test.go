@@ -3,6 +3,8 @@ package score
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/zegl/kube-score/scorecard"
 )
 
@@ -35,3 +37,21 @@ func TestProbesPodCronMissingReady(t *testing.T) {
 		})
 	}
 }
+
+func TestCronJobScheduleValid(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "cronjob-batchv1beta1.yaml", "CronJob Schedule Valid", scorecard.GradeAllOK)
+	testExpectedScore(t, "cronjob-schedule-every-minute-forbid.yaml", "CronJob Schedule Valid", scorecard.GradeAllOK)
+}
+
+func TestCronJobScheduleInvalid(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScore(t, "cronjob-schedule-invalid.yaml", "CronJob Schedule Valid", scorecard.GradeCritical)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, `The schedule "0 25 * * *" is invalid`, comments[0].Summary)
+}
+
+func TestCronJobScheduleEveryMinute(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "cronjob-schedule-every-minute.yaml", "CronJob Schedule Valid", scorecard.GradeWarning)
+}
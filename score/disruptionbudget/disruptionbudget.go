@@ -14,7 +14,9 @@ import (
 
 func Register(allChecks *checks.Checks, budgets ks.PodDisruptionBudgets) {
 	allChecks.RegisterStatefulSetCheck("StatefulSet has PodDisruptionBudget", `Makes sure that all StatefulSets are targeted by a PDB`, statefulSetHas(budgets.PodDisruptionBudgets()))
-	allChecks.RegisterDeploymentCheck("Deployment has PodDisruptionBudget", `Makes sure that all Deployments are targeted by a PDB`, deploymentHas(budgets.PodDisruptionBudgets()))
+	allChecks.CrossObject("StatefulSet has PodDisruptionBudget")
+	allChecks.RegisterOptionalDeploymentCheck("Deployment has PodDisruptionBudget", `Makes sure that all Deployments are targeted by a PDB, Deployments with a single replica are skipped`, deploymentHas(budgets.PodDisruptionBudgets()))
+	allChecks.CrossObject("Deployment has PodDisruptionBudget")
 }
 
 func hasMatching(budgets []ks.PodDisruptionBudget, namespace string, lables map[string]string) (bool, error) {
@@ -54,7 +56,7 @@ func statefulSetHas(budgets []ks.PodDisruptionBudget) func(appsv1.StatefulSet) (
 			score.Grade = scorecard.GradeAllOK
 		} else {
 			score.Grade = scorecard.GradeCritical
-			score.AddComment("", "No matching PodDisruptionBudget was found", "It's recommended to define a PodDisruptionBudget to avoid unexpected downtime during Kubernetes maintenance operations, such as when draining a node.")
+			score.AddComment("", fmt.Sprintf("No matching PodDisruptionBudget was found for StatefulSet %s", statefulset.Name), "It's recommended to define a PodDisruptionBudget to avoid unexpected downtime during Kubernetes maintenance operations, such as when draining a node.")
 		}
 
 		return
@@ -63,6 +65,8 @@ func statefulSetHas(budgets []ks.PodDisruptionBudget) func(appsv1.StatefulSet) (
 
 func deploymentHas(budgets []ks.PodDisruptionBudget) func(appsv1.Deployment) (scorecard.TestScore, error) {
 	return func(deployment appsv1.Deployment) (score scorecard.TestScore, err error) {
+		// Deployments without replicas set are checked, as the replicas of Deployments that are scaled by a
+		// HorizontalPodAutoscaler are not set
 		if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas < 2 {
 			score.Skipped = true
 			score.AddComment("", "Skipped because the deployment has less than 2 replicas", "")
@@ -78,8 +82,8 @@ func deploymentHas(budgets []ks.PodDisruptionBudget) func(appsv1.Deployment) (sc
 		if match {
 			score.Grade = scorecard.GradeAllOK
 		} else {
-			score.Grade = scorecard.GradeCritical
-			score.AddComment("", "No matching PodDisruptionBudget was found", "It's recommended to define a PodDisruptionBudget to avoid unexpected downtime during Kubernetes maintenance operations, such as when draining a node.")
+			score.Grade = scorecard.GradeWarning
+			score.AddComment("", fmt.Sprintf("No matching PodDisruptionBudget was found for Deployment %s", deployment.Name), "It's recommended to define a PodDisruptionBudget to avoid unexpected downtime during Kubernetes maintenance operations, such as when draining a node.")
 		}
 
 		return
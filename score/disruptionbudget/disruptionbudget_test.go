@@ -41,9 +41,9 @@ func TestDeploymentReplicas(t *testing.T) {
 		grade   scorecard.Grade
 		skipped bool
 	}{
-		nil:        {scorecard.GradeCritical, false}, // failed
-		intptr(1):  {0, true},                        // skipped
-		intptr(10): {scorecard.GradeCritical, false}, // failed
+		nil:        {scorecard.GradeWarning, false}, // failed
+		intptr(1):  {0, true},                       // skipped
+		intptr(10): {scorecard.GradeWarning, false}, // failed
 	}
 
 	fn := deploymentHas(nil)
@@ -1,6 +1,8 @@
 package hpa
 
 import (
+	"fmt"
+
 	"github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/score/checks"
 	"github.com/zegl/kube-score/scorecard"
@@ -8,6 +10,8 @@ import (
 
 func Register(allChecks *checks.Checks, allTargetableObjs []domain.BothMeta) {
 	allChecks.RegisterHorizontalPodAutoscalerCheck("HorizontalPodAutoscaler has target", `Makes sure that the HPA targets a valid object`, hpaHasTarget(allTargetableObjs))
+	allChecks.CrossObject("HorizontalPodAutoscaler has target")
+	allChecks.RegisterOptionalHorizontalPodAutoscalerCheck("HPA MinMax Replicas", `Makes sure that the HPA has a minReplicas of at least 1, and a maxReplicas that is larger than minReplicas`, hpaMinMaxReplicas)
 }
 
 func hpaHasTarget(allTargetableObjs []domain.BothMeta) func(hpa domain.HpaTargeter) scorecard.TestScore {
@@ -33,3 +37,33 @@ func hpaHasTarget(allTargetableObjs []domain.BothMeta) func(hpa domain.HpaTarget
 		return
 	}
 }
+
+// hpaMinMaxReplicas checks that the HPA can scale the target. The fields are the same in all versions of the
+// HorizontalPodAutoscaler, and minReplicas defaults to 1.
+func hpaMinMaxReplicas(hpa domain.HpaTargeter) (score scorecard.TestScore) {
+	minReplicas := int32(1)
+	if hpa.MinReplicas() != nil {
+		minReplicas = *hpa.MinReplicas()
+	}
+	maxReplicas := hpa.MaxReplicas()
+	replicas := fmt.Sprintf("minReplicas is %d and maxReplicas is %d.", minReplicas, maxReplicas)
+
+	switch {
+	case minReplicas == 0:
+		// Scaling to zero is behind the HPAScaleToZero feature gate, which is alpha and disabled by default
+		score.Grade = scorecard.GradeCritical
+		score.AddComment("", "The HPA has minReplicas set to 0",
+			replicas+" A minReplicas of 0 is rejected unless the alpha HPAScaleToZero feature gate is enabled. Set minReplicas to at least 1.")
+	case minReplicas > maxReplicas:
+		score.Grade = scorecard.GradeCritical
+		score.AddComment("", "The HPA has a minReplicas that is larger than maxReplicas",
+			replicas+" Set maxReplicas to a value that is larger than minReplicas.")
+	case minReplicas == maxReplicas:
+		score.Grade = scorecard.GradeWarning
+		score.AddComment("", "The HPA can't scale, minReplicas is equal to maxReplicas",
+			replicas+" Set maxReplicas to a value that is larger than minReplicas, or remove the HPA and set the replicas of the target instead.")
+	default:
+		score.Grade = scorecard.GradeAllOK
+	}
+	return
+}
@@ -176,6 +176,14 @@ func (d hpav1) GetObjectMeta() metav1.ObjectMeta {
 	return d.ObjectMeta
 }
 
+func (d hpav1) MinReplicas() *int32 {
+	return d.Spec.MinReplicas
+}
+
+func (d hpav1) MaxReplicas() int32 {
+	return d.Spec.MaxReplicas
+}
+
 func (d hpav1) HpaTarget() v1.CrossVersionObjectReference {
 	return d.Spec.ScaleTargetRef
 }
@@ -183,3 +191,36 @@ func (d hpav1) HpaTarget() v1.CrossVersionObjectReference {
 func (d hpav1) FileLocation() domain.FileLocation {
 	return domain.FileLocation{}
 }
+
+func TestHpaMinMaxReplicas(t *testing.T) {
+	t.Parallel()
+	i := func(i int32) *int32 { return &i }
+
+	testcases := []struct {
+		minReplicas     *int32
+		maxReplicas     int32
+		expectedGrade   scorecard.Grade
+		expectedSummary string
+	}{
+		{minReplicas: i(2), maxReplicas: 5, expectedGrade: scorecard.GradeAllOK},
+		// minReplicas defaults to 1
+		{minReplicas: nil, maxReplicas: 3, expectedGrade: scorecard.GradeAllOK},
+		{minReplicas: nil, maxReplicas: 1, expectedGrade: scorecard.GradeWarning, expectedSummary: "The HPA can't scale, minReplicas is equal to maxReplicas"},
+		{minReplicas: i(4), maxReplicas: 4, expectedGrade: scorecard.GradeWarning, expectedSummary: "The HPA can't scale, minReplicas is equal to maxReplicas"},
+		{minReplicas: i(0), maxReplicas: 4, expectedGrade: scorecard.GradeCritical, expectedSummary: "The HPA has minReplicas set to 0"},
+		{minReplicas: i(5), maxReplicas: 4, expectedGrade: scorecard.GradeCritical, expectedSummary: "The HPA has a minReplicas that is larger than maxReplicas"},
+	}
+
+	for _, tc := range testcases {
+		score := hpaMinMaxReplicas(hpav1{v1.HorizontalPodAutoscaler{
+			Spec: v1.HorizontalPodAutoscalerSpec{MinReplicas: tc.minReplicas, MaxReplicas: tc.maxReplicas},
+		}})
+		assert.Equal(t, tc.expectedGrade, score.Grade)
+		if tc.expectedSummary == "" {
+			assert.Empty(t, score.Comments)
+		} else {
+			assert.Len(t, score.Comments, 1)
+			assert.Equal(t, tc.expectedSummary, score.Comments[0].Summary)
+		}
+	}
+}
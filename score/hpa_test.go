@@ -3,6 +3,10 @@ package score
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/scorecard"
 )
 
@@ -15,3 +19,30 @@ func TestHorizontalPodAutoscalerHasNoTarget(t *testing.T) {
 	t.Parallel()
 	testExpectedScore(t, "hpa-has-no-target.yaml", "HorizontalPodAutoscaler has target", scorecard.GradeCritical)
 }
+
+func testHpaMinMaxReplicas(t *testing.T, filename string, expectedScore scorecard.Grade) []scorecard.TestScoreComment {
+	return testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile(filename)},
+		EnabledOptionalTests: map[string]struct{}{"hpa-minmax-replicas": {}},
+	}, "HPA MinMax Replicas", expectedScore)
+}
+
+func TestHorizontalPodAutoscalerMinMaxReplicasEqual(t *testing.T) {
+	t.Parallel()
+	comments := testHpaMinMaxReplicas(t, "hpa-minmax-replicas-equal.yaml", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "The HPA can't scale, minReplicas is equal to maxReplicas", comments[0].Summary)
+	assert.Contains(t, comments[0].Description, "minReplicas is 3 and maxReplicas is 3.")
+}
+
+func TestHorizontalPodAutoscalerMinMaxReplicasZero(t *testing.T) {
+	t.Parallel()
+	comments := testHpaMinMaxReplicas(t, "hpa-minmax-replicas-zero.yaml", scorecard.GradeCritical)
+	assert.Len(t, comments, 1)
+	assert.Contains(t, comments[0].Description, "minReplicas is 0 and maxReplicas is 10.")
+}
+
+func TestHorizontalPodAutoscalerMinMaxReplicasValid(t *testing.T) {
+	t.Parallel()
+	testHpaMinMaxReplicas(t, "hpa-targets-deployment.yaml", scorecard.GradeAllOK)
+}
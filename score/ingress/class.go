@@ -0,0 +1,44 @@
+package ingress
+
+import (
+	"fmt"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// ingressClassAnnotation is the annotation that selected the Ingress controller before spec.ingressClassName
+const ingressClassAnnotation = "kubernetes.io/ingress.class"
+
+// ingressClassNameSince is the first version of Kubernetes that supports spec.ingressClassName
+var ingressClassNameSince = config.Semver{Major: 1, Minor: 18}
+
+// ingressClassName returns a function that checks that Ingresses select the controller that should handle them,
+// either with spec.ingressClassName or with the deprecated annotation.
+func ingressClassName(kubernetesVersion config.Semver) func(ks.Ingress) scorecard.TestScore {
+	return func(ingress ks.Ingress) (score scorecard.TestScore) {
+		score.Grade = scorecard.GradeAllOK
+
+		if kubernetesVersion.LessThan(ingressClassNameSince) {
+			score.Skipped = true
+			score.AddComment("", fmt.Sprintf("Skipped because spec.ingressClassName is not supported before Kubernetes %s", ingressClassNameSince), "")
+			return
+		}
+
+		if name := ingress.IngressClassName(); name != nil && *name != "" {
+			return
+		}
+		if _, ok := ingress.GetObjectMeta().Annotations[ingressClassAnnotation]; ok {
+			return
+		}
+
+		score.Grade = scorecard.GradeWarning
+		score.AddComment("spec.ingressClassName", "The Ingress does not set an ingressClassName",
+			"Without an IngressClass, the Ingress is handled by the default IngressClass of the cluster if there is one. "+
+				"If there are multiple Ingress controllers, the Ingress can be served by the wrong controller, by all of them, or by none. "+
+				"Set spec.ingressClassName to the IngressClass that should handle the Ingress.",
+		)
+		return
+	}
+}
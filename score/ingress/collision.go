@@ -0,0 +1,124 @@
+package ingress
+
+import (
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// hostPath is a host and path that an Ingress routes traffic for
+type hostPath struct {
+	host     string
+	path     string
+	pathType networkingv1.PathType
+}
+
+// displayHost returns the host, or * if the rule applies to all hosts
+func (h hostPath) displayHost() string {
+	if h.host == "" {
+		return "*"
+	}
+	return h.host
+}
+
+func (h hostPath) String() string {
+	return h.displayHost() + h.path
+}
+
+// definedHostPath is a hostPath together with the Ingress that defines it
+type definedHostPath struct {
+	ingress  ks.Ingress
+	hostPath hostPath
+}
+
+// ingressHostPaths returns all host and path combinations of the Ingress. Prefix paths are normalized, as a trailing
+// slash does not change which requests that they match. Paths without a pathType are ImplementationSpecific.
+// Ingresses that only have a default backend have no host and path combinations.
+func ingressHostPaths(ingress ks.Ingress) (res []hostPath) {
+	for _, rule := range ingress.Rules() {
+		if rule.HTTP == nil {
+			continue
+		}
+
+		for _, path := range rule.HTTP.Paths {
+			pathType := networkingv1.PathTypeImplementationSpecific
+			if path.PathType != nil {
+				pathType = *path.PathType
+			}
+
+			p := path.Path
+			if pathType == networkingv1.PathTypePrefix {
+				p = "/" + strings.Trim(p, "/")
+			}
+
+			res = append(res, hostPath{host: rule.Host, path: p, pathType: pathType})
+		}
+	}
+	return
+}
+
+// ingressHostPathCollision returns a function that checks if any other Ingress defines the same host and path as the
+// Ingress. The hosts must be equal, as exact hosts take precedence over wildcard hosts, and the paths must be equal
+// and have the same pathType, as longer paths and Exact paths take precedence over shorter paths and Prefix paths.
+// All host and path combinations are indexed once, when the function is created.
+func ingressHostPathCollision(allIngresses []ks.Ingress) func(ks.Ingress) scorecard.TestScore {
+	definedBy := make(map[hostPath][]definedHostPath)
+	for _, ingress := range allIngresses {
+		for _, hp := range ingressHostPaths(ingress) {
+			definedBy[hp] = append(definedBy[hp], definedHostPath{ingress: ingress, hostPath: hp})
+		}
+	}
+
+	return func(ingress ks.Ingress) (score scorecard.TestScore) {
+		score.Grade = scorecard.GradeAllOK
+
+		reported := make(map[hostPath]struct{})
+		for _, hp := range ingressHostPaths(ingress) {
+			if _, ok := reported[hp]; ok {
+				continue
+			}
+			reported[hp] = struct{}{}
+
+			for _, other := range definedBy[hp] {
+				if sameIngress(ingress, other.ingress) {
+					continue
+				}
+
+				score.Grade = scorecard.GradeCritical
+				score.AddComment(hp.String(),
+					fmt.Sprintf("The host and path are also defined by the Ingress %s", ingressRef(other.ingress)),
+					fmt.Sprintf("The %s path %s on the host %s is defined both in %s and in %s. "+
+						"It's not defined which of the Ingresses that receives the traffic.",
+						hp.pathType, hp.path, hp.displayHost(), fileLocation(ingress), fileLocation(other.ingress)),
+				)
+			}
+		}
+
+		return
+	}
+}
+
+func sameIngress(a, b ks.Ingress) bool {
+	return a.GetObjectMeta().Namespace == b.GetObjectMeta().Namespace &&
+		a.GetObjectMeta().Name == b.GetObjectMeta().Name &&
+		a.FileLocation() == b.FileLocation()
+}
+
+func ingressRef(ingress ks.Ingress) string {
+	if ns := ingress.GetObjectMeta().Namespace; ns != "" {
+		return ns + "/" + ingress.GetObjectMeta().Name
+	}
+	return ingress.GetObjectMeta().Name
+}
+
+func fileLocation(ingress ks.Ingress) string {
+	location := ingress.FileLocation()
+	if location.Line == 0 {
+		return location.Name
+	}
+	return fmt.Sprintf("%s:%d", location.Name, location.Line)
+}
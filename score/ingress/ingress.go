@@ -3,13 +3,19 @@ package ingress
 import (
 	"fmt"
 
+	"github.com/zegl/kube-score/config"
 	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/score/checks"
 	"github.com/zegl/kube-score/scorecard"
 )
 
-func Register(allChecks *checks.Checks, services ks.Services) {
+func Register(allChecks *checks.Checks, kubernetesVersion config.Semver, services ks.Services, ingresses ks.Ingresses) {
 	allChecks.RegisterIngressCheck("Ingress targets Service", `Makes sure that the Ingress targets a Service`, ingressTargetsService(services.Services()))
+	allChecks.CrossObject("Ingress targets Service")
+	allChecks.RegisterIngressCheck("Ingress Host Path Collision", `Makes sure that no two Ingresses define the same host and path`, ingressHostPathCollision(ingresses.Ingresses()))
+	allChecks.CrossObject("Ingress Host Path Collision")
+	allChecks.RegisterIngressCheck("Ingress Path Type", `Makes sure that all paths of networking.k8s.io/v1 Ingresses have a pathType, and warns about paths with the ImplementationSpecific pathType`, ingressPathType(kubernetesVersion))
+	allChecks.RegisterOptionalIngressCheck("Ingress Class Name", `Makes sure that Ingresses set spec.ingressClassName, or the kubernetes.io/ingress.class annotation, on Kubernetes v1.18 and later`, ingressClassName(kubernetesVersion))
 }
 
 func ingressTargetsService(allServices []ks.Service) func(ks.Ingress) scorecard.TestScore {
@@ -19,6 +25,13 @@ func ingressTargetsService(allServices []ks.Service) func(ks.Ingress) scorecard.
 }
 
 func ingressTargetsServiceCommon(ingress ks.Ingress, allServices []ks.Service) (score scorecard.TestScore) {
+	if len(allServices) == 0 {
+		score.Grade = scorecard.GradeAllOK
+		score.Skipped = true
+		score.AddComment("", "Skipped because no Services are defined", "")
+		return
+	}
+
 	allRulesHaveMatches := true
 
 	for _, rule := range ingress.Rules() {
@@ -44,7 +57,7 @@ func ingressTargetsServiceCommon(ingress ks.Ingress, allServices []ks.Service) (
 					for _, servicePort := range service.Spec.Ports {
 						if path.Backend.Service.Port.Number > 0 && servicePort.Port == path.Backend.Service.Port.Number {
 							pathHasMatch = true
-						} else if servicePort.Name == path.Backend.Service.Port.Name {
+						} else if path.Backend.Service.Port.Name != "" && servicePort.Name == path.Backend.Service.Port.Name {
 							pathHasMatch = true
 						}
 					}
@@ -0,0 +1,59 @@
+package ingress
+
+import (
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// ingressV1Since is the first version of Kubernetes that serves networking.k8s.io/v1 Ingresses, where pathType is
+// required
+var ingressV1Since = config.Semver{Major: 1, Minor: 19}
+
+// ingressPathType returns a function that checks that all paths of the Ingress have a portable pathType. The pathType
+// is required on networking.k8s.io/v1 Ingresses, and ImplementationSpecific paths are matched differently by each
+// Ingress controller.
+func ingressPathType(kubernetesVersion config.Semver) func(ks.Ingress) scorecard.TestScore {
+	return func(ingress ks.Ingress) (score scorecard.TestScore) {
+		score.Grade = scorecard.GradeAllOK
+
+		required := ingress.GetTypeMeta().APIVersion == networkingv1.SchemeGroupVersion.String() &&
+			!kubernetesVersion.LessThan(ingressV1Since)
+
+		for i, rule := range ingress.Rules() {
+			if rule.HTTP == nil {
+				continue
+			}
+			for j, path := range rule.HTTP.Paths {
+				hp := hostPath{host: rule.Host, path: path.Path}
+				fieldPath := fmt.Sprintf("spec.rules[%d].http.paths[%d].pathType", i, j)
+
+				if path.PathType == nil {
+					if required {
+						score.Grade = scorecard.GradeCritical
+						score.AddComment(fieldPath, fmt.Sprintf("The path %s has no pathType", hp),
+							"The pathType is required on networking.k8s.io/v1 Ingresses, and the Ingress is rejected by the API server. Set pathType to Prefix or Exact.",
+						)
+					}
+					continue
+				}
+
+				if *path.PathType == networkingv1.PathTypeImplementationSpecific {
+					if score.Grade > scorecard.GradeWarning {
+						score.Grade = scorecard.GradeWarning
+					}
+					score.AddComment(fieldPath, fmt.Sprintf("The path %s has pathType ImplementationSpecific", hp),
+						"ImplementationSpecific paths are matched differently by each Ingress controller, so the Ingress can route traffic differently if the controller is changed. "+
+							"Use Prefix or Exact unless the path relies on a feature of the controller, such as regular expressions.",
+					)
+				}
+			}
+		}
+
+		return
+	}
+}
@@ -3,6 +3,10 @@ package score
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/scorecard"
 )
 
@@ -36,6 +40,32 @@ func TestNetworkingIngressV1TargetsServiceNoMatch(t *testing.T) {
 	testExpectedScore(t, "ingress-networkingv1-targets-service-no-match.yaml", "Ingress targets Service", scorecard.GradeCritical)
 }
 
+func TestNetworkingIngressV1TargetsServicePortMismatch(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScore(t, "ingress-networkingv1-targets-service-port-mismatch.yaml", "Ingress targets Service", scorecard.GradeCritical)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "No service with name app-service and port number 8080 was found", comments[0].Description)
+}
+
+func TestNetworkingIngressV1TargetsServiceNoServices(t *testing.T) {
+	t.Parallel()
+	s, err := testScore(config.Configuration{
+		AllFiles: []ks.NamedReader{testFile("ingress-networkingv1-no-services.yaml")},
+	})
+	assert.Nil(t, err)
+
+	tested := false
+	for _, o := range s {
+		for _, c := range o.Checks {
+			if c.Check.ID == "ingress-targets-service" {
+				assert.True(t, c.Skipped)
+				tested = true
+			}
+		}
+	}
+	assert.True(t, tested)
+}
+
 func TestNetworkPolicyV1InvalidBackend(t *testing.T) {
 	t.Parallel()
 	testExpectedScore(t, "ingress-v1-invalid-backend.yaml", "Ingress targets Service", scorecard.GradeCritical)
@@ -50,3 +80,124 @@ func TestIngressNoPanicIssue388(t *testing.T) {
 	t.Parallel()
 	testExpectedScore(t, "ingress_issue388.yaml", "Ingress targets Service", scorecard.GradeAllOK)
 }
+
+func TestIngressHostPathCollision(t *testing.T) {
+	t.Parallel()
+	sc, err := testScore(config.Configuration{
+		AllFiles: []ks.NamedReader{testFile("ingress-host-path-collision.yaml")},
+	})
+	assert.Nil(t, err)
+
+	grades := make(map[string]scorecard.Grade)
+	for _, o := range sc {
+		for _, c := range o.Checks {
+			if c.Check.ID != "ingress-host-path-collision" {
+				continue
+			}
+			grades[o.ObjectMeta.Name] = c.Grade
+
+			if o.ObjectMeta.Name == "first" {
+				// The Exact and Prefix /login paths don't collide, and the wildcard host is different from the exact host
+				assert.Len(t, c.Comments, 1)
+				assert.Equal(t, "example.com/api", c.Comments[0].Path)
+				assert.Equal(t, "The host and path are also defined by the Ingress bar/second", c.Comments[0].Summary)
+				assert.Contains(t, c.Comments[0].Description, "ingress-host-path-collision.yaml:1 and in ")
+				assert.Contains(t, c.Comments[0].Description, "ingress-host-path-collision.yaml:26.")
+			}
+		}
+	}
+
+	assert.Equal(t, map[string]scorecard.Grade{
+		"first":                scorecard.GradeCritical,
+		"second":               scorecard.GradeCritical,
+		"default-backend-only": scorecard.GradeAllOK,
+	}, grades)
+}
+
+func TestIngressClassName(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		version  config.Semver
+		expected map[string]scorecard.Grade
+		skipped  bool
+	}{
+		{
+			config.Semver{Major: 1, Minor: 18},
+			map[string]scorecard.Grade{"no-class": scorecard.GradeWarning, "class-name": scorecard.GradeAllOK, "class-annotation": scorecard.GradeAllOK},
+			false,
+		},
+		{
+			config.Semver{Major: 1, Minor: 17},
+			map[string]scorecard.Grade{"no-class": scorecard.GradeAllOK, "class-name": scorecard.GradeAllOK, "class-annotation": scorecard.GradeAllOK},
+			true,
+		},
+	} {
+		sc, err := testScore(config.Configuration{
+			AllFiles:             []ks.NamedReader{testFile("ingress-class-name.yaml")},
+			KubernetesVersion:    tc.version,
+			EnabledOptionalTests: map[string]struct{}{"ingress-class-name": {}},
+		})
+		assert.NoError(t, err)
+
+		grades := make(map[string]scorecard.Grade)
+		for _, o := range sc {
+			for _, c := range o.Checks {
+				if c.Check.ID == "ingress-class-name" {
+					grades[o.ObjectMeta.Name] = c.Grade
+					assert.Equal(t, tc.skipped, c.Skipped)
+				}
+			}
+		}
+		assert.Equal(t, tc.expected, grades, tc.version.String())
+	}
+}
+
+func TestIngressPathType(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		version  config.Semver
+		expected map[string]scorecard.Grade
+	}{
+		{
+			config.Semver{Major: 1, Minor: 19},
+			map[string]scorecard.Grade{
+				"v1-no-path-type":            scorecard.GradeCritical,
+				"v1-implementation-specific": scorecard.GradeWarning,
+				"v1-exact":                   scorecard.GradeAllOK,
+				"v1beta1-no-path-type":       scorecard.GradeAllOK,
+			},
+		},
+		{
+			config.Semver{Major: 1, Minor: 18},
+			map[string]scorecard.Grade{
+				"v1-no-path-type":            scorecard.GradeAllOK,
+				"v1-implementation-specific": scorecard.GradeWarning,
+				"v1-exact":                   scorecard.GradeAllOK,
+				"v1beta1-no-path-type":       scorecard.GradeAllOK,
+			},
+		},
+	} {
+		sc, err := testScore(config.Configuration{
+			AllFiles:          []ks.NamedReader{testFile("ingress-path-type.yaml")},
+			KubernetesVersion: tc.version,
+		})
+		assert.NoError(t, err)
+
+		grades := make(map[string]scorecard.Grade)
+		for _, o := range sc {
+			for _, c := range o.Checks {
+				if c.Check.ID == "ingress-path-type" {
+					grades[o.ObjectMeta.Name] = c.Grade
+					if o.ObjectMeta.Name == "v1-no-path-type" && c.Grade == scorecard.GradeCritical {
+						assert.Len(t, c.Comments, 1)
+						assert.Equal(t, "spec.rules[0].http.paths[1].pathType", c.Comments[0].Path)
+						assert.Equal(t, "The path foo.example.com/api has no pathType", c.Comments[0].Summary)
+					}
+				}
+			}
+		}
+		assert.Equal(t, tc.expected, grades, tc.version.String())
+	}
+}
@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// Annotation returns the value of an annotation that changes how a check is run, such as kube-score/tier. The
+// annotations are read from the metadata of the object that is checked, in the same way as kube-score/ignore, and
+// not from the pod template of Deployments, StatefulSets, etc.
+func Annotation(meta metav1.ObjectMeta, key string) (string, bool) {
+	value, ok := meta.Annotations[key]
+	return value, ok
+}
+
+// Int32Annotation returns the value of the annotation as a positive integer, or def if the annotation is not set. If
+// the value is invalid, a warning is added to the score and def is returned.
+func Int32Annotation(score *scorecard.TestScore, meta metav1.ObjectMeta, key string, def int32) int32 {
+	value, ok := Annotation(meta, key)
+	if !ok {
+		return def
+	}
+	i, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || i < 0 {
+		invalidAnnotation(score, key, fmt.Sprintf("The value %q is not a positive integer. The default threshold %d is used instead.", value, def))
+		return def
+	}
+	return int32(i)
+}
+
+// QuantityAnnotation returns the value of the annotation as a quantity, or def if the annotation is not set. If the
+// value is invalid, a warning is added to the score and def is returned.
+func QuantityAnnotation(score *scorecard.TestScore, meta metav1.ObjectMeta, key string, def resource.Quantity) resource.Quantity {
+	value, ok := Annotation(meta, key)
+	if !ok {
+		return def
+	}
+	q, err := resource.ParseQuantity(value)
+	if err != nil {
+		invalidAnnotation(score, key, fmt.Sprintf("The value %q could not be parsed as a quantity: %s. The default threshold %s is used instead.", value, err, def.String()))
+		return def
+	}
+	return q
+}
+
+func invalidAnnotation(score *scorecard.TestScore, key, description string) {
+	score.Grade = scorecard.GradeWarning
+	score.AddComment("", fmt.Sprintf("The annotation %s has an invalid value", key), description)
+}
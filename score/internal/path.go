@@ -0,0 +1,15 @@
+package internal
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// PodSpecPath returns the path to the pod spec in the object, for comments on fields of the pod spec in Pod checks
+func PodSpecPath(typeMeta metav1.TypeMeta) string {
+	switch typeMeta.Kind {
+	case "Pod":
+		return "spec"
+	case "CronJob":
+		return "spec.jobTemplate.spec.template.spec"
+	default:
+		return "spec.template.spec"
+	}
+}
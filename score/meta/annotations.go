@@ -0,0 +1,23 @@
+package meta
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func objectNoLastAppliedAnnotation(meta domain.BothMeta) (score scorecard.TestScore) {
+	if _, ok := meta.ObjectMeta.Annotations[corev1.LastAppliedConfigAnnotation]; !ok {
+		score.Grade = scorecard.GradeAllOK
+		return
+	}
+
+	score.Grade = scorecard.GradeWarning
+	score.AddComment("metadata.annotations",
+		"The object has the "+corev1.LastAppliedConfigAnnotation+" annotation",
+		"The annotation is set by kubectl apply, and is only expected on objects in the cluster. In a manifest, it's a sign that the object was copied from the cluster with kubectl get -o yaml, "+
+			"and it can contain values that are not the same as in the rest of the manifest. Remove the annotation from the manifest.",
+	)
+	return
+}
@@ -0,0 +1,33 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestObjectNoLastAppliedAnnotation(t *testing.T) {
+	t.Parallel()
+
+	s := objectNoLastAppliedAnnotation(domain.BothMeta{
+		TypeMeta:   metav1.TypeMeta{Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Annotations: map[string]string{"foo": "bar"}},
+	})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+
+	s = objectNoLastAppliedAnnotation(domain.BothMeta{
+		TypeMeta: metav1.TypeMeta{Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Annotations: map[string]string{
+			"kubectl.kubernetes.io/last-applied-configuration": `{"apiVersion":"apps/v1","kind":"Deployment"}`,
+		}},
+	})
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 1)
+	assert.Equal(t, "metadata.annotations", s.Comments[0].Path)
+	assert.Equal(t, "The object has the kubectl.kubernetes.io/last-applied-configuration annotation", s.Comments[0].Summary)
+}
@@ -0,0 +1,47 @@
+package meta
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// objectIdentity returns a key that is unique for each object that can exist in a cluster
+func objectIdentity(meta domain.BothMeta) string {
+	return meta.TypeMeta.APIVersion + "/" + meta.TypeMeta.Kind + "/" + meta.ObjectMeta.Namespace + "/" + meta.ObjectMeta.Name
+}
+
+// duplicateObjectIdentity returns a function that checks if any other object in allMetas has the same
+// apiVersion, kind, namespace and name as the object. The locations of all objects are indexed once, when
+// the function is created.
+func duplicateObjectIdentity(allMetas []domain.BothMeta) func(domain.BothMeta) scorecard.TestScore {
+	locations := make(map[string][]string)
+	for _, m := range allMetas {
+		key := objectIdentity(m)
+		locations[key] = append(locations[key], formatFileLocation(m.FileLocation()))
+	}
+
+	return func(meta domain.BothMeta) (score scorecard.TestScore) {
+		objectLocations := locations[objectIdentity(meta)]
+		if len(objectLocations) < 2 {
+			score.Grade = scorecard.GradeAllOK
+			return
+		}
+
+		score.Grade = scorecard.GradeCritical
+		score.AddComment("", fmt.Sprintf("The object is defined %d times", len(objectLocations)),
+			fmt.Sprintf("Objects with the same apiVersion, kind, namespace and name are defined in %s. "+
+				"Only one of them will exist in the cluster, the one that is applied last silently replaces the others.", strings.Join(objectLocations, ", ")),
+		)
+		return
+	}
+}
+
+func formatFileLocation(location domain.FileLocation) string {
+	if location.Line == 0 {
+		return location.Name
+	}
+	return fmt.Sprintf("%s:%d", location.Name, location.Line)
+}
@@ -0,0 +1,46 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+type fileLocation domain.FileLocation
+
+func (f fileLocation) FileLocation() domain.FileLocation {
+	return domain.FileLocation(f)
+}
+
+func testMeta(apiVersion, kind, namespace, name string, location fileLocation) domain.BothMeta {
+	return domain.BothMeta{
+		TypeMeta:       metav1.TypeMeta{APIVersion: apiVersion, Kind: kind},
+		ObjectMeta:     metav1.ObjectMeta{Namespace: namespace, Name: name},
+		FileLocationer: location,
+	}
+}
+
+func TestDuplicateObjectIdentity(t *testing.T) {
+	t.Parallel()
+
+	first := testMeta("apps/v1", "Deployment", "foo", "app", fileLocation{Name: "base/deployment.yaml", Line: 1})
+	second := testMeta("apps/v1", "Deployment", "foo", "app", fileLocation{Name: "overlay/deployment.yaml", Line: 12})
+	otherNamespace := testMeta("apps/v1", "Deployment", "bar", "app", fileLocation{Name: "base/deployment.yaml", Line: 30})
+	otherKind := testMeta("v1", "Service", "foo", "app", fileLocation{Name: "base/service.yaml"})
+
+	fn := duplicateObjectIdentity([]domain.BothMeta{first, second, otherNamespace, otherKind})
+
+	s := fn(first)
+	assert.Equal(t, scorecard.GradeCritical, s.Grade)
+	assert.Len(t, s.Comments, 1)
+	assert.Equal(t, "The object is defined 2 times", s.Comments[0].Summary)
+	assert.Contains(t, s.Comments[0].Description, "base/deployment.yaml:1, overlay/deployment.yaml:12")
+
+	assert.Equal(t, scorecard.GradeCritical, fn(second).Grade)
+	assert.Equal(t, scorecard.GradeAllOK, fn(otherNamespace).Grade)
+	assert.Equal(t, scorecard.GradeAllOK, fn(otherKind).Grade)
+}
@@ -0,0 +1,32 @@
+package meta
+
+import (
+	"fmt"
+
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// objectKindKnown returns a function that reports objects with a kind or apiVersion that is not known to Kubernetes,
+// which would otherwise be skipped without being scored. The objects are graded as Critical if strict is set.
+func objectKindKnown(strict bool) func(domain.UnknownObject) scorecard.TestScore {
+	return func(obj domain.UnknownObject) (score scorecard.TestScore) {
+		score.Grade = scorecard.GradeWarning
+		if strict {
+			score.Grade = scorecard.GradeCritical
+		}
+
+		typeMeta := obj.GetTypeMeta()
+		description := "The object is not scored by kube-score, and will be rejected by Kubernetes. Check the spelling and casing of the apiVersion and kind."
+		if suggestion := obj.Suggestion(); suggestion != nil {
+			description = fmt.Sprintf("The object is not scored by kube-score, and will be rejected by Kubernetes. Did you mean apiVersion: %s, kind: %s?",
+				suggestion.APIVersion, suggestion.Kind)
+		}
+
+		score.AddComment("",
+			fmt.Sprintf("The apiVersion %q and kind %q are not known to Kubernetes", typeMeta.APIVersion, typeMeta.Kind),
+			description,
+		)
+		return
+	}
+}
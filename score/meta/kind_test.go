@@ -0,0 +1,39 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+type unknownObject struct {
+	typeMeta   metav1.TypeMeta
+	suggestion *metav1.TypeMeta
+}
+
+func (u unknownObject) GetTypeMeta() metav1.TypeMeta     { return u.typeMeta }
+func (u unknownObject) GetObjectMeta() metav1.ObjectMeta { return metav1.ObjectMeta{} }
+func (u unknownObject) Suggestion() *metav1.TypeMeta     { return u.suggestion }
+func (u unknownObject) FileLocation() ks.FileLocation    { return ks.FileLocation{} }
+
+func TestObjectKindKnown(t *testing.T) {
+	obj := unknownObject{
+		typeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deploymnet"},
+		suggestion: &metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+	}
+
+	score := objectKindKnown(false)(obj)
+	assert.Equal(t, scorecard.GradeWarning, score.Grade)
+	assert.Len(t, score.Comments, 1)
+	assert.Equal(t, `The apiVersion "apps/v1" and kind "Deploymnet" are not known to Kubernetes`, score.Comments[0].Summary)
+	assert.Contains(t, score.Comments[0].Description, "Did you mean apiVersion: apps/v1, kind: Deployment?")
+
+	obj.suggestion = nil
+	score = objectKindKnown(true)(obj)
+	assert.Equal(t, scorecard.GradeCritical, score.Grade)
+	assert.NotContains(t, score.Comments[0].Description, "Did you mean")
+}
@@ -1,15 +1,44 @@
 package meta
 
 import (
+	"fmt"
 	"regexp"
+	"strings"
 
+	"github.com/zegl/kube-score/config"
 	"github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/score/checks"
 	"github.com/zegl/kube-score/scorecard"
 )
 
-func Register(allChecks *checks.Checks) {
+// recommendedLabelPrefix is the prefix of the labels recommended by Kubernetes
+// https://kubernetes.io/docs/concepts/overview/working-with-objects/common-labels/
+const recommendedLabelPrefix = "app.kubernetes.io/"
+
+// DefaultRecommendedLabels is the set of labels required by the "Object Recommended Labels" check, if no labels are configured
+var DefaultRecommendedLabels = []string{"name", "instance", "version", "component", "part-of", "managed-by"}
+
+func Register(allChecks *checks.Checks, cnf config.Configuration, metas domain.Metas) {
 	allChecks.RegisterMetaCheck("Label values", "Validates label values", validateLabelValues)
+	allChecks.RegisterMetaCheck("Duplicate Object Identity", "Makes sure that no two objects have the same apiVersion, kind, namespace and name", duplicateObjectIdentity(metas.Metas()))
+	allChecks.CrossObject("Duplicate Object Identity")
+	allChecks.RegisterUnknownObjectCheck("Object Kind Known", "Makes sure that the apiVersion and kind of all objects are known to Kubernetes, objects with a misspelled kind are otherwise not scored", objectKindKnown(cnf.StrictUnknownKinds))
+
+	requiredLabels := cnf.RecommendedLabels
+	if len(requiredLabels) == 0 {
+		requiredLabels = DefaultRecommendedLabels
+	}
+	allChecks.RegisterOptionalMetaCheck("Object Namespace Set", "Makes sure that all namespaced objects have an explicit metadata.namespace set", objectNamespaceSet)
+	allChecks.RegisterOptionalMetaCheck("Object No Last Applied Annotation", "Makes sure that objects don't have the kubectl.kubernetes.io/last-applied-configuration annotation, which is a sign that the manifest was copied from the cluster", objectNoLastAppliedAnnotation)
+	maxAnnotationBytes, maxLabels := cnf.MaxAnnotationBytes, cnf.MaxLabels
+	if maxAnnotationBytes <= 0 {
+		maxAnnotationBytes = DefaultMaxAnnotationBytes
+	}
+	if maxLabels <= 0 {
+		maxLabels = DefaultMaxLabels
+	}
+	allChecks.RegisterOptionalMetaCheck("Object Metadata Size", objectMetadataSizeComment(maxAnnotationBytes, maxLabels), objectMetadataSize(maxAnnotationBytes, maxLabels))
+	allChecks.RegisterOptionalMetaCheck("Object Recommended Labels", "Makes sure that all objects have the recommended app.kubernetes.io/ labels set. The set of required labels can be changed with --recommended-label", recommendedLabels(requiredLabels))
 }
 
 func validateLabelValues(meta domain.BothMeta) (score scorecard.TestScore) {
@@ -23,3 +52,35 @@ func validateLabelValues(meta domain.BothMeta) (score scorecard.TestScore) {
 	}
 	return
 }
+
+// recommendedLabels returns a function that checks that all labels in requiredLabels are set on the object.
+// Labels without a prefix are prefixed with app.kubernetes.io/.
+func recommendedLabels(requiredLabels []string) func(domain.BothMeta) scorecard.TestScore {
+	var keys []string
+	for _, label := range requiredLabels {
+		if !strings.Contains(label, "/") {
+			label = recommendedLabelPrefix + label
+		}
+		keys = append(keys, label)
+	}
+
+	return func(meta domain.BothMeta) (score scorecard.TestScore) {
+		var missing []string
+		for _, key := range keys {
+			if _, ok := meta.ObjectMeta.Labels[key]; !ok {
+				missing = append(missing, key)
+			}
+		}
+
+		if len(missing) == 0 {
+			score.Grade = scorecard.GradeAllOK
+			return
+		}
+
+		score.Grade = scorecard.GradeWarning
+		score.AddComment("", "The object is missing recommended labels",
+			fmt.Sprintf("The labels %s are not set. The recommended labels describe the application that the object belongs to, and are used by tools to show and manage applications. https://kubernetes.io/docs/concepts/overview/working-with-objects/common-labels/", strings.Join(missing, ", ")),
+		)
+		return
+	}
+}
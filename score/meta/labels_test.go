@@ -37,3 +37,31 @@ func TestOKLabel(t *testing.T) {
 	})
 	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
 }
+
+func TestRecommendedLabels(t *testing.T) {
+	t.Parallel()
+	fn := recommendedLabels([]string{"name", "part-of", "example.com/team"})
+
+	s := fn(domain.BothMeta{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"app.kubernetes.io/name": "foo",
+			},
+		},
+	})
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 1)
+	assert.Contains(t, s.Comments[0].Description, "The labels app.kubernetes.io/part-of, example.com/team are not set.")
+
+	s = fn(domain.BothMeta{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"app.kubernetes.io/name":    "foo",
+				"app.kubernetes.io/part-of": "bar",
+				"example.com/team":          "baz",
+			},
+		},
+	})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+}
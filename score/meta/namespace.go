@@ -0,0 +1,51 @@
+package meta
+
+import (
+	"fmt"
+
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// ClusterScopedKinds are the kinds of objects that don't belong to a namespace, the "Object Namespace Set" check
+// is skipped for these
+var ClusterScopedKinds = map[string]struct{}{
+	"APIService":                     {},
+	"CertificateSigningRequest":      {},
+	"ClusterRole":                    {},
+	"ClusterRoleBinding":             {},
+	"CSIDriver":                      {},
+	"CSINode":                        {},
+	"CustomResourceDefinition":       {},
+	"IngressClass":                   {},
+	"MutatingWebhookConfiguration":   {},
+	"Namespace":                      {},
+	"Node":                           {},
+	"PersistentVolume":               {},
+	"PodSecurityPolicy":              {},
+	"PriorityClass":                  {},
+	"RuntimeClass":                   {},
+	"StorageClass":                   {},
+	"ValidatingWebhookConfiguration": {},
+	"VolumeAttachment":               {},
+}
+
+func objectNamespaceSet(meta domain.BothMeta) (score scorecard.TestScore) {
+	if _, ok := ClusterScopedKinds[meta.TypeMeta.Kind]; ok {
+		score.Grade = scorecard.GradeAllOK
+		score.Skipped = true
+		score.AddComment("", fmt.Sprintf("Skipped because %s is cluster-scoped", meta.TypeMeta.Kind), "")
+		return
+	}
+
+	if meta.ObjectMeta.Namespace != "" {
+		score.Grade = scorecard.GradeAllOK
+		return
+	}
+
+	score.Grade = scorecard.GradeWarning
+	score.AddComment("metadata.namespace", "The object has no namespace set",
+		"Objects without a namespace are created in the namespace that the current kubectl context defaults to, which may not be the intended one. Set metadata.namespace explicitly.",
+	)
+	return
+}
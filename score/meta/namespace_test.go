@@ -0,0 +1,36 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestObjectNamespaceSet(t *testing.T) {
+	t.Parallel()
+
+	s := objectNamespaceSet(domain.BothMeta{
+		TypeMeta:   metav1.TypeMeta{Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"},
+	})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+
+	s = objectNamespaceSet(domain.BothMeta{
+		TypeMeta:   metav1.TypeMeta{Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+	})
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 1)
+	assert.Equal(t, "metadata.namespace", s.Comments[0].Path)
+	assert.Equal(t, "The object has no namespace set", s.Comments[0].Summary)
+
+	s = objectNamespaceSet(domain.BothMeta{
+		TypeMeta:   metav1.TypeMeta{Kind: "ClusterRole"},
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+	})
+	assert.True(t, s.Skipped)
+}
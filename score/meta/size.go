@@ -0,0 +1,62 @@
+package meta
+
+import (
+	"fmt"
+
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+const (
+	// DefaultMaxAnnotationBytes is the largest combined size of the keys and values of all annotations of an object that
+	// is accepted by the "Object Metadata Size" check, the same as the largest size that is accepted by the API server
+	DefaultMaxAnnotationBytes = 256 * 1024
+
+	// DefaultMaxLabels is the largest number of labels on an object that is accepted by the "Object Metadata Size" check
+	DefaultMaxLabels = 64
+)
+
+// objectMetadataSizeComment returns the description of the "Object Metadata Size" check, with the thresholds in effect
+func objectMetadataSizeComment(maxAnnotationBytes, maxLabels int) string {
+	return fmt.Sprintf("Makes sure that the annotations of objects are not larger than %s, and that objects have at most %d labels. "+
+		"The thresholds can be changed with --max-annotation-bytes and --max-labels", formatBytes(maxAnnotationBytes), maxLabels)
+}
+
+// formatBytes formats a size in bytes as KiB if it's a whole number of KiB
+func formatBytes(b int) string {
+	if b%1024 == 0 {
+		return fmt.Sprintf("%dKiB", b/1024)
+	}
+	return fmt.Sprintf("%d bytes", b)
+}
+
+// objectMetadataSize returns a function that checks that the annotations of an object are not larger than maxAnnotationBytes,
+// and that it has at most maxLabels labels
+func objectMetadataSize(maxAnnotationBytes, maxLabels int) func(domain.BothMeta) scorecard.TestScore {
+	return func(meta domain.BothMeta) (score scorecard.TestScore) {
+		score.Grade = scorecard.GradeAllOK
+
+		// Measured in the same way as by the API server
+		annotationBytes := 0
+		for key, value := range meta.ObjectMeta.Annotations {
+			annotationBytes += len(key) + len(value)
+		}
+		if annotationBytes > maxAnnotationBytes {
+			score.Grade = scorecard.GradeWarning
+			score.AddComment("metadata.annotations", "The annotations of the object are too large",
+				fmt.Sprintf("The annotations are %d bytes, which is more than %d bytes. Large annotations are stored in etcd and returned in every response, "+
+					"and the API server rejects objects with more than 262144 bytes of annotations. Move large data to a ConfigMap, or remove the annotations that are added by tooling.", annotationBytes, maxAnnotationBytes),
+			)
+		}
+
+		if labels := len(meta.ObjectMeta.Labels); labels > maxLabels {
+			score.Grade = scorecard.GradeWarning
+			score.AddComment("metadata.labels", "The object has too many labels",
+				fmt.Sprintf("The object has %d labels, which is more than %d. Labels are indexed and returned in every response, "+
+					"use annotations for metadata that is not used by selectors.", labels, maxLabels),
+			)
+		}
+
+		return
+	}
+}
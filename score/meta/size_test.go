@@ -0,0 +1,48 @@
+package meta
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestObjectMetadataSize(t *testing.T) {
+	t.Parallel()
+
+	check := objectMetadataSize(100, 2)
+
+	s := check(domain.BothMeta{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{"foo": strings.Repeat("a", 97)},
+		Labels:      map[string]string{"a": "1", "b": "2"},
+	}})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+
+	labels := make(map[string]string)
+	for i := 0; i < 3; i++ {
+		labels[fmt.Sprintf("label-%d", i)] = "value"
+	}
+	s = check(domain.BothMeta{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{"foo": strings.Repeat("a", 98)},
+		Labels:      labels,
+	}})
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 2)
+	assert.Equal(t, "The annotations of the object are too large", s.Comments[0].Summary)
+	assert.Contains(t, s.Comments[0].Description, "The annotations are 101 bytes, which is more than 100 bytes")
+	assert.Contains(t, s.Comments[1].Description, "The object has 3 labels, which is more than 2")
+}
+
+func TestObjectMetadataSizeComment(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Makes sure that the annotations of objects are not larger than 256KiB, and that objects have at most 64 labels. "+
+		"The thresholds can be changed with --max-annotation-bytes and --max-labels", objectMetadataSizeComment(DefaultMaxAnnotationBytes, DefaultMaxLabels))
+	assert.Contains(t, objectMetadataSizeComment(1000, 10), "not larger than 1000 bytes, and that objects have at most 10 labels")
+}
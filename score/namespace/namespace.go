@@ -0,0 +1,49 @@
+package namespace
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/zegl/kube-score/score/checks"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func Register(allChecks *checks.Checks) {
+	allChecks.RegisterOptionalNamespaceCheck("Namespace Pod Security Labels", `Makes sure that all Namespaces have a pod-security.kubernetes.io/enforce label, that enforces a Pod Security Standard`, namespacePodSecurityLabels)
+}
+
+const (
+	// podSecurityLabelPrefix is the prefix of the labels that configure Pod Security Admission for a Namespace
+	podSecurityLabelPrefix = "pod-security.kubernetes.io/"
+
+	// podSecurityEnforceLabel is the label that decides which pods are rejected by Pod Security Admission
+	podSecurityEnforceLabel = podSecurityLabelPrefix + "enforce"
+)
+
+// podSecurityModes are the modes of Pod Security Admission, each mode is configured with a separate label
+var podSecurityModes = []string{"enforce", "audit", "warn"}
+
+// namespacePodSecurityLabels checks that the Namespace enforces a Pod Security Standard. Only the enforce label
+// affects the grade, the audit and warn labels are listed together with it if they are also missing.
+func namespacePodSecurityLabels(namespace corev1.Namespace) (score scorecard.TestScore) {
+	if _, ok := namespace.Labels[podSecurityEnforceLabel]; ok {
+		score.Grade = scorecard.GradeAllOK
+		return
+	}
+
+	var missing []string
+	for _, mode := range podSecurityModes {
+		if _, ok := namespace.Labels[podSecurityLabelPrefix+mode]; !ok {
+			missing = append(missing, podSecurityLabelPrefix+mode)
+		}
+	}
+
+	score.Grade = scorecard.GradeWarning
+	score.AddComment("", "The Namespace does not enforce a Pod Security Standard",
+		fmt.Sprintf("The Namespace is missing the labels %s. "+
+			"Set %s to baseline or restricted, to reject pods that don't follow the standard.", strings.Join(missing, ", "), podSecurityEnforceLabel),
+	)
+	return
+}
@@ -0,0 +1,33 @@
+package score
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func testNamespacePodSecurityLabels(t *testing.T, filename string, expectedScore scorecard.Grade) []scorecard.TestScoreComment {
+	return testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile(filename)},
+		EnabledOptionalTests: map[string]struct{}{"namespace-pod-security-labels": {}},
+		KubernetesVersion:    config.Semver{1, 18},
+	}, "Namespace Pod Security Labels", expectedScore)
+}
+
+func TestNamespacePodSecurityLabelsMissing(t *testing.T) {
+	t.Parallel()
+	comments := testNamespacePodSecurityLabels(t, "namespace-pod-security-labels-missing.yaml", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "The Namespace does not enforce a Pod Security Standard", comments[0].Summary)
+	assert.Contains(t, comments[0].Description, "pod-security.kubernetes.io/enforce, pod-security.kubernetes.io/audit.")
+	assert.NotContains(t, comments[0].Description, "pod-security.kubernetes.io/warn")
+}
+
+func TestNamespacePodSecurityLabelsSet(t *testing.T) {
+	t.Parallel()
+	testNamespacePodSecurityLabels(t, "namespace-pod-security-labels-set.yaml", scorecard.GradeAllOK)
+}
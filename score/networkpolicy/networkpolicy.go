@@ -13,7 +13,9 @@ import (
 
 func Register(allChecks *checks.Checks, netpols ks.NetworkPolicies, pods ks.Pods, podspecers ks.PodSpeccers) {
 	allChecks.RegisterPodCheck("Pod NetworkPolicy", `Makes sure that all Pods are targeted by a NetworkPolicy`, podHasNetworkPolicy(netpols.NetworkPolicies()))
+	allChecks.CrossObject("Pod NetworkPolicy")
 	allChecks.RegisterNetworkPolicyCheck("NetworkPolicy targets Pod", `Makes sure that all NetworkPolicies targets at least one Pod`, networkPolicyTargetsPod(pods.Pods(), podspecers.PodSpeccers()))
+	allChecks.CrossObject("NetworkPolicy targets Pod")
 }
 
 // podHasNetworkPolicy returns a function that tests that all pods have matching NetworkPolicies
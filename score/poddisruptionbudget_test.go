@@ -3,6 +3,10 @@ package score
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/scorecard"
 )
 
@@ -26,32 +30,63 @@ func TestStatefulSetPodDisruptionBudgetNoMatch(t *testing.T) {
 	testExpectedScore(t, "statefulset-poddisruptionbudget-v1beta1-no-match.yaml", "StatefulSet has PodDisruptionBudget", scorecard.GradeCritical)
 }
 
+// testDeploymentPodDisruptionBudget runs the optional deployment-has-poddisruptionbudget check against the file
+func testDeploymentPodDisruptionBudget(t *testing.T, filename string, expectedScore scorecard.Grade) []scorecard.TestScoreComment {
+	return testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile(filename)},
+		EnabledOptionalTests: map[string]struct{}{"deployment-has-poddisruptionbudget": {}},
+	}, "Deployment has PodDisruptionBudget", expectedScore)
+}
+
 func TestDeploymentPodDisruptionBudgetMatches(t *testing.T) {
 	t.Parallel()
-	testExpectedScore(t, "deployment-poddisruptionbudget-v1beta1-matches.yaml", "Deployment has PodDisruptionBudget", scorecard.GradeAllOK)
+	testDeploymentPodDisruptionBudget(t, "deployment-poddisruptionbudget-v1beta1-matches.yaml", scorecard.GradeAllOK)
 }
 
 func TestDeploymentPodDisruptionBudgetExpressionMatches(t *testing.T) {
 	t.Parallel()
-	testExpectedScore(t, "deployment-poddisruptionbudget-v1beta1-expression-matches.yaml", "Deployment has PodDisruptionBudget", scorecard.GradeAllOK)
+	testDeploymentPodDisruptionBudget(t, "deployment-poddisruptionbudget-v1beta1-expression-matches.yaml", scorecard.GradeAllOK)
 }
 
 func TestDeploymentPodDisruptionBudgetExpressionNoMatch(t *testing.T) {
 	t.Parallel()
-	testExpectedScore(t, "deployment-poddisruptionbudget-v1beta1-expression-no-match.yaml", "Deployment has PodDisruptionBudget", scorecard.GradeCritical)
+	testDeploymentPodDisruptionBudget(t, "deployment-poddisruptionbudget-v1beta1-expression-no-match.yaml", scorecard.GradeWarning)
 }
 
 func TestDeploymentPodDisruptionBudgetNoMatch(t *testing.T) {
 	t.Parallel()
-	testExpectedScore(t, "deployment-poddisruptionbudget-v1beta1-no-match.yaml", "Deployment has PodDisruptionBudget", scorecard.GradeCritical)
+	testDeploymentPodDisruptionBudget(t, "deployment-poddisruptionbudget-v1beta1-no-match.yaml", scorecard.GradeWarning)
 }
 
 func TestDeploymentPodDisruptionBudgetV1Matches(t *testing.T) {
 	t.Parallel()
-	testExpectedScore(t, "deployment-poddisruptionbudget-v1-matches.yaml", "Deployment has PodDisruptionBudget", scorecard.GradeAllOK)
+	testDeploymentPodDisruptionBudget(t, "deployment-poddisruptionbudget-v1-matches.yaml", scorecard.GradeAllOK)
 }
 
 func TestDeploymentPodDisruptionBudgetV1NoMatch(t *testing.T) {
 	t.Parallel()
-	testExpectedScore(t, "deployment-poddisruptionbudget-v1-no-match.yaml", "Deployment has PodDisruptionBudget", scorecard.GradeCritical)
+	comments := testDeploymentPodDisruptionBudget(t, "deployment-poddisruptionbudget-v1-no-match.yaml", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "No matching PodDisruptionBudget was found for Deployment statefulset-test-1", comments[0].Summary)
+}
+
+func TestDeploymentPodDisruptionBudgetTargetedByHPA(t *testing.T) {
+	t.Parallel()
+	// Deployments that are scaled by an HPA don't have replicas set, and are checked
+	comments := testDeploymentPodDisruptionBudget(t, "deployment-with-hpa-not-has-replicas.yaml", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "No matching PodDisruptionBudget was found for Deployment php-apache", comments[0].Summary)
+}
+
+func TestDeploymentPodDisruptionBudgetOptional(t *testing.T) {
+	t.Parallel()
+	sc, err := testScore(config.Configuration{
+		AllFiles: []ks.NamedReader{testFile("deployment-poddisruptionbudget-v1-no-match.yaml")},
+	})
+	assert.Nil(t, err)
+	for _, o := range sc {
+		for _, c := range o.Checks {
+			assert.NotEqual(t, "deployment-has-poddisruptionbudget", c.Check.ID)
+		}
+	}
 }
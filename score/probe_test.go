@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/scorecard"
 )
 
@@ -96,3 +98,136 @@ func TestProbesMultipleContainersInit(t *testing.T) {
 	comments := testExpectedScore(t, "pod-probes-on-different-containers-init.yaml", "Pod Probes", scorecard.GradeAllOK)
 	assert.Len(t, comments, 0)
 }
+
+func TestReadinessProbeForServiceMissing(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("pod-readiness-probe-for-service.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"pod-readiness-probe-for-service": {}},
+	}, "Pod Readiness Probe For Service", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "app", comments[0].Path)
+	assert.Equal(t, "Container receives traffic from the Service my-service, but has no readinessProbe", comments[0].Summary)
+}
+
+func TestReadinessProbeForServiceOK(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("pod-readiness-probe-for-service-ok.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"pod-readiness-probe-for-service": {}},
+	}, "Pod Readiness Probe For Service", scorecard.GradeAllOK)
+	assert.Len(t, comments, 0)
+}
+
+func TestReadinessProbeForServiceNotTargeted(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("pod-probes-not-targeted-by-service.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"pod-readiness-probe-for-service": {}},
+	}, "Pod Readiness Probe For Service", scorecard.GradeAllOK)
+	assert.Len(t, comments, 0)
+}
+
+func testPreStopForGracefulShutdown(t *testing.T, filename string, expectedScore scorecard.Grade) []scorecard.TestScoreComment {
+	return testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile(filename)},
+		EnabledOptionalTests: map[string]struct{}{"pod-prestop-for-graceful-shutdown": {}},
+	}, "Pod PreStop For Graceful Shutdown", expectedScore)
+}
+
+func TestPreStopForGracefulShutdownMissing(t *testing.T) {
+	t.Parallel()
+	comments := testPreStopForGracefulShutdown(t, "deployment-prestop-for-graceful-shutdown.yaml", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "app", comments[0].Path)
+	assert.Equal(t, "Container receives traffic from the Service my-service, but has no preStop hook", comments[0].Summary)
+	assert.Contains(t, comments[0].Description, "terminationGracePeriodSeconds of 30")
+}
+
+func TestPreStopForGracefulShutdownOK(t *testing.T) {
+	t.Parallel()
+	comments := testPreStopForGracefulShutdown(t, "deployment-prestop-for-graceful-shutdown-ok.yaml", scorecard.GradeAllOK)
+	assert.Len(t, comments, 0)
+}
+
+func TestPreStopForGracefulShutdownLongGracePeriod(t *testing.T) {
+	t.Parallel()
+	comments := testPreStopForGracefulShutdown(t, "deployment-prestop-for-graceful-shutdown-long-grace-period.yaml", scorecard.GradeAllOK)
+	assert.Len(t, comments, 0)
+}
+
+func TestPreStopForGracefulShutdownNotTargeted(t *testing.T) {
+	t.Parallel()
+	comments := testPreStopForGracefulShutdown(t, "pod-probes-not-targeted-by-service.yaml", scorecard.GradeAllOK)
+	assert.Len(t, comments, 0)
+}
+
+func TestPortNameConsistency(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScore(t, "pod-port-name-consistency.yaml", "Port Name Consistency", scorecard.GradeCritical)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "foo", comments[0].Path)
+	assert.Equal(t, "The livenessProbe references the undefined port health", comments[0].Summary)
+	assert.Equal(t, "The Service foo-service targets the undefined port web", comments[1].Summary)
+}
+
+func TestPortNameConsistencyOK(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "pod-port-name-consistency-ok.yaml", "Port Name Consistency", scorecard.GradeAllOK)
+}
+
+func TestProbePreferHTTP(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("pod-probe-prefer-http.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"probe-prefer-http": {}},
+	}, "Probe Prefer HTTP", scorecard.GradeWarning)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "web", comments[0].Path)
+	assert.Equal(t, "The readinessProbe uses tcpSocket, but the container exposes the HTTP port http-api", comments[0].Summary)
+	assert.Equal(t, "proxy", comments[1].Path)
+	assert.Equal(t, "The livenessProbe uses tcpSocket, but the container exposes the HTTP port 8080", comments[1].Summary)
+}
+
+func testContainerPortUnexposed(t *testing.T, filename string, expectedScore scorecard.Grade) []scorecard.TestScoreComment {
+	return testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile(filename)},
+		EnabledOptionalTests: map[string]struct{}{"container-port-unexposed": {}},
+	}, "Container Port Unexposed", expectedScore)
+}
+
+func TestContainerPortUnexposed(t *testing.T) {
+	t.Parallel()
+	comments := testContainerPortUnexposed(t, "deployment-container-port-unexposed.yaml", scorecard.GradeWarning)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "app", comments[0].Path)
+	assert.Equal(t, "The port metrics (9090) is not targeted by any Service", comments[0].Summary)
+	assert.Equal(t, "sidecar", comments[1].Path)
+	assert.Equal(t, "The port 9001 is not targeted by any Service", comments[1].Summary)
+}
+
+func TestContainerPortUnexposedOK(t *testing.T) {
+	t.Parallel()
+	comments := testContainerPortUnexposed(t, "deployment-prestop-for-graceful-shutdown-ok.yaml", scorecard.GradeAllOK)
+	assert.Len(t, comments, 0)
+}
+
+func TestContainerPortUnexposedNoServices(t *testing.T) {
+	t.Parallel()
+	comments := testContainerPortUnexposed(t, "pod-seccomp-profile-field-missing.yaml", scorecard.GradeAllOK)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "Skipped because there are no Services in the namespace", comments[0].Summary)
+}
+
+func TestServiceWithoutSelectorTargetsNoPods(t *testing.T) {
+	t.Parallel()
+	for _, check := range []string{"Pod Probes", "Port Name Consistency", "Pod Readiness Probe For Service", "Pod PreStop For Graceful Shutdown"} {
+		testExpectedScoreWithConfig(t, config.Configuration{
+			AllFiles: []ks.NamedReader{testFile("service-without-selector.yaml")},
+			EnabledOptionalTests: map[string]struct{}{
+				"pod-readiness-probe-for-service":   {},
+				"pod-prestop-for-graceful-shutdown": {},
+			},
+		}, check, scorecard.GradeAllOK)
+	}
+}
@@ -1,8 +1,12 @@
 package probes
 
 import (
+	"fmt"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/score/checks"
@@ -12,6 +16,27 @@ import (
 
 func Register(allChecks *checks.Checks, services ks.Services) {
 	allChecks.RegisterPodCheck("Pod Probes", `Makes sure that all Pods have safe probe configurations`, containerProbes(services.Services()))
+	allChecks.CrossObject("Pod Probes")
+	allChecks.RegisterPodCheck("Port Name Consistency", `Makes sure that all ports that are referenced by name from probes and Services are defined on the container`, portNameConsistency(services.Services()))
+	allChecks.CrossObject("Port Name Consistency")
+	allChecks.RegisterOptionalPodCheck("Pod Readiness Probe For Service", `Makes sure that all containers that receive traffic from a Service have a readinessProbe`, readinessProbeForService(services.Services()))
+	allChecks.CrossObject("Pod Readiness Probe For Service")
+	allChecks.RegisterOptionalPodCheck("Pod PreStop For Graceful Shutdown", `Makes sure that pods that receive traffic from a Service, and have a short terminationGracePeriodSeconds, have a container with a preStop hook`, preStopForGracefulShutdown(services.Services()))
+	allChecks.CrossObject("Pod PreStop For Graceful Shutdown")
+	allChecks.RegisterOptionalPodCheck("Container Port Unexposed", `Makes sure that all ports that are declared by containers are targeted by a Service`, containerPortUnexposed(services.Services()))
+	allChecks.CrossObject("Container Port Unexposed")
+	allChecks.RegisterOptionalPodCheck("Probe Prefer HTTP", `Makes sure that containers that expose a HTTP port use httpGet instead of tcpSocket for readiness and liveness probes`, probePreferHTTP)
+	allChecks.RegisterOptionalPodObjectCheck("Probe Threshold Tuning", `Makes sure that livenessProbes don't restart the container after a single failure, or within 10 seconds, the thresholds can be changed with the kube-score/probe-min-failure-threshold and kube-score/probe-min-time-to-restart-seconds annotations`, probeThresholdTuning)
+}
+
+// HTTPPorts is the list of well-known port numbers that are assumed to serve HTTP, used by the "Probe Prefer HTTP" check.
+// Ports named "http" or "http-*" are also assumed to serve HTTP.
+var HTTPPorts = map[int32]struct{}{
+	80:   {},
+	443:  {},
+	8000: {},
+	8080: {},
+	8443: {},
 }
 
 // containerProbes returns a function that checks if all probes are defined correctly in the Pod.
@@ -130,7 +155,353 @@ func containerProbes(allServices []ks.Service) func(corev1.PodTemplateSpec, meta
 	}
 }
 
+// readinessProbeForService returns a function that checks that all containers that are targeted by a Service
+// have a readinessProbe
+func readinessProbeForService(allServices []ks.Service) func(corev1.PodTemplateSpec, metav1.TypeMeta) scorecard.TestScore {
+	return func(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+		score.Grade = scorecard.GradeAllOK
+
+		targetingServices, targetedContainers := containersTargetedByServices(podTemplate, allServices)
+		if len(targetingServices) == 0 {
+			return
+		}
+
+		for _, container := range targetedContainers {
+			if container.ReadinessProbe != nil {
+				continue
+			}
+
+			score.Grade = scorecard.GradeWarning
+			score.AddCommentWithURL(container.Name,
+				fmt.Sprintf("Container receives traffic from the Service %s, but has no readinessProbe", targetingServices[0].Name),
+				"Without a readinessProbe, the container will receive traffic from the Service before it's ready to handle it.",
+				"https://github.com/zegl/kube-score/blob/master/README_PROBES.md",
+			)
+		}
+
+		return
+	}
+}
+
+// shortTerminationGracePeriodSeconds is the longest terminationGracePeriodSeconds that is considered to be short by the
+// "Pod PreStop For Graceful Shutdown" check, it's the same as the default grace period
+const shortTerminationGracePeriodSeconds = 30
+
+// preStopForGracefulShutdown returns a function that checks that pods that are targeted by a Service, and have a short
+// grace period, have at least one container with a preStop hook. The Service keeps sending traffic to a terminating pod
+// until the endpoints have been updated, a short sleep in the preStop hook delays the SIGTERM until that has happened.
+func preStopForGracefulShutdown(allServices []ks.Service) func(corev1.PodTemplateSpec, metav1.TypeMeta) scorecard.TestScore {
+	return func(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+		score.Grade = scorecard.GradeAllOK
+
+		gracePeriod := int64(shortTerminationGracePeriodSeconds)
+		if podTemplate.Spec.TerminationGracePeriodSeconds != nil {
+			gracePeriod = *podTemplate.Spec.TerminationGracePeriodSeconds
+		}
+		if gracePeriod > shortTerminationGracePeriodSeconds {
+			return
+		}
+
+		for _, container := range podTemplate.Spec.Containers {
+			if container.Lifecycle != nil && container.Lifecycle.PreStop != nil {
+				return
+			}
+		}
+
+		targetingServices, targetedContainers := containersTargetedByServices(podTemplate, allServices)
+		if len(targetingServices) == 0 {
+			return
+		}
+
+		for _, container := range targetedContainers {
+			score.Grade = scorecard.GradeWarning
+			score.AddComment(container.Name,
+				fmt.Sprintf("Container receives traffic from the Service %s, but has no preStop hook", targetingServices[0].Name),
+				fmt.Sprintf("The Service can send traffic to the container for a short while after it has received SIGTERM, and the pod "+
+					"has a terminationGracePeriodSeconds of %d. Add a preStop hook that sleeps for a few seconds, such as exec: "+
+					"{command: [sleep, \"5\"]}, so that the container keeps serving traffic until it has been removed from the Service.", gracePeriod),
+			)
+		}
+
+		return
+	}
+}
+
+// containerPortUnexposed returns a function that checks that all ports declared by the containers are targeted by a
+// Service that selects the pod. Pods in namespaces without Services are skipped, as the Services are likely not part of
+// the input.
+func containerPortUnexposed(allServices []ks.Service) func(corev1.PodTemplateSpec, metav1.TypeMeta) scorecard.TestScore {
+	return func(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+		var servicesInNamespace int
+		var targetingServices []corev1.Service
+		for _, s := range allServices {
+			service := s.Service()
+			if service.Namespace != podTemplate.Namespace {
+				continue
+			}
+			servicesInNamespace++
+			if podIsTargetedByService(podTemplate, service) {
+				targetingServices = append(targetingServices, service)
+			}
+		}
+
+		if servicesInNamespace == 0 {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", "Skipped because there are no Services in the namespace", "")
+			return
+		}
+
+		score.Grade = scorecard.GradeAllOK
+		for _, container := range podTemplate.Spec.Containers {
+			for _, containerPort := range container.Ports {
+				if portIsTargetedByServices(containerPort, targetingServices) {
+					continue
+				}
+
+				name := fmt.Sprintf("%d", containerPort.ContainerPort)
+				if containerPort.Name != "" {
+					name = fmt.Sprintf("%s (%d)", containerPort.Name, containerPort.ContainerPort)
+				}
+				score.Grade = scorecard.GradeWarning
+				score.AddComment(container.Name,
+					fmt.Sprintf("The port %s is not targeted by any Service", name),
+					"The container declares the port, but no Service that selects the pod sends traffic to it. "+
+						"Add the port to a Service, or remove it from the container if it's no longer used.",
+				)
+			}
+		}
+
+		return
+	}
+}
+
+func portIsTargetedByServices(containerPort corev1.ContainerPort, services []corev1.Service) bool {
+	for _, service := range services {
+		for _, servicePort := range service.Spec.Ports {
+			if servicePortTargetsContainerPort(servicePort, containerPort) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// portNameConsistency returns a function that checks that all named ports that are referenced by the probes of a container
+// are defined on the same container, and that all named target ports of the Services that target the pod are defined on
+// any of the containers in the pod.
+func portNameConsistency(allServices []ks.Service) func(corev1.PodTemplateSpec, metav1.TypeMeta) scorecard.TestScore {
+	return func(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+		score.Grade = scorecard.GradeAllOK
+
+		podPortNames := make(map[string]struct{})
+		for _, container := range podTemplate.Spec.Containers {
+			for _, port := range container.Ports {
+				if port.Name != "" {
+					podPortNames[port.Name] = struct{}{}
+				}
+			}
+		}
+
+		for _, container := range podTemplate.Spec.Containers {
+			containerPortNames := make(map[string]struct{})
+			for _, port := range container.Ports {
+				if port.Name != "" {
+					containerPortNames[port.Name] = struct{}{}
+				}
+			}
+
+			probes := []struct {
+				name  string
+				probe *corev1.Probe
+			}{
+				{"readinessProbe", container.ReadinessProbe},
+				{"livenessProbe", container.LivenessProbe},
+				{"startupProbe", container.StartupProbe},
+			}
+
+			for _, p := range probes {
+				portName, ok := probePortName(p.probe)
+				if !ok {
+					continue
+				}
+				if _, ok := containerPortNames[portName]; ok {
+					continue
+				}
+
+				score.Grade = scorecard.GradeCritical
+				score.AddComment(container.Name,
+					fmt.Sprintf("The %s references the undefined port %s", p.name, portName),
+					fmt.Sprintf("The %s uses the named port %s, but the container does not define a port with that name. The probe will always fail. Add the port to the container, or reference the port by number.", p.name, portName),
+				)
+			}
+		}
+
+		for _, s := range allServices {
+			service := s.Service()
+			if !podIsTargetedByService(podTemplate, service) {
+				continue
+			}
+
+			for _, servicePort := range service.Spec.Ports {
+				if servicePort.TargetPort.Type != intstr.String {
+					continue
+				}
+				if _, ok := podPortNames[servicePort.TargetPort.StrVal]; ok {
+					continue
+				}
+
+				score.Grade = scorecard.GradeCritical
+				score.AddComment("",
+					fmt.Sprintf("The Service %s targets the undefined port %s", service.Name, servicePort.TargetPort.StrVal),
+					fmt.Sprintf("The Service %s has a targetPort that references the named port %s, but no container in the pod defines a port with that name. The Service will not be able to send traffic to the pod.", service.Name, servicePort.TargetPort.StrVal),
+				)
+			}
+		}
+
+		return
+	}
+}
+
+// probePreferHTTP checks that containers that expose a HTTP port don't use tcpSocket readiness or liveness probes,
+// as a TCP probe only verifies that the port is open, and not that the application is able to serve requests.
+func probePreferHTTP(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	for _, container := range podTemplate.Spec.Containers {
+		httpPort, ok := containerHTTPPort(container)
+		if !ok {
+			continue
+		}
+
+		probes := []struct {
+			name  string
+			probe *corev1.Probe
+		}{
+			{"readinessProbe", container.ReadinessProbe},
+			{"livenessProbe", container.LivenessProbe},
+		}
+
+		for _, p := range probes {
+			if p.probe == nil || p.probe.TCPSocket == nil {
+				continue
+			}
+
+			score.Grade = scorecard.GradeWarning
+			score.AddCommentWithURL(container.Name,
+				fmt.Sprintf("The %s uses tcpSocket, but the container exposes the HTTP port %s", p.name, httpPort),
+				"A tcpSocket probe only verifies that the port is open, and not that the application is healthy. "+
+					"Use a httpGet probe against a health endpoint for a better signal.",
+				"https://github.com/zegl/kube-score/blob/master/README_PROBES.md",
+			)
+		}
+	}
+
+	return
+}
+
+// containerHTTPPort returns the name (or number if unnamed) of the first port of the container that is assumed to serve HTTP
+func containerHTTPPort(container corev1.Container) (string, bool) {
+	for _, port := range container.Ports {
+		if port.Name == "http" || strings.HasPrefix(port.Name, "http-") {
+			return port.Name, true
+		}
+		if _, ok := HTTPPorts[port.ContainerPort]; ok {
+			if port.Name != "" {
+				return port.Name, true
+			}
+			return fmt.Sprintf("%d", port.ContainerPort), true
+		}
+	}
+	return "", false
+}
+
+// probePortName returns the name of the port that is used by a HTTP or TCP probe, if the port is referenced by name
+func probePortName(probe *corev1.Probe) (string, bool) {
+	if probe == nil {
+		return "", false
+	}
+
+	var port intstr.IntOrString
+	switch {
+	case probe.HTTPGet != nil:
+		port = probe.HTTPGet.Port
+	case probe.TCPSocket != nil:
+		port = probe.TCPSocket.Port
+	default:
+		return "", false
+	}
+
+	if port.Type != intstr.String {
+		return "", false
+	}
+	return port.StrVal, true
+}
+
+// containersTargetedByServices returns the Services that target the pod, and the containers that receive traffic from
+// them. A container is targeted if it exposes a port that is the targetPort of a Service. If no container exposes a
+// targeted port, all containers in the pod are assumed to be targeted.
+func containersTargetedByServices(podTemplate corev1.PodTemplateSpec, allServices []ks.Service) ([]corev1.Service, []corev1.Container) {
+	var targetingServices []corev1.Service
+	for _, s := range allServices {
+		if podIsTargetedByService(podTemplate, s.Service()) {
+			targetingServices = append(targetingServices, s.Service())
+		}
+	}
+
+	if len(targetingServices) == 0 {
+		return nil, nil
+	}
+
+	var targetedContainers []corev1.Container
+	for _, container := range podTemplate.Spec.Containers {
+		for _, s := range targetingServices {
+			if containerIsTargetedByService(container, s) {
+				targetedContainers = append(targetedContainers, container)
+				break
+			}
+		}
+	}
+
+	if len(targetedContainers) == 0 {
+		targetedContainers = podTemplate.Spec.Containers
+	}
+
+	return targetingServices, targetedContainers
+}
+
+// containerIsTargetedByService returns true if the container exposes any of the target ports of the Service
+func containerIsTargetedByService(container corev1.Container, service corev1.Service) bool {
+	for _, servicePort := range service.Spec.Ports {
+		for _, containerPort := range container.Ports {
+			if servicePortTargetsContainerPort(servicePort, containerPort) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// servicePortTargetsContainerPort returns true if the targetPort of the servicePort references the containerPort,
+// either by name or by number. If the targetPort is not set, the port of the Service is used.
+func servicePortTargetsContainerPort(servicePort corev1.ServicePort, containerPort corev1.ContainerPort) bool {
+	if servicePort.TargetPort.Type == intstr.String {
+		return servicePort.TargetPort.StrVal == containerPort.Name
+	}
+
+	targetPort := servicePort.TargetPort.IntVal
+	if targetPort == 0 {
+		targetPort = servicePort.Port
+	}
+	return targetPort == containerPort.ContainerPort
+}
+
+// podIsTargetedByService returns true if the selector of the Service matches the labels of the pod. Services without a
+// selector, such as Services for external endpoints, never target any pods.
 func podIsTargetedByService(pod corev1.PodTemplateSpec, service corev1.Service) bool {
+	if len(service.Spec.Selector) == 0 {
+		return false
+	}
 	if pod.Namespace != service.Namespace {
 		return false
 	}
@@ -0,0 +1,79 @@
+package probes
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/score/internal"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// ProbeMinFailureThreshold and ProbeMinTimeToRestartSeconds are the default thresholds of the
+// "Probe Threshold Tuning" check
+var (
+	ProbeMinFailureThreshold     int32 = 2
+	ProbeMinTimeToRestartSeconds int32 = 10
+)
+
+const (
+	// minFailureThresholdAnnotation and minTimeToRestartAnnotation overrides the thresholds used by the
+	// "Probe Threshold Tuning" check
+	minFailureThresholdAnnotation = "kube-score/probe-min-failure-threshold"
+	minTimeToRestartAnnotation    = "kube-score/probe-min-time-to-restart-seconds"
+
+	// The defaults that are set by the API server if the fields are not set, or are zero
+	defaultFailureThreshold int32 = 3
+	defaultPeriodSeconds    int32 = 10
+)
+
+// probeThresholdTuning checks that liveness probes don't restart the container after a single failure, or after a
+// short time. The time to restart is the number of consecutive failures times the period between the probes.
+func probeThresholdTuning(meta metav1.ObjectMeta, podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	minFailureThreshold := internal.Int32Annotation(&score, meta, minFailureThresholdAnnotation, ProbeMinFailureThreshold)
+	minTimeToRestart := internal.Int32Annotation(&score, meta, minTimeToRestartAnnotation, ProbeMinTimeToRestartSeconds)
+
+	for _, container := range podTemplate.Spec.Containers {
+		probe := container.LivenessProbe
+		if probe == nil {
+			continue
+		}
+
+		failureThreshold := probe.FailureThreshold
+		if failureThreshold <= 0 {
+			failureThreshold = defaultFailureThreshold
+		}
+		periodSeconds := probe.PeriodSeconds
+		if periodSeconds <= 0 {
+			periodSeconds = defaultPeriodSeconds
+		}
+		timeToRestart := failureThreshold * periodSeconds
+
+		description := fmt.Sprintf("The container is restarted after %d consecutive failures, %ds apart, about %ds after the application stops responding. "+
+			"A short garbage collection pause or a slow dependency can cause unnecessary restarts. "+
+			"Raise livenessProbe.failureThreshold or livenessProbe.periodSeconds, so that the container is only restarted if it does not recover.",
+			failureThreshold, periodSeconds, timeToRestart)
+
+		if failureThreshold < minFailureThreshold {
+			score.Grade = scorecard.GradeWarning
+			score.AddComment(container.Name,
+				fmt.Sprintf("The livenessProbe restarts the container after %d failure(s)", failureThreshold),
+				description,
+			)
+			continue
+		}
+
+		if timeToRestart < minTimeToRestart {
+			score.Grade = scorecard.GradeWarning
+			score.AddComment(container.Name,
+				fmt.Sprintf("The livenessProbe restarts the container after %ds", timeToRestart),
+				description,
+			)
+		}
+	}
+
+	return
+}
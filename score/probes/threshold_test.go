@@ -0,0 +1,68 @@
+package probes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestProbeThresholdTuning(t *testing.T) {
+	t.Parallel()
+
+	pod := func(probe *v1.Probe) v1.PodTemplateSpec {
+		return v1.PodTemplateSpec{
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{Name: "app", LivenessProbe: probe}},
+			},
+		}
+	}
+
+	cases := []struct {
+		name        string
+		annotations map[string]string
+		probe       *v1.Probe
+		grade       scorecard.Grade
+		summary     string
+	}{
+		{"no probe", nil, nil, scorecard.GradeAllOK, ""},
+		{"defaults", nil, &v1.Probe{}, scorecard.GradeAllOK, ""},
+		{"single failure", nil, &v1.Probe{FailureThreshold: 1, PeriodSeconds: 30}, scorecard.GradeWarning, "The livenessProbe restarts the container after 1 failure(s)"},
+		{"tight timing", nil, &v1.Probe{FailureThreshold: 3, PeriodSeconds: 2}, scorecard.GradeWarning, "The livenessProbe restarts the container after 6s"},
+		{"forgiving", nil, &v1.Probe{FailureThreshold: 5, PeriodSeconds: 5}, scorecard.GradeAllOK, ""},
+		{
+			"lowered thresholds",
+			map[string]string{"kube-score/probe-min-failure-threshold": "1", "kube-score/probe-min-time-to-restart-seconds": "5"},
+			&v1.Probe{FailureThreshold: 1, PeriodSeconds: 5},
+			scorecard.GradeAllOK, "",
+		},
+		{
+			"raised thresholds",
+			map[string]string{"kube-score/probe-min-time-to-restart-seconds": "60"},
+			&v1.Probe{},
+			scorecard.GradeWarning, "The livenessProbe restarts the container after 30s",
+		},
+		{
+			"invalid annotation",
+			map[string]string{"kube-score/probe-min-failure-threshold": "many"},
+			&v1.Probe{},
+			scorecard.GradeWarning, "The annotation kube-score/probe-min-failure-threshold has an invalid value",
+		},
+	}
+
+	for _, tc := range cases {
+		s := probeThresholdTuning(metav1.ObjectMeta{Annotations: tc.annotations}, pod(tc.probe), metav1.TypeMeta{})
+		assert.Equal(t, tc.grade, s.Grade, tc.name)
+		if tc.summary == "" {
+			assert.Len(t, s.Comments, 0, tc.name)
+		} else if assert.Len(t, s.Comments, 1, tc.name) {
+			assert.Equal(t, tc.summary, s.Comments[0].Summary, tc.name)
+		}
+	}
+
+	s := probeThresholdTuning(metav1.ObjectMeta{}, pod(&v1.Probe{FailureThreshold: 1, PeriodSeconds: 5}), metav1.TypeMeta{})
+	assert.Contains(t, s.Comments[0].Description, "about 5s after the application stops responding")
+}
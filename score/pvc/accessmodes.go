@@ -0,0 +1,110 @@
+package pvc
+
+import (
+	"fmt"
+	"regexp"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// DefaultReadWriteManyStorageClasses are the regular expressions of the StorageClasses that are expected to support
+// the ReadWriteMany access mode by the "StatefulSet VolumeClaim AccessModes" check, if no StorageClasses are
+// configured. Most block storage only supports a single node, so these match the names of common file storage
+// provisioners, such as NFS, Amazon EFS, Azure Files and Google Filestore.
+var DefaultReadWriteManyStorageClasses = []string{
+	".*nfs.*", ".*efs.*", ".*azurefile.*", ".*azure-file.*", ".*filestore.*", ".*cephfs.*", ".*glusterfs.*", ".*rwx.*",
+}
+
+// statefulSetVolumeClaimAccessModes returns a function that checks that all volumeClaimTemplates of StatefulSets
+// have an access mode, and warns about templates that use ReadWriteMany with a StorageClass that doesn't match any of
+// the patterns. The patterns must match the whole name of the StorageClass, patterns that are not valid regular
+// expressions are ignored.
+func statefulSetVolumeClaimAccessModes(patterns []string) func(appsv1.StatefulSet) (scorecard.TestScore, error) {
+	if len(patterns) == 0 {
+		patterns = DefaultReadWriteManyStorageClasses
+	}
+	var rwxClasses []*regexp.Regexp
+	for _, pattern := range patterns {
+		if r, err := regexp.Compile("^(?:" + pattern + ")$"); err == nil {
+			rwxClasses = append(rwxClasses, r)
+		}
+	}
+
+	supportsReadWriteMany := func(storageClass string) bool {
+		for _, r := range rwxClasses {
+			if r.MatchString(storageClass) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(statefulset appsv1.StatefulSet) (score scorecard.TestScore, err error) {
+		score.Grade = scorecard.GradeAllOK
+
+		for _, template := range statefulset.Spec.VolumeClaimTemplates {
+			if len(template.Spec.AccessModes) == 0 {
+				score.Grade = scorecard.GradeCritical
+				score.AddComment(template.Name,
+					fmt.Sprintf("The volumeClaimTemplate %s has no accessModes", template.Name),
+					"A PersistentVolumeClaim without accessModes is rejected, and the pods of the StatefulSet are never started. "+
+						"Set accessModes to ReadWriteOnce, or to ReadWriteOncePod, for volumes that are only used by a single pod.",
+				)
+				continue
+			}
+
+			if !hasAccessMode(template, corev1.ReadWriteMany) {
+				continue
+			}
+
+			// A storageClassName set to "" binds to a pre-provisioned volume, that can be of any kind
+			storageClass, ok := storageClassName(template)
+			if ok && storageClass == "" {
+				continue
+			}
+			if ok && supportsReadWriteMany(storageClass) {
+				continue
+			}
+
+			if score.Grade > scorecard.GradeWarning {
+				score.Grade = scorecard.GradeWarning
+			}
+			class := "the default StorageClass"
+			if ok {
+				class = "the StorageClass " + storageClass
+			}
+			score.AddComment(template.Name,
+				fmt.Sprintf("The volumeClaimTemplate %s uses ReadWriteMany with %s", template.Name, class),
+				"Most block storage only supports volumes that are mounted by a single node, and the volume can't be provisioned or the pods can't start. "+
+					"Each pod of a StatefulSet gets its own volume, so ReadWriteOnce is usually enough. "+
+					"Use a StorageClass that supports ReadWriteMany, such as NFS, or add its name with --rwx-storage-class.",
+			)
+		}
+
+		return
+	}
+}
+
+func hasAccessMode(pvc corev1.PersistentVolumeClaim, mode corev1.PersistentVolumeAccessMode) bool {
+	for _, m := range pvc.Spec.AccessModes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// storageClassName returns the StorageClass of the PersistentVolumeClaim, and false if it's not set and the default
+// StorageClass is used
+func storageClassName(pvc corev1.PersistentVolumeClaim) (string, bool) {
+	if pvc.Spec.StorageClassName != nil {
+		return *pvc.Spec.StorageClassName, true
+	}
+	if name, ok := pvc.Annotations[storageClassAnnotation]; ok {
+		return name, true
+	}
+	return "", false
+}
@@ -0,0 +1,62 @@
+package pvc
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/zegl/kube-score/config"
+	"github.com/zegl/kube-score/score/checks"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// storageClassAnnotation is the deprecated way of setting the StorageClass of a PersistentVolumeClaim
+const storageClassAnnotation = "volume.beta.kubernetes.io/storage-class"
+
+const missingStorageClassDescription = "Relying on the default StorageClass of the cluster can lead to different storage being provisioned in different environments. " +
+	"Set storageClassName explicitly, or set it to \"\" to bind to a pre-provisioned volume."
+
+func Register(allChecks *checks.Checks, cnf config.Configuration) {
+	allChecks.RegisterOptionalPersistentVolumeClaimCheck("PVC StorageClass", `Makes sure that PersistentVolumeClaims and StatefulSet volumeClaimTemplates have an explicit storageClassName set`, pvcHasStorageClass)
+	allChecks.RegisterOptionalStatefulSetCheck("PVC StorageClass", `Makes sure that PersistentVolumeClaims and StatefulSet volumeClaimTemplates have an explicit storageClassName set`, statefulSetHasStorageClass)
+	allChecks.RegisterStatefulSetCheck("StatefulSet VolumeClaim AccessModes", `Makes sure that all StatefulSet volumeClaimTemplates have accessModes set, and warns about ReadWriteMany with a StorageClass that is unlikely to support it. The StorageClasses can be changed with --rwx-storage-class`, statefulSetVolumeClaimAccessModes(cnf.ReadWriteManyStorageClasses))
+}
+
+func pvcHasStorageClass(pvc corev1.PersistentVolumeClaim) (score scorecard.TestScore) {
+	if hasStorageClass(pvc) {
+		score.Grade = scorecard.GradeAllOK
+		return
+	}
+
+	score.Grade = scorecard.GradeWarning
+	score.AddComment(pvc.Name, "The PersistentVolumeClaim does not have a storageClassName set", missingStorageClassDescription)
+	return
+}
+
+func statefulSetHasStorageClass(statefulset appsv1.StatefulSet) (score scorecard.TestScore, err error) {
+	score.Grade = scorecard.GradeAllOK
+
+	for _, template := range statefulset.Spec.VolumeClaimTemplates {
+		if hasStorageClass(template) {
+			continue
+		}
+
+		score.Grade = scorecard.GradeWarning
+		score.AddComment(template.Name,
+			fmt.Sprintf("The volumeClaimTemplate %s does not have a storageClassName set", template.Name),
+			missingStorageClassDescription,
+		)
+	}
+
+	return
+}
+
+// hasStorageClass returns true if the storageClassName has been set, including being explicitly set to ""
+func hasStorageClass(pvc corev1.PersistentVolumeClaim) bool {
+	if pvc.Spec.StorageClassName != nil {
+		return true
+	}
+	_, ok := pvc.Annotations[storageClassAnnotation]
+	return ok
+}
@@ -0,0 +1,102 @@
+package score
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestPvcStorageClassNotSet(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("pvc-storageclass-not-set.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"pvc-storageclass": {}},
+	}, "PVC StorageClass", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "data", comments[0].Path)
+}
+
+func TestPvcStorageClassSet(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("pvc-storageclass-set.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"pvc-storageclass": {}},
+	}, "PVC StorageClass", scorecard.GradeAllOK)
+}
+
+func TestPvcStorageClassEmpty(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("pvc-storageclass-empty.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"pvc-storageclass": {}},
+	}, "PVC StorageClass", scorecard.GradeAllOK)
+}
+
+func TestPvcStorageClassStatefulSetTemplateNotSet(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("statefulset-pvc-storageclass-not-set.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"pvc-storageclass": {}},
+	}, "PVC StorageClass", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "data", comments[0].Path)
+}
+
+func TestStatefulSetVolumeClaimAccessModes(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		rwxStorageClasses []string
+		expected          map[string]scorecard.Grade
+	}{
+		{
+			nil,
+			map[string]scorecard.Grade{
+				"no-access-modes":   scorecard.GradeCritical,
+				"rwx-default-class": scorecard.GradeWarning,
+				"rwx-nfs-class":     scorecard.GradeAllOK,
+			},
+		},
+		{
+			// Configured StorageClasses replace the defaults
+			[]string{"cephfs-.*"},
+			map[string]scorecard.Grade{
+				"no-access-modes":   scorecard.GradeCritical,
+				"rwx-default-class": scorecard.GradeWarning,
+				"rwx-nfs-class":     scorecard.GradeWarning,
+			},
+		},
+	} {
+		sc, err := testScore(config.Configuration{
+			AllFiles:                    []ks.NamedReader{testFile("statefulset-volumeclaim-accessmodes.yaml")},
+			ReadWriteManyStorageClasses: tc.rwxStorageClasses,
+		})
+		assert.NoError(t, err)
+
+		grades := make(map[string]scorecard.Grade)
+		for _, o := range sc {
+			for _, c := range o.Checks {
+				if c.Check.ID != "statefulset-volumeclaim-accessmodes" {
+					continue
+				}
+				grades[o.ObjectMeta.Name] = c.Grade
+
+				switch o.ObjectMeta.Name {
+				case "no-access-modes":
+					assert.Len(t, c.Comments, 1)
+					assert.Equal(t, "logs", c.Comments[0].Path)
+					assert.Equal(t, "The volumeClaimTemplate logs has no accessModes", c.Comments[0].Summary)
+				case "rwx-default-class":
+					assert.Len(t, c.Comments, 1)
+					assert.Equal(t, "shared", c.Comments[0].Path)
+					assert.Equal(t, "The volumeClaimTemplate shared uses ReadWriteMany with the default StorageClass", c.Comments[0].Summary)
+				}
+			}
+		}
+		assert.Equal(t, tc.expected, grades)
+	}
+}
@@ -0,0 +1,147 @@
+package rbac
+
+import (
+	"fmt"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/score/checks"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func Register(allChecks *checks.Checks, roles ks.Roles, bindings ks.RoleBindings) {
+	allChecks.RegisterOptionalRoleCheck("RBAC Wildcard", `Makes sure that Roles and ClusterRoles don't use wildcards in apiGroups, resources or verbs, and don't grant write access to sensitive resources`, rbacWildcard)
+	allChecks.RegisterOptionalPodCheck("ServiceAccount Cluster Admin", `Makes sure that the ServiceAccount of all pods is not bound to cluster-admin, or to a role that grants all verbs on all resources`, serviceAccountClusterAdmin(roles.Roles(), bindings.RoleBindings()))
+	allChecks.CrossObject("ServiceAccount Cluster Admin")
+}
+
+// SensitiveResources are the resources that allow reading credentials, or granting more permissions, if they can be
+// written to, used by the "RBAC Wildcard" check
+var SensitiveResources = map[string]struct{}{
+	"secrets":             {},
+	"roles":               {},
+	"rolebindings":        {},
+	"clusterroles":        {},
+	"clusterrolebindings": {},
+	"pods/exec":           {},
+}
+
+// WriteVerbs are the verbs that modify resources
+var WriteVerbs = map[string]struct{}{
+	"create":           {},
+	"update":           {},
+	"patch":            {},
+	"delete":           {},
+	"deletecollection": {},
+}
+
+// EscalationVerbs are the verbs that allow the subject to gain more permissions than it has been granted
+var EscalationVerbs = map[string]struct{}{
+	"bind":        {},
+	"escalate":    {},
+	"impersonate": {},
+}
+
+func rbacWildcard(role ks.Role) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	for i, rule := range role.Rules() {
+		path := fmt.Sprintf("rules[%d]", i)
+
+		var wildcards []string
+		for _, field := range []struct {
+			name   string
+			values []string
+		}{
+			{"apiGroups", rule.APIGroups},
+			{"resources", rule.Resources},
+			{"verbs", rule.Verbs},
+		} {
+			if contains(field.values, rbacv1.ResourceAll) {
+				wildcards = append(wildcards, field.name)
+			}
+		}
+
+		if len(wildcards) > 0 {
+			score.Grade = scorecard.GradeCritical
+			score.AddComment(path,
+				fmt.Sprintf("The rule uses * in %s", strings.Join(wildcards, ", ")),
+				fmt.Sprintf("The rule %s grants access to all current and future %s. List the apiGroups, resources and verbs that are needed instead.",
+					formatRule(rule), strings.Join(wildcards, ", ")),
+			)
+			continue
+		}
+
+		escalation := matching(rule.Verbs, EscalationVerbs)
+		if len(escalation) > 0 {
+			if score.Grade > scorecard.GradeWarning {
+				score.Grade = scorecard.GradeWarning
+			}
+			score.AddComment(path,
+				fmt.Sprintf("The rule grants %s", strings.Join(escalation, ", ")),
+				fmt.Sprintf("The rule %s allows the subject to gain permissions that it has not been granted. Remove the verbs, or limit the rule to specific resourceNames.",
+					formatRule(rule)),
+			)
+			continue
+		}
+
+		// Rules that are limited to specific objects are not broad
+		if len(rule.ResourceNames) > 0 {
+			continue
+		}
+
+		sensitive := matching(rule.Resources, SensitiveResources)
+		writes := matching(rule.Verbs, WriteVerbs)
+		if len(sensitive) > 0 && len(writes) > 0 {
+			if score.Grade > scorecard.GradeWarning {
+				score.Grade = scorecard.GradeWarning
+			}
+			score.AddComment(path,
+				fmt.Sprintf("The rule grants %s on %s", strings.Join(writes, ", "), strings.Join(sensitive, ", ")),
+				fmt.Sprintf("The rule %s allows writing to resources that can be used to read credentials or gain more permissions. Limit the rule to specific resourceNames, or to read-only verbs.",
+					formatRule(rule)),
+			)
+		}
+	}
+
+	return
+}
+
+// formatRule returns the apiGroups, resources and verbs of the rule, on the same format as in the object
+func formatRule(rule rbacv1.PolicyRule) string {
+	s := fmt.Sprintf("{apiGroups: %s, resources: %s, verbs: %s", formatList(rule.APIGroups), formatList(rule.Resources), formatList(rule.Verbs))
+	if len(rule.ResourceNames) > 0 {
+		s += fmt.Sprintf(", resourceNames: %s", formatList(rule.ResourceNames))
+	}
+	return s + "}"
+}
+
+func formatList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// matching returns the values that are in the set, in the same order as in values
+func matching(values []string, set map[string]struct{}) []string {
+	var res []string
+	for _, v := range values {
+		if _, ok := set[strings.ToLower(v)]; ok {
+			res = append(res, v)
+		}
+	}
+	return res
+}
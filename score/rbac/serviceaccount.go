@@ -0,0 +1,105 @@
+package rbac
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// ClusterAdminRoles are the built-in ClusterRoles that grant full access to the cluster, used by the
+// "ServiceAccount Cluster Admin" check
+var ClusterAdminRoles = map[string]struct{}{
+	"cluster-admin": {},
+}
+
+// serviceAccountClusterAdmin returns a function that checks if the ServiceAccount of the pod is bound to a role that
+// grants full access, either cluster-admin or a Role or ClusterRole in the input with a rule that grants all verbs on
+// all resources. Pods are skipped if there are no RoleBindings or ClusterRoleBindings in the input.
+func serviceAccountClusterAdmin(allRoles []ks.Role, allBindings []ks.RoleBinding) func(corev1.PodTemplateSpec, metav1.TypeMeta) scorecard.TestScore {
+	// Roles by namespace and name, ClusterRoles are in the "" namespace
+	broadRoles := make(map[string]struct{})
+	for _, role := range allRoles {
+		if grantsFullAccess(role.Rules()) {
+			broadRoles[roleKey(role.GetTypeMeta().Kind, role.GetObjectMeta().Namespace, role.GetObjectMeta().Name)] = struct{}{}
+		}
+	}
+
+	return func(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+		score.Grade = scorecard.GradeAllOK
+
+		if len(allBindings) == 0 {
+			score.Skipped = true
+			score.AddComment("", "Skipped because there are no RoleBindings or ClusterRoleBindings", "")
+			return
+		}
+
+		serviceAccount := podTemplate.Spec.ServiceAccountName
+		if serviceAccount == "" {
+			serviceAccount = "default"
+		}
+
+		for _, binding := range allBindings {
+			bindingMeta := binding.GetObjectMeta()
+			if !bindsServiceAccount(binding, serviceAccount, podTemplate.Namespace) {
+				continue
+			}
+
+			roleRef := binding.RoleRef()
+			_, isClusterAdmin := ClusterAdminRoles[roleRef.Name]
+			isClusterAdmin = isClusterAdmin && roleRef.Kind == "ClusterRole"
+			_, isBroad := broadRoles[roleKey(roleRef.Kind, bindingMeta.Namespace, roleRef.Name)]
+			if !isClusterAdmin && !isBroad {
+				continue
+			}
+
+			score.Grade = scorecard.GradeCritical
+			score.AddComment("",
+				fmt.Sprintf("The ServiceAccount %s is bound to the %s %s by the %s %s", serviceAccount, roleRef.Kind, roleRef.Name, binding.GetTypeMeta().Kind, bindingMeta.Name),
+				fmt.Sprintf("The %s %s grants all verbs on all resources, and anyone that can run code in the pod gets the same access. "+
+					"Bind the ServiceAccount to a role that only grants the permissions that the workload needs.", roleRef.Kind, roleRef.Name),
+			)
+		}
+
+		return
+	}
+}
+
+// bindsServiceAccount returns true if the ServiceAccount is a subject of the binding. ServiceAccount subjects
+// without a namespace in a RoleBinding are in the namespace of the RoleBinding.
+func bindsServiceAccount(binding ks.RoleBinding, name, namespace string) bool {
+	for _, subject := range binding.Subjects() {
+		if subject.Kind != rbacv1.ServiceAccountKind || subject.Name != name {
+			continue
+		}
+		subjectNamespace := subject.Namespace
+		if subjectNamespace == "" {
+			subjectNamespace = binding.GetObjectMeta().Namespace
+		}
+		if subjectNamespace == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// grantsFullAccess returns true if any rule grants all verbs on all resources
+func grantsFullAccess(rules []rbacv1.PolicyRule) bool {
+	for _, rule := range rules {
+		if contains(rule.Verbs, rbacv1.VerbAll) && contains(rule.Resources, rbacv1.ResourceAll) {
+			return true
+		}
+	}
+	return false
+}
+
+func roleKey(kind, namespace, name string) string {
+	if kind == "ClusterRole" {
+		namespace = ""
+	}
+	return kind + "/" + namespace + "/" + name
+}
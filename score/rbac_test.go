@@ -0,0 +1,83 @@
+package score
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func testRBACWildcard(t *testing.T, filename string, expectedScore scorecard.Grade) []scorecard.TestScoreComment {
+	return testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile(filename)},
+		EnabledOptionalTests: map[string]struct{}{"rbac-wildcard": {}},
+	}, "RBAC Wildcard", expectedScore)
+}
+
+func TestRBACWildcard(t *testing.T) {
+	t.Parallel()
+	comments := testRBACWildcard(t, "role-rbac-wildcard.yaml", scorecard.GradeCritical)
+	assert.Len(t, comments, 3)
+	assert.Equal(t, "rules[0]", comments[0].Path)
+	assert.Equal(t, "The rule uses * in apiGroups, resources", comments[0].Summary)
+	assert.Contains(t, comments[0].Description, `{apiGroups: ["*"], resources: ["*"], verbs: ["get", "list"]}`)
+	assert.Equal(t, "rules[1]", comments[1].Path)
+	assert.Equal(t, "The rule grants create, delete on secrets", comments[1].Summary)
+	assert.Equal(t, "rules[2]", comments[2].Path)
+	assert.Equal(t, "The rule grants bind", comments[2].Summary)
+}
+
+func TestRBACWildcardSensitiveResource(t *testing.T) {
+	t.Parallel()
+	comments := testRBACWildcard(t, "role-rbac-sensitive.yaml", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "The rule grants create, delete on secrets", comments[0].Summary)
+}
+
+func TestRBACWildcardOK(t *testing.T) {
+	t.Parallel()
+	comments := testRBACWildcard(t, "role-rbac-ok.yaml", scorecard.GradeAllOK)
+	assert.Len(t, comments, 0)
+}
+
+func TestServiceAccountClusterAdmin(t *testing.T) {
+	t.Parallel()
+	sc, err := testScore(config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("serviceaccount-cluster-admin.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"serviceaccount-cluster-admin": {}},
+	})
+	assert.NoError(t, err)
+
+	results := make(map[string]scorecard.TestScore)
+	for _, o := range sc {
+		for _, c := range o.Checks {
+			if c.Check.ID == "serviceaccount-cluster-admin" {
+				results[o.ObjectMeta.Name] = c
+			}
+		}
+	}
+
+	assert.Equal(t, scorecard.GradeCritical, results["admin"].Grade)
+	assert.Len(t, results["admin"].Comments, 1)
+	assert.Equal(t, "The ServiceAccount admin is bound to the ClusterRole cluster-admin by the ClusterRoleBinding admin-binding", results["admin"].Comments[0].Summary)
+
+	assert.Equal(t, scorecard.GradeCritical, results["wildcard"].Grade)
+	assert.Len(t, results["wildcard"].Comments, 1)
+	assert.Equal(t, "The ServiceAccount default is bound to the Role everything by the RoleBinding default-everything", results["wildcard"].Comments[0].Summary)
+
+	assert.Equal(t, scorecard.GradeAllOK, results["tightly-scoped"].Grade)
+	assert.Len(t, results["tightly-scoped"].Comments, 0)
+}
+
+func TestServiceAccountClusterAdminNoBindings(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("pod-seccomp-profile-field-missing.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"serviceaccount-cluster-admin": {}},
+	}, "ServiceAccount Cluster Admin", scorecard.GradeAllOK)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "Skipped because there are no RoleBindings or ClusterRoleBindings", comments[0].Summary)
+}
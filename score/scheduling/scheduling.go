@@ -0,0 +1,80 @@
+package scheduling
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/config"
+	"github.com/zegl/kube-score/score/checks"
+	"github.com/zegl/kube-score/score/internal"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+const (
+	// tierAnnotation marks the importance of a workload, objects with the value "critical" are expected to have a priorityClassName
+	tierAnnotation = "kube-score/tier"
+	tierCritical   = "critical"
+)
+
+// controlPlaneTaints are the taints that are set on control plane nodes by kubeadm, the nodes also have a label with the same key
+var controlPlaneTaints = []corev1.Taint{
+	{Key: "node-role.kubernetes.io/control-plane", Effect: corev1.TaintEffectNoSchedule},
+	{Key: "node-role.kubernetes.io/master", Effect: corev1.TaintEffectNoSchedule},
+}
+
+func Register(allChecks *checks.Checks, cnf config.Configuration) {
+	allChecks.RegisterOptionalPodObjectCheck("Pod Priority Class", `Makes sure that objects annotated with kube-score/tier: critical have a priorityClassName set`, podPriorityClass)
+	allChecks.RegisterOptionalPodCheck("Pod NodeSelector Toleration", `Makes sure that pods that select control plane nodes tolerate the control plane taint`, podNodeSelectorToleration)
+	allChecks.RegisterOptionalPodCheck("Pod Affinity TopologyKey", `Makes sure that the topologyKey of pod affinity and anti-affinity terms is a well-known node label, such as kubernetes.io/hostname or topology.kubernetes.io/zone. More keys can be allowed with --topology-key`, podAffinityTopologyKey(cnf.TopologyKeys))
+}
+
+// podPriorityClass checks that pods of objects that are marked as critical have a priorityClassName set
+func podPriorityClass(meta metav1.ObjectMeta, podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	if tier, _ := internal.Annotation(meta, tierAnnotation); tier != tierCritical {
+		return
+	}
+
+	if podTemplate.Spec.PriorityClassName != "" {
+		return
+	}
+
+	score.Grade = scorecard.GradeWarning
+	score.AddComment(internal.PodSpecPath(typeMeta)+".priorityClassName", "The pod is marked as critical, but has no priorityClassName set",
+		"Pods without a priority are the first to be evicted when the node is under resource pressure. Set priorityClassName to a PriorityClass with a high priority.")
+	return
+}
+
+// podNodeSelectorToleration checks that pods with a nodeSelector for control plane nodes also tolerates the taint
+// of control plane nodes, without it the pod can never be scheduled
+func podNodeSelectorToleration(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	for _, taint := range controlPlaneTaints {
+		if _, ok := podTemplate.Spec.NodeSelector[taint.Key]; !ok {
+			continue
+		}
+
+		if toleratesTaint(podTemplate.Spec.Tolerations, taint) {
+			continue
+		}
+
+		score.Grade = scorecard.GradeWarning
+		score.AddComment(internal.PodSpecPath(typeMeta)+".tolerations", fmt.Sprintf("The pod selects nodes with the label %s, but does not tolerate the %s taint", taint.Key, taint.ToString()),
+			"Control plane nodes are usually tainted, and pods without a matching toleration can not be scheduled on them. Add a toleration for the taint, or remove the nodeSelector.")
+	}
+
+	return
+}
+
+func toleratesTaint(tolerations []corev1.Toleration, taint corev1.Taint) bool {
+	for _, toleration := range tolerations {
+		if toleration.ToleratesTaint(&taint) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package scheduling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestPodPriorityClass(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		annotations         map[string]string
+		templateAnnotations map[string]string
+		priorityClass       string
+		expected            scorecard.Grade
+	}{
+		{nil, nil, "", scorecard.GradeAllOK},
+		{map[string]string{"kube-score/tier": "best-effort"}, nil, "", scorecard.GradeAllOK},
+		{map[string]string{"kube-score/tier": "critical"}, nil, "", scorecard.GradeWarning},
+		{map[string]string{"kube-score/tier": "critical"}, nil, "high-priority", scorecard.GradeAllOK},
+		// The annotation is read from the object, not from the pod template
+		{nil, map[string]string{"kube-score/tier": "critical"}, "", scorecard.GradeAllOK},
+	}
+
+	for i, tc := range cases {
+		s := podPriorityClass(metav1.ObjectMeta{Annotations: tc.annotations}, corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Annotations: tc.templateAnnotations},
+			Spec:       corev1.PodSpec{PriorityClassName: tc.priorityClass},
+		}, metav1.TypeMeta{Kind: "Deployment"})
+		assert.Equal(t, tc.expected, s.Grade, "case %d", i)
+		if tc.expected == scorecard.GradeWarning {
+			assert.Equal(t, "spec.template.spec.priorityClassName", s.Comments[0].Path, "case %d", i)
+		}
+	}
+}
+
+func TestPodNodeSelectorToleration(t *testing.T) {
+	t.Parallel()
+
+	controlPlane := map[string]string{"node-role.kubernetes.io/control-plane": ""}
+
+	cases := []struct {
+		nodeSelector map[string]string
+		tolerations  []corev1.Toleration
+		expected     scorecard.Grade
+	}{
+		{nil, nil, scorecard.GradeAllOK},
+		{map[string]string{"disktype": "ssd"}, nil, scorecard.GradeAllOK},
+		{controlPlane, nil, scorecard.GradeWarning},
+		{map[string]string{"node-role.kubernetes.io/master": ""}, nil, scorecard.GradeWarning},
+		{controlPlane, []corev1.Toleration{{Key: "node-role.kubernetes.io/master", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}}, scorecard.GradeWarning},
+		{controlPlane, []corev1.Toleration{{Key: "node-role.kubernetes.io/control-plane", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}}, scorecard.GradeAllOK},
+		{controlPlane, []corev1.Toleration{{Operator: corev1.TolerationOpExists}}, scorecard.GradeAllOK},
+	}
+
+	for i, tc := range cases {
+		s := podNodeSelectorToleration(corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{NodeSelector: tc.nodeSelector, Tolerations: tc.tolerations},
+		}, metav1.TypeMeta{Kind: "Pod"})
+		assert.Equal(t, tc.expected, s.Grade, "case %d", i)
+		if tc.expected == scorecard.GradeWarning {
+			assert.Equal(t, "spec.tolerations", s.Comments[0].Path, "case %d", i)
+		}
+	}
+}
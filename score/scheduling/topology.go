@@ -0,0 +1,74 @@
+package scheduling
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// WellKnownTopologyKeys are the node labels that are set by Kubernetes, and are accepted as topologyKey by the
+// "Pod Affinity TopologyKey" check
+var WellKnownTopologyKeys = []string{
+	"kubernetes.io/hostname",
+	"kubernetes.io/os",
+	"kubernetes.io/arch",
+	"topology.kubernetes.io/zone",
+	"topology.kubernetes.io/region",
+	"node.kubernetes.io/instance-type",
+	"failure-domain.beta.kubernetes.io/zone",
+	"failure-domain.beta.kubernetes.io/region",
+	"beta.kubernetes.io/instance-type",
+}
+
+// podAffinityTopologyKey returns a function that checks that the topologyKey of all pod affinity and anti-affinity
+// terms is either one of WellKnownTopologyKeys, or one of the customKeys. Nodes without the label can't satisfy a
+// required term, so a misspelled key makes the pods unschedulable.
+func podAffinityTopologyKey(customKeys []string) func(corev1.PodTemplateSpec, metav1.TypeMeta) scorecard.TestScore {
+	knownKeys := make(map[string]struct{})
+	for _, key := range append(append([]string{}, WellKnownTopologyKeys...), customKeys...) {
+		knownKeys[key] = struct{}{}
+	}
+
+	return func(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+		score.Grade = scorecard.GradeAllOK
+
+		affinity := podTemplate.Spec.Affinity
+		if affinity == nil {
+			return
+		}
+
+		check := func(path string, term corev1.PodAffinityTerm) {
+			if _, ok := knownKeys[term.TopologyKey]; ok {
+				return
+			}
+			score.Grade = scorecard.GradeWarning
+			score.AddComment(path,
+				fmt.Sprintf("The topologyKey %q is not a well-known node label", term.TopologyKey),
+				"Pods are only scheduled to nodes that have the topologyKey as a label. If the key is misspelled, no node has it, and pods with a required term can't be scheduled. "+
+					"Use a well-known key, such as kubernetes.io/hostname or topology.kubernetes.io/zone, or add the key with --topology-key if the nodes in the cluster have it.",
+			)
+		}
+
+		if a := affinity.PodAffinity; a != nil {
+			for i, term := range a.RequiredDuringSchedulingIgnoredDuringExecution {
+				check(fmt.Sprintf("podAffinity.requiredDuringSchedulingIgnoredDuringExecution[%d]", i), term)
+			}
+			for i, term := range a.PreferredDuringSchedulingIgnoredDuringExecution {
+				check(fmt.Sprintf("podAffinity.preferredDuringSchedulingIgnoredDuringExecution[%d]", i), term.PodAffinityTerm)
+			}
+		}
+		if a := affinity.PodAntiAffinity; a != nil {
+			for i, term := range a.RequiredDuringSchedulingIgnoredDuringExecution {
+				check(fmt.Sprintf("podAntiAffinity.requiredDuringSchedulingIgnoredDuringExecution[%d]", i), term)
+			}
+			for i, term := range a.PreferredDuringSchedulingIgnoredDuringExecution {
+				check(fmt.Sprintf("podAntiAffinity.preferredDuringSchedulingIgnoredDuringExecution[%d]", i), term.PodAffinityTerm)
+			}
+		}
+
+		return
+	}
+}
@@ -0,0 +1,42 @@
+package scheduling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestPodAffinityTopologyKey(t *testing.T) {
+	t.Parallel()
+
+	template := func(keys ...string) corev1.PodTemplateSpec {
+		var terms []corev1.PodAffinityTerm
+		for _, key := range keys {
+			terms = append(terms, corev1.PodAffinityTerm{TopologyKey: key})
+		}
+		return corev1.PodTemplateSpec{Spec: corev1.PodSpec{Affinity: &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: terms,
+			},
+		}}}
+	}
+
+	check := podAffinityTopologyKey([]string{"example.com/rack"})
+
+	s := check(template("kubernetes.io/hostname", "topology.kubernetes.io/zone", "example.com/rack"), metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+	assert.Len(t, s.Comments, 0)
+
+	s = check(template("kubernetes.io/hostname", "kubernets.io/hostname"), metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeWarning, s.Grade)
+	assert.Len(t, s.Comments, 1)
+	assert.Equal(t, "podAntiAffinity.requiredDuringSchedulingIgnoredDuringExecution[1]", s.Comments[0].Path)
+	assert.Equal(t, `The topologyKey "kubernets.io/hostname" is not a well-known node label`, s.Comments[0].Summary)
+
+	s = check(corev1.PodTemplateSpec{}, metav1.TypeMeta{})
+	assert.Equal(t, scorecard.GradeAllOK, s.Grade)
+}
@@ -1,18 +1,29 @@
 package score
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/zegl/kube-score/config"
 	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/policy"
 	"github.com/zegl/kube-score/score/apps"
 	"github.com/zegl/kube-score/score/checks"
+	"github.com/zegl/kube-score/score/configmap"
 	"github.com/zegl/kube-score/score/container"
 	"github.com/zegl/kube-score/score/cronjob"
 	"github.com/zegl/kube-score/score/disruptionbudget"
 	"github.com/zegl/kube-score/score/hpa"
 	"github.com/zegl/kube-score/score/ingress"
 	"github.com/zegl/kube-score/score/meta"
+	"github.com/zegl/kube-score/score/namespace"
 	"github.com/zegl/kube-score/score/networkpolicy"
 	"github.com/zegl/kube-score/score/probes"
+	"github.com/zegl/kube-score/score/pvc"
+	"github.com/zegl/kube-score/score/rbac"
+	"github.com/zegl/kube-score/score/scheduling"
+	"github.com/zegl/kube-score/score/secret"
 	"github.com/zegl/kube-score/score/security"
 	"github.com/zegl/kube-score/score/service"
 	"github.com/zegl/kube-score/score/stable"
@@ -25,18 +36,24 @@ import (
 func RegisterAllChecks(allObjects ks.AllTypes, cnf config.Configuration) *checks.Checks {
 	allChecks := checks.New(cnf)
 
-	ingress.Register(allChecks, allObjects)
+	ingress.Register(allChecks, cnf.KubernetesVersion, allObjects, allObjects)
 	cronjob.Register(allChecks)
-	container.Register(allChecks, cnf)
+	container.Register(allChecks, cnf, allObjects, allObjects, allObjects, allObjects)
 	disruptionbudget.Register(allChecks, allObjects)
 	networkpolicy.Register(allChecks, allObjects, allObjects, allObjects)
 	probes.Register(allChecks, allObjects)
-	security.Register(allChecks)
+	security.Register(allChecks, cnf.KubernetesVersion)
 	service.Register(allChecks, allObjects, allObjects)
 	stable.Register(cnf.KubernetesVersion, allChecks)
-	apps.Register(allChecks, allObjects.HorizontalPodAutoscalers(), allObjects.Services())
-	meta.Register(allChecks)
+	apps.Register(allChecks, cnf, allObjects.HorizontalPodAutoscalers(), allObjects.Services())
+	meta.Register(allChecks, cnf, allObjects)
 	hpa.Register(allChecks, allObjects.Metas())
+	pvc.Register(allChecks, cnf)
+	scheduling.Register(allChecks, cnf)
+	configmap.Register(allChecks, cnf.KubernetesVersion)
+	secret.Register(allChecks)
+	namespace.Register(allChecks)
+	rbac.Register(allChecks, allObjects, allObjects)
 
 	return allChecks
 }
@@ -44,95 +61,373 @@ func RegisterAllChecks(allObjects ks.AllTypes, cnf config.Configuration) *checks
 // Score runs a pre-configured list of tests against the files defined in the configuration, and returns a scorecard.
 // Additional configuration and tuning parameters can be provided via the config.
 func Score(allObjects ks.AllTypes, cnf config.Configuration) (*scorecard.Scorecard, error) {
+	return ScoreWithCallback(allObjects, cnf, nil)
+}
+
+// ScoreWithCallback is the same as Score, but also calls onScored with each object as soon as all checks have been
+// executed for it. Objects are passed to onScored in the order that they were defined in the input.
+func ScoreWithCallback(allObjects ks.AllTypes, cnf config.Configuration, onScored func(*scorecard.ScoredObject)) (*scorecard.Scorecard, error) {
 	allChecks := RegisterAllChecks(allObjects, cnf)
 	scoreCard := scorecard.New()
+	timings := checkTimings(cnf.CheckTimings)
+	results := newResultCache(cnf, allChecks)
+
+	for _, check := range allChecks.All() {
+		targetTypes := strings.Join(allChecks.TargetTypes(check.ID), ",")
+		if len(cnf.OnlyChecks) > 0 {
+			if _, ok := cnf.OnlyChecks[check.ID]; !ok {
+				cnf.Logger.Debug("Check is disabled", "check", check.ID, "target_type", targetTypes, "reason", "not-selected")
+			}
+		} else if _, ok := cnf.IgnoredTests[check.ID]; ok {
+			cnf.Logger.Debug("Check is disabled", "check", check.ID, "target_type", targetTypes, "reason", "ignored")
+		} else if _, ok := cnf.EnabledOptionalTests[check.ID]; check.Optional && !ok {
+			cnf.Logger.Debug("Check is disabled", "check", check.ID, "target_type", targetTypes, "reason", "optional")
+		}
+	}
+
+	// All checks for an object are scheduled first, and are then executed object by object, so that it's
+	// known when an object is completely scored.
+	var objectsInOrder []*scorecard.ScoredObject
+	scheduled := make(map[*scorecard.ScoredObject][]func() error)
+
+	schedule := func(typeMeta metav1.TypeMeta, objectMeta metav1.ObjectMeta, fn func(o *scorecard.ScoredObject) error) {
+		o := scoreCard.NewObject(typeMeta, objectMeta, cnf.UseIgnoreChecksAnnotation)
+		if _, ok := scheduled[o]; !ok {
+			objectsInOrder = append(objectsInOrder, o)
+		}
+		scheduled[o] = append(scheduled[o], func() error {
+			return fn(o)
+		})
+	}
 
-	newObject := func(typeMeta metav1.TypeMeta, objectMeta metav1.ObjectMeta) *scorecard.ScoredObject {
-		return scoreCard.NewObject(typeMeta, objectMeta, cnf.UseIgnoreChecksAnnotation)
+	// skipDisabled adds the checks for the target type that are not enabled as skipped, if enabled in the config,
+	// or if only some checks are selected to run
+	skipDisabled := func(o *scorecard.ScoredObject, targetType string, locationer ks.FileLocationer) {
+		if !cnf.IncludeSkipped && len(cnf.OnlyChecks) == 0 {
+			return
+		}
+		for _, check := range allChecks.Disabled(targetType) {
+			o.Add(disabledScore(check, cnf), check, locationer)
+		}
+	}
+
+	// All objects are included in Metas() in the same order as they are defined in the input
+	for _, meta := range allObjects.Metas() {
+		o := scoreCard.NewObject(meta.TypeMeta, meta.ObjectMeta, cnf.UseIgnoreChecksAnnotation)
+		if _, ok := scheduled[o]; !ok {
+			objectsInOrder = append(objectsInOrder, o)
+			scheduled[o] = nil
+		}
+	}
+
+	// scoreObject schedules the checks of the target type for an object, the results are cached by the target type
+	// and the content of the object that is passed to the checks
+	scoreObject := func(targetType string, typeMeta metav1.TypeMeta, objectMeta metav1.ObjectMeta, locationer ks.FileLocationer, tests []objectCheck, content ...interface{}) {
+		schedule(typeMeta, objectMeta, func(o *scorecard.ScoredObject) error {
+			cached := results.object(targetType, content...)
+			for _, test := range tests {
+				start := timings.start()
+				res, err := cached.runWithError(test.check, test.fn)
+				timings.record(test.check.ID, start)
+				if err != nil {
+					return err
+				}
+				o.Add(res, test.check, locationer)
+			}
+			cached.save()
+			skipDisabled(o, targetType, locationer)
+			return nil
+		})
 	}
 
 	for _, ingress := range allObjects.Ingresses() {
-		o := newObject(ingress.GetTypeMeta(), ingress.GetObjectMeta())
+		ingress := ingress
+		var tests []objectCheck
 		for _, test := range allChecks.Ingresses() {
-			o.Add(test.Fn(ingress), test.Check, ingress)
+			test := test
+			tests = append(tests, withoutError(test.Check, func() scorecard.TestScore { return test.Fn(ingress) }))
 		}
+		scoreObject("Ingress", ingress.GetTypeMeta(), ingress.GetObjectMeta(), ingress, tests,
+			ingress.GetTypeMeta(), ingress.GetObjectMeta(), ingress.Rules(), ingress.IngressClassName())
 	}
 
 	for _, meta := range allObjects.Metas() {
-		o := newObject(meta.TypeMeta, meta.ObjectMeta)
+		meta := meta
+		var tests []objectCheck
 		for _, test := range allChecks.Metas() {
-			o.Add(test.Fn(meta), test.Check, meta)
+			test := test
+			tests = append(tests, withoutError(test.Check, func() scorecard.TestScore { return test.Fn(meta) }))
 		}
+		scoreObject("all", meta.TypeMeta, meta.ObjectMeta, meta, tests, meta.TypeMeta, meta.ObjectMeta)
 	}
 
 	for _, pod := range allObjects.Pods() {
-		o := newObject(pod.Pod().TypeMeta, pod.Pod().ObjectMeta)
+		pod := pod
+		podTemplateSpec := corev1.PodTemplateSpec{
+			ObjectMeta: pod.Pod().ObjectMeta,
+			Spec:       pod.Pod().Spec,
+		}
+		var tests []objectCheck
 		for _, test := range allChecks.Pods() {
-			score := test.Fn(corev1.PodTemplateSpec{
-				ObjectMeta: pod.Pod().ObjectMeta,
-				Spec:       pod.Pod().Spec,
-			}, pod.Pod().TypeMeta)
-			o.Add(score, test.Check, pod)
+			test := test
+			tests = append(tests, withoutError(test.Check, func() scorecard.TestScore { return test.Fn(pod.Pod().ObjectMeta, podTemplateSpec, pod.Pod().TypeMeta) }))
 		}
+		scoreObject("Pod", pod.Pod().TypeMeta, pod.Pod().ObjectMeta, pod, tests, podTemplateSpec, pod.Pod().TypeMeta)
 	}
 
 	for _, podspecer := range allObjects.PodSpeccers() {
-		o := newObject(podspecer.GetTypeMeta(), podspecer.GetObjectMeta())
+		podspecer := podspecer
+		var tests []objectCheck
 		for _, test := range allChecks.Pods() {
-			score := test.Fn(podspecer.GetPodTemplateSpec(), podspecer.GetTypeMeta())
-			o.Add(score, test.Check, podspecer)
+			test := test
+			tests = append(tests, withoutError(test.Check, func() scorecard.TestScore {
+				return test.Fn(podspecer.GetObjectMeta(), podspecer.GetPodTemplateSpec(), podspecer.GetTypeMeta())
+			}))
 		}
+		scoreObject("Pod", podspecer.GetTypeMeta(), podspecer.GetObjectMeta(), podspecer, tests,
+			podspecer.GetObjectMeta().Annotations, podspecer.GetPodTemplateSpec(), podspecer.GetTypeMeta())
 	}
 
 	for _, service := range allObjects.Services() {
-		o := newObject(service.Service().TypeMeta, service.Service().ObjectMeta)
+		service := service
+		var tests []objectCheck
 		for _, test := range allChecks.Services() {
-			o.Add(test.Fn(service.Service()), test.Check, service)
+			test := test
+			tests = append(tests, withoutError(test.Check, func() scorecard.TestScore { return test.Fn(service.Service()) }))
 		}
+		scoreObject("Service", service.Service().TypeMeta, service.Service().ObjectMeta, service, tests, service.Service())
 	}
 
 	for _, statefulset := range allObjects.StatefulSets() {
-		o := newObject(statefulset.StatefulSet().TypeMeta, statefulset.StatefulSet().ObjectMeta)
+		statefulset := statefulset
+		var tests []objectCheck
 		for _, test := range allChecks.StatefulSets() {
-			res, err := test.Fn(statefulset.StatefulSet())
-			if err != nil {
-				return nil, err
-			}
-			o.Add(res, test.Check, statefulset)
+			test := test
+			tests = append(tests, objectCheck{test.Check, func() (scorecard.TestScore, error) { return test.Fn(statefulset.StatefulSet()) }})
 		}
+		scoreObject("StatefulSet", statefulset.StatefulSet().TypeMeta, statefulset.StatefulSet().ObjectMeta, statefulset, tests,
+			statefulset.StatefulSet())
 	}
 
 	for _, deployment := range allObjects.Deployments() {
-		o := newObject(deployment.Deployment().TypeMeta, deployment.Deployment().ObjectMeta)
+		deployment := deployment
+		var tests []objectCheck
 		for _, test := range allChecks.Deployments() {
-			res, err := test.Fn(deployment.Deployment())
-			if err != nil {
-				return nil, err
-			}
-			o.Add(res, test.Check, deployment)
+			test := test
+			tests = append(tests, objectCheck{test.Check, func() (scorecard.TestScore, error) { return test.Fn(deployment.Deployment()) }})
+		}
+		scoreObject("Deployment", deployment.Deployment().TypeMeta, deployment.Deployment().ObjectMeta, deployment, tests,
+			deployment.Deployment())
+	}
+
+	for _, daemonset := range allObjects.DaemonSets() {
+		daemonset := daemonset
+		var tests []objectCheck
+		for _, test := range allChecks.DaemonSets() {
+			test := test
+			tests = append(tests, withoutError(test.Check, func() scorecard.TestScore { return test.Fn(daemonset.DaemonSet()) }))
 		}
+		scoreObject("DaemonSet", daemonset.DaemonSet().TypeMeta, daemonset.DaemonSet().ObjectMeta, daemonset, tests,
+			daemonset.DaemonSet())
 	}
 
 	for _, netpol := range allObjects.NetworkPolicies() {
-		o := newObject(netpol.NetworkPolicy().TypeMeta, netpol.NetworkPolicy().ObjectMeta)
+		netpol := netpol
+		var tests []objectCheck
 		for _, test := range allChecks.NetworkPolicies() {
-			o.Add(test.Fn(netpol.NetworkPolicy()), test.Check, netpol)
+			test := test
+			tests = append(tests, withoutError(test.Check, func() scorecard.TestScore { return test.Fn(netpol.NetworkPolicy()) }))
 		}
+		scoreObject("NetworkPolicy", netpol.NetworkPolicy().TypeMeta, netpol.NetworkPolicy().ObjectMeta, netpol, tests,
+			netpol.NetworkPolicy())
 	}
 
 	for _, cjob := range allObjects.CronJobs() {
-		o := newObject(cjob.GetTypeMeta(), cjob.GetObjectMeta())
+		cjob := cjob
+		var tests []objectCheck
 		for _, test := range allChecks.CronJobs() {
-			o.Add(test.Fn(cjob), test.Check, cjob)
+			test := test
+			tests = append(tests, withoutError(test.Check, func() scorecard.TestScore { return test.Fn(cjob) }))
 		}
+		scoreObject("CronJob", cjob.GetTypeMeta(), cjob.GetObjectMeta(), cjob, tests,
+			cjob.GetTypeMeta(), cjob.GetObjectMeta(), cjob.StartingDeadlineSeconds(), cjob.Schedule(), cjob.ConcurrencyPolicy())
 	}
 
 	for _, hpa := range allObjects.HorizontalPodAutoscalers() {
-		o := newObject(hpa.GetTypeMeta(), hpa.GetObjectMeta())
+		hpa := hpa
+		var tests []objectCheck
 		for _, test := range allChecks.HorizontalPodAutoscalers() {
-			o.Add(test.Fn(hpa), test.Check, hpa)
+			test := test
+			tests = append(tests, withoutError(test.Check, func() scorecard.TestScore { return test.Fn(hpa) }))
+		}
+		scoreObject("HorizontalPodAutoscaler", hpa.GetTypeMeta(), hpa.GetObjectMeta(), hpa, tests,
+			hpa.GetTypeMeta(), hpa.GetObjectMeta(), hpa.HpaTarget(), hpa.MinReplicas(), hpa.MaxReplicas())
+	}
+
+	for _, pvc := range allObjects.PersistentVolumeClaims() {
+		pvc := pvc
+		var tests []objectCheck
+		for _, test := range allChecks.PersistentVolumeClaims() {
+			test := test
+			tests = append(tests, withoutError(test.Check, func() scorecard.TestScore { return test.Fn(pvc.PersistentVolumeClaim()) }))
+		}
+		scoreObject("PersistentVolumeClaim", pvc.PersistentVolumeClaim().TypeMeta, pvc.PersistentVolumeClaim().ObjectMeta, pvc, tests,
+			pvc.PersistentVolumeClaim())
+	}
+
+	for _, configMap := range allObjects.ConfigMaps() {
+		configMap := configMap
+		var tests []objectCheck
+		for _, test := range allChecks.ConfigMaps() {
+			test := test
+			tests = append(tests, withoutError(test.Check, func() scorecard.TestScore { return test.Fn(configMap.ConfigMap()) }))
+		}
+		scoreObject("ConfigMap", configMap.ConfigMap().TypeMeta, configMap.ConfigMap().ObjectMeta, configMap, tests,
+			configMap.ConfigMap())
+	}
+
+	for _, secret := range allObjects.Secrets() {
+		secret := secret
+		var tests []objectCheck
+		for _, test := range allChecks.Secrets() {
+			test := test
+			tests = append(tests, withoutError(test.Check, func() scorecard.TestScore { return test.Fn(secret.Secret()) }))
+		}
+		scoreObject("Secret", secret.Secret().TypeMeta, secret.Secret().ObjectMeta, secret, tests, secret.Secret())
+	}
+
+	for _, namespace := range allObjects.Namespaces() {
+		namespace := namespace
+		var tests []objectCheck
+		for _, test := range allChecks.Namespaces() {
+			test := test
+			tests = append(tests, withoutError(test.Check, func() scorecard.TestScore { return test.Fn(namespace.Namespace()) }))
+		}
+		scoreObject("Namespace", namespace.Namespace().TypeMeta, namespace.Namespace().ObjectMeta, namespace, tests,
+			namespace.Namespace())
+	}
+
+	for _, role := range allObjects.Roles() {
+		role := role
+		var tests []objectCheck
+		for _, test := range allChecks.Roles() {
+			test := test
+			tests = append(tests, withoutError(test.Check, func() scorecard.TestScore { return test.Fn(role) }))
+		}
+		scoreObject("Role", role.GetTypeMeta(), role.GetObjectMeta(), role, tests, role.GetTypeMeta(), role.GetObjectMeta(), role.Rules())
+	}
+
+	// Objects of unknown kinds are not part of Metas(), and are scored after all other objects
+	for _, unknown := range allObjects.UnknownObjects() {
+		unknown := unknown
+		var tests []objectCheck
+		for _, test := range allChecks.UnknownObjects() {
+			test := test
+			tests = append(tests, withoutError(test.Check, func() scorecard.TestScore { return test.Fn(unknown) }))
+		}
+		scoreObject("UnknownObject", unknown.GetTypeMeta(), unknown.GetObjectMeta(), unknown, tests,
+			unknown.GetTypeMeta(), unknown.GetObjectMeta(), unknown.Suggestion())
+	}
+
+	for _, o := range objectsInOrder {
+		for _, fn := range scheduled[o] {
+			if err := fn(); err != nil {
+				return nil, err
+			}
+		}
+
+		applySeverityOverrides(o, cnf.SeverityOverrides, cnf.SeverityPolicy)
+		if cnf.Explain {
+			addExplanations(o)
+		}
+
+		if onScored != nil {
+			onScored(o)
 		}
 	}
 
 	return &scoreCard, nil
 }
+
+// objectCheck is a check together with the object that it scores
+type objectCheck struct {
+	check ks.Check
+	fn    func() (scorecard.TestScore, error)
+}
+
+// withoutError returns an objectCheck for a check that can't fail
+func withoutError(check ks.Check, fn func() scorecard.TestScore) objectCheck {
+	return objectCheck{check: check, fn: func() (scorecard.TestScore, error) {
+		return fn(), nil
+	}}
+}
+
+// disabledScore returns the score of a check that has not been executed, because it's ignored or not enabled
+func disabledScore(check ks.Check, cnf config.Configuration) (score scorecard.TestScore) {
+	score.Skipped = true
+
+	if len(cnf.OnlyChecks) > 0 {
+		score.SkipReason = scorecard.SkipReasonNotSelected
+		score.AddComment("", fmt.Sprintf("Skipped because %s is not selected with --only", check.ID), "")
+		return
+	}
+
+	if _, ok := cnf.IgnoredTests[check.ID]; ok {
+		score.SkipReason = scorecard.SkipReasonIgnored
+		score.AddComment("", fmt.Sprintf("Skipped because %s is ignored", check.ID), "")
+		return
+	}
+
+	score.SkipReason = scorecard.SkipReasonDisabled
+	score.AddComment("", fmt.Sprintf("Skipped because the optional check %s is not enabled", check.ID), "")
+	return
+}
+
+// applySeverityOverrides raises the grade of all checks that are more severe than their configured override.
+// The rules of the policy that match the object are used before the overrides.
+func applySeverityOverrides(o *scorecard.ScoredObject, overrides map[string]scorecard.Grade, severityPolicy *policy.Policy) {
+	for i, c := range o.Checks {
+		if c.Skipped {
+			continue
+		}
+		maxSeverity, ok := severityPolicy.MaxSeverity(c.Check.ID, o.ObjectMeta)
+		if !ok {
+			maxSeverity, ok = overrides[c.Check.ID]
+		}
+		if !ok {
+			continue
+		}
+		if c.Grade < maxSeverity {
+			o.Checks[i].Grade = maxSeverity
+		}
+	}
+}
+
+// addExplanations adds the explanation of the check to all checks of the object that did not pass
+func addExplanations(o *scorecard.ScoredObject) {
+	for i, c := range o.Checks {
+		if c.Skipped || c.Grade >= scorecard.GradeAllOK {
+			continue
+		}
+		if explanation, ok := checks.Explanation(c.Check.ID); ok {
+			o.Checks[i].Check.Explanation = &explanation
+		}
+	}
+}
+
+// checkTimings accumulates the time spent in each check, all methods are no-ops if the map is nil
+type checkTimings map[string]time.Duration
+
+func (t checkTimings) start() time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+func (t checkTimings) record(id string, start time.Time) {
+	if t == nil {
+		return
+	}
+	t[id] += time.Since(start)
+}
@@ -2,12 +2,17 @@ package score
 
 import (
 	"io"
+	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/zegl/kube-score/config"
 	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/parser"
+	"github.com/zegl/kube-score/policy"
+	"github.com/zegl/kube-score/score/checks"
 	"github.com/zegl/kube-score/scorecard"
 
 	"github.com/stretchr/testify/assert"
@@ -307,3 +312,318 @@ func TestList(t *testing.T) {
 	assert.True(t, hasService)
 	assert.True(t, hasDeployment)
 }
+
+func TestListMixedKinds(t *testing.T) {
+	t.Parallel()
+	s, err := testScore(config.Configuration{
+		AllFiles: []ks.NamedReader{testFile("list-mixed.yaml")},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, s, 4)
+
+	locations := make(map[string]ks.FileLocation)
+	for _, obj := range s {
+		locations[obj.ObjectMeta.Name] = obj.FileLocation
+
+		// Each item is scored with the checks of its own kind
+		for _, c := range obj.Checks {
+			if c.Check.ID == "container-image-tag" && !c.Skipped {
+				if obj.ObjectMeta.Name == "list-mixed-pod" {
+					assert.Equal(t, scorecard.GradeAllOK, c.Grade)
+				} else {
+					assert.Equal(t, scorecard.GradeCritical, c.Grade)
+				}
+			}
+		}
+	}
+
+	assert.Equal(t, map[string]ks.FileLocation{
+		"list-mixed-service":      {Name: "testdata/list-mixed.yaml#items[0]", Line: 4},
+		"list-mixed-deployment":   {Name: "testdata/list-mixed.yaml#items[1].items[0]", Line: 17},
+		"list-mixed-pod":          {Name: "testdata/list-mixed.yaml#items[2].items[0]", Line: 36},
+		"list-mixed-deployment-2": {Name: "testdata/list-mixed.yaml#items[0]", Line: 48},
+	}, locations)
+}
+
+func TestSeverityOverrides(t *testing.T) {
+	t.Parallel()
+	sc, err := testScore(config.Configuration{
+		AllFiles: []ks.NamedReader{testFile("pod-image-tag-latest.yaml")},
+		SeverityOverrides: map[string]scorecard.Grade{
+			"container-image-tag": scorecard.GradeWarning,
+		},
+	})
+	assert.Nil(t, err)
+
+	tested := false
+	for _, o := range sc {
+		for _, c := range o.Checks {
+			if c.Check.ID == "container-image-tag" {
+				assert.Equal(t, scorecard.GradeWarning, c.Grade)
+				assert.NotEmpty(t, c.Comments)
+				tested = true
+			}
+		}
+	}
+	assert.True(t, tested)
+}
+
+func TestSeverityOverridesDoesNotLowerGrade(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles: []ks.NamedReader{testFile("pod-image-tag-fixed.yaml")},
+		SeverityOverrides: map[string]scorecard.Grade{
+			"container-image-tag": scorecard.GradeWarning,
+		},
+	}, "Container Image Tag", scorecard.GradeAllOK)
+}
+
+func TestSeverityPolicy(t *testing.T) {
+	t.Parallel()
+	severityPolicy, err := policy.Parse(strings.NewReader(`
+rules:
+  - check: container-image-tag
+    namespace: prod
+    severity: critical
+  - check: container-image-tag
+    severity: ok
+`))
+	assert.Nil(t, err)
+
+	b, err := ioutil.ReadFile("testdata/pod-image-tag-latest.yaml")
+	assert.Nil(t, err)
+	inProd := strings.Replace(string(b), "metadata:\n", "metadata:\n  namespace: prod\n", 1)
+
+	for _, tc := range []struct {
+		file     string
+		expected scorecard.Grade
+	}{
+		{string(b), scorecard.GradeAllOK},
+		{inProd, scorecard.GradeCritical},
+	} {
+		testExpectedScoreWithConfig(t, config.Configuration{
+			AllFiles:       []ks.NamedReader{unnamedReader{strings.NewReader(tc.file)}},
+			SeverityPolicy: severityPolicy,
+			// The matching rule in the policy takes precedence
+			SeverityOverrides: map[string]scorecard.Grade{
+				"container-image-tag": scorecard.GradeWarning,
+			},
+		}, "Container Image Tag", tc.expected)
+	}
+}
+
+func TestScoreWithCallbackInputOrder(t *testing.T) {
+	t.Parallel()
+	cnf := config.Configuration{
+		AllFiles: []ks.NamedReader{testFile("ingress-targets-service.yaml")},
+	}
+	parsed, err := parser.ParseFiles(cnf)
+	assert.Nil(t, err)
+
+	var kinds []string
+	card, err := ScoreWithCallback(parsed, cnf, func(o *scorecard.ScoredObject) {
+		assert.NotEmpty(t, o.Checks)
+		kinds = append(kinds, o.TypeMeta.Kind)
+	})
+	assert.Nil(t, err)
+	assert.Len(t, kinds, len(*card))
+
+	var expected []string
+	for _, m := range parsed.Metas() {
+		expected = append(expected, m.TypeMeta.Kind)
+	}
+	assert.Equal(t, expected, kinds)
+}
+
+func TestStatefulSetVolumeMountOfVolumeClaimTemplate(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "statefulset-pvc-storageclass-not-set.yaml", "Container VolumeMount Exists", scorecard.GradeAllOK)
+}
+
+func TestImagePullPolicyConsistency(t *testing.T) {
+	t.Parallel()
+	s, err := testScore(config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("image-pull-policy-consistency.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"image-pull-policy-consistency": {}},
+	})
+	assert.Nil(t, err)
+
+	results := make(map[string]scorecard.TestScore)
+	for _, o := range s {
+		for _, c := range o.Checks {
+			if c.Check.ID == "image-pull-policy-consistency" {
+				results[o.ObjectMeta.Name] = c
+			}
+		}
+	}
+
+	// The image of api is pulled with Always, and with the default IfNotPresent by worker
+	assert.Equal(t, scorecard.GradeWarning, results["api"].Grade)
+	assert.Len(t, results["api"].Comments, 1)
+	assert.Equal(t, "The image registry.example.com/api:1.2.3 is pulled with imagePullPolicy Always, but with a different policy by other workloads", results["api"].Comments[0].Summary)
+	assert.Contains(t, results["api"].Comments[0].Description, "Deployment worker in prod (IfNotPresent)")
+
+	assert.Equal(t, scorecard.GradeWarning, results["worker"].Grade)
+	assert.Len(t, results["worker"].Comments, 1)
+	assert.Contains(t, results["worker"].Comments[0].Description, "Deployment api in prod (Always)")
+
+	// The sidecar image is pulled with IfNotPresent, explicitly or by default, by both workloads
+	assert.Equal(t, scorecard.GradeAllOK, results["debug"].Grade)
+}
+
+func TestCheckTimings(t *testing.T) {
+	t.Parallel()
+	timings := make(map[string]time.Duration)
+	_, err := testScore(config.Configuration{
+		AllFiles:     []ks.NamedReader{testFile("pod-image-tag-fixed.yaml")},
+		CheckTimings: timings,
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, timings, "container-image-tag")
+	assert.NotContains(t, timings, "container-resource-requests-equal-limits")
+}
+
+func TestIncludeSkipped(t *testing.T) {
+	t.Parallel()
+
+	skipReasons := func(includeSkipped bool) map[string]scorecard.SkipReason {
+		s, err := testScore(config.Configuration{
+			AllFiles:                  []ks.NamedReader{testFile("deployment-include-skipped.yaml")},
+			IgnoredTests:              map[string]struct{}{"pod-networkpolicy": {}},
+			UseIgnoreChecksAnnotation: true,
+			IncludeSkipped:            includeSkipped,
+		})
+		assert.Nil(t, err)
+
+		res := make(map[string]scorecard.SkipReason)
+		for _, o := range s {
+			for _, c := range o.Checks {
+				if c.Skipped {
+					res[c.Check.ID] = c.SkipReason
+				}
+			}
+		}
+		return res
+	}
+
+	included := skipReasons(true)
+	assert.Equal(t, scorecard.SkipReasonIgnoredByAnnotation, included["container-image-tag"])
+	assert.Equal(t, scorecard.SkipReasonIgnored, included["pod-networkpolicy"])
+	assert.Equal(t, scorecard.SkipReasonDisabled, included["container-resource-requests-equal-limits"])
+	assert.Equal(t, scorecard.SkipReasonNotApplicable, included["deployment-targeted-by-hpa-does-not-have-replicas-configured"])
+
+	notIncluded := skipReasons(false)
+	assert.Equal(t, scorecard.SkipReasonIgnoredByAnnotation, notIncluded["container-image-tag"])
+	assert.Equal(t, scorecard.SkipReasonNotApplicable, notIncluded["deployment-targeted-by-hpa-does-not-have-replicas-configured"])
+	assert.NotContains(t, notIncluded, "pod-networkpolicy")
+	assert.NotContains(t, notIncluded, "container-resource-requests-equal-limits")
+}
+
+func TestOnlyChecks(t *testing.T) {
+	t.Parallel()
+	sc, err := testScore(config.Configuration{
+		AllFiles:   []ks.NamedReader{testFile("pod-test-resources-none.yaml")},
+		OnlyChecks: map[string]struct{}{"container-resources": {}, "pod-guaranteed-qos": {}},
+	})
+	assert.Nil(t, err)
+
+	for _, o := range sc {
+		executed := make(map[string]struct{})
+		for _, c := range o.Checks {
+			if c.Skipped {
+				assert.Equal(t, scorecard.SkipReasonNotSelected, c.SkipReason)
+				continue
+			}
+			executed[c.Check.ID] = struct{}{}
+		}
+		// The optional check is executed as well
+		assert.Equal(t, map[string]struct{}{"container-resources": {}, "pod-guaranteed-qos": {}}, executed)
+	}
+}
+
+func TestAllChecksHaveExplanations(t *testing.T) {
+	t.Parallel()
+	for _, check := range RegisterAllChecks(parser.Empty(), config.Configuration{}).All() {
+		explanation, ok := checks.Explanation(check.ID)
+		assert.True(t, ok, check.ID)
+		assert.NotEmpty(t, explanation.Rationale, check.ID)
+		assert.NotEmpty(t, explanation.Remediation, check.ID)
+	}
+}
+
+func TestAllChecksUniqueIDs(t *testing.T) {
+	t.Parallel()
+	allChecks := RegisterAllChecks(parser.Empty(), config.Configuration{})
+
+	seen := make(map[string]struct{})
+	for _, check := range allChecks.All() {
+		_, ok := seen[check.ID]
+		assert.False(t, ok, check.ID)
+		seen[check.ID] = struct{}{}
+	}
+
+	assert.Equal(t, []string{"PersistentVolumeClaim", "StatefulSet"}, allChecks.TargetTypes("pvc-storageclass"))
+	assert.Equal(t, []string{"ConfigMap", "Secret"}, allChecks.TargetTypes("configmap-secret-immutable"))
+}
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	for _, explain := range []bool{false, true} {
+		s, err := testScore(config.Configuration{
+			AllFiles: []ks.NamedReader{testFile("pod-image-tag-latest.yaml")},
+			Explain:  explain,
+		})
+		assert.Nil(t, err)
+
+		tested := false
+		for _, o := range s {
+			for _, c := range o.Checks {
+				switch {
+				case c.Check.ID == "container-image-tag":
+					tested = true
+					if explain {
+						assert.NotNil(t, c.Check.Explanation)
+					} else {
+						assert.Nil(t, c.Check.Explanation)
+					}
+				case c.Grade == scorecard.GradeAllOK || c.Skipped:
+					// Only checks that did not pass are explained
+					assert.Nil(t, c.Check.Explanation, c.Check.ID)
+				}
+			}
+		}
+		assert.True(t, tested)
+	}
+}
+
+func TestContainerResourceQuantityValid(t *testing.T) {
+	t.Parallel()
+	sc, err := testScore(config.Configuration{
+		AllFiles: []ks.NamedReader{testFile("container-resource-quantity-invalid.yaml")},
+	})
+	assert.NoError(t, err)
+
+	grades := make(map[string]scorecard.Grade)
+	comments := make(map[string][]scorecard.TestScoreComment)
+	for _, o := range sc {
+		for _, c := range o.Checks {
+			if c.Check.ID == "container-resource-quantity-valid" {
+				grades[o.ObjectMeta.Name] = c.Grade
+				comments[o.ObjectMeta.Name] = c.Comments
+			}
+		}
+	}
+
+	assert.Equal(t, map[string]scorecard.Grade{
+		"deployment-invalid-quantity": scorecard.GradeCritical,
+		"pvc-invalid-quantity":        scorecard.GradeCritical,
+		"pvc-valid-quantity":          scorecard.GradeAllOK,
+	}, grades)
+
+	assert.Len(t, comments["deployment-invalid-quantity"], 2)
+	assert.Equal(t, "spec.template.spec.containers[0].resources.requests.memory", comments["deployment-invalid-quantity"][0].Path)
+	assert.Equal(t, `The quantity "512MB" is not a valid resource quantity`, comments["deployment-invalid-quantity"][0].Summary)
+	assert.Equal(t, "spec.template.spec.containers[0].resources.limits.memory", comments["deployment-invalid-quantity"][1].Path)
+	assert.Equal(t, "spec.resources.requests.storage", comments["pvc-invalid-quantity"][0].Path)
+}
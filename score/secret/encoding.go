@@ -0,0 +1,67 @@
+package secret
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// minDoubleEncodedLength is the shortest value that is considered by the "Secret Double Encoded" check, shorter values
+// are too likely to be valid base64 by chance
+const minDoubleEncodedLength = 8
+
+// secretDoubleEncoded checks if any of the values in the data of a Secret, which have already been base64 decoded once,
+// is base64 encoded printable text. The values are never included in the comments.
+func secretDoubleEncoded(secret corev1.Secret) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	var keys []string
+	for key, value := range secret.Data {
+		if isBase64EncodedText(value) {
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return
+	}
+	sort.Strings(keys)
+
+	which := fmt.Sprintf("The values of the keys %s are", strings.Join(keys, ", "))
+	if len(keys) == 1 {
+		which = fmt.Sprintf("The value of the key %s is", keys[0])
+	}
+
+	score.Grade = scorecard.GradeWarning
+	score.AddComment("data", "The Secret contains values that look base64 encoded twice",
+		fmt.Sprintf("%s base64 encoded text after the data has been decoded, so the containers get the base64 encoded value instead of the original one. "+
+			"Encode the values in data only once, or use stringData for values that are not encoded.", which),
+	)
+	return
+}
+
+// isBase64EncodedText returns true if the value is padded base64, that decodes to non-empty printable UTF-8 text
+func isBase64EncodedText(value []byte) bool {
+	if len(value) < minDoubleEncodedLength {
+		return false
+	}
+
+	decoded, err := base64.StdEncoding.Strict().DecodeString(string(value))
+	if err != nil || len(decoded) == 0 || !utf8.Valid(decoded) {
+		return false
+	}
+
+	for _, r := range string(decoded) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
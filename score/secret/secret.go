@@ -0,0 +1,65 @@
+package secret
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/zegl/kube-score/score/checks"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func Register(allChecks *checks.Checks) {
+	allChecks.RegisterOptionalSecretCheck("Secret TLS Type", `Makes sure that Secrets with TLS certificates and keys have the type kubernetes.io/tls`, secretTLSType)
+	allChecks.RegisterOptionalSecretCheck("Secret Double Encoded", `Makes sure that the data of Secrets is not base64 encoded twice, values that decode to base64 encoded printable text are likely to be encoded by mistake`, secretDoubleEncoded)
+}
+
+// pemPrefix is the start of all PEM encoded certificates and keys
+var pemPrefix = []byte("-----BEGIN ")
+
+// secretTLSType checks if an Opaque Secret contains the keys of a kubernetes.io/tls Secret, or values that look like
+// PEM encoded certificates or keys. The values are never included in the comments.
+func secretTLSType(secret corev1.Secret) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	if secret.Type != "" && secret.Type != corev1.SecretTypeOpaque {
+		return
+	}
+
+	triggered := make(map[string]struct{})
+	for key, value := range secret.Data {
+		if key == corev1.TLSCertKey || key == corev1.TLSPrivateKeyKey || bytes.HasPrefix(bytes.TrimSpace(value), pemPrefix) {
+			triggered[key] = struct{}{}
+		}
+	}
+	for key, value := range secret.StringData {
+		if key == corev1.TLSCertKey || key == corev1.TLSPrivateKeyKey || strings.HasPrefix(strings.TrimSpace(value), string(pemPrefix)) {
+			triggered[key] = struct{}{}
+		}
+	}
+
+	if len(triggered) == 0 {
+		return
+	}
+
+	var keys []string
+	for key := range triggered {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	which := fmt.Sprintf("The keys %s look", strings.Join(keys, ", "))
+	if len(keys) == 1 {
+		which = fmt.Sprintf("The key %s looks", keys[0])
+	}
+
+	score.Grade = scorecard.GradeWarning
+	score.AddComment("", "The Secret contains TLS material, but is not of type kubernetes.io/tls",
+		fmt.Sprintf("%s like a TLS certificate or key. Secrets of type kubernetes.io/tls are validated to contain tls.crt and tls.key, and are recognized by Ingress controllers and other tools. "+
+			"Set type to kubernetes.io/tls, and store the certificate in tls.crt and the key in tls.key.", which),
+	)
+	return
+}
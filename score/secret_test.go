@@ -0,0 +1,65 @@
+package score
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func testSecretTLSTypeConfig(filename string) config.Configuration {
+	return config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile(filename)},
+		EnabledOptionalTests: map[string]struct{}{"secret-tls-type": {}},
+	}
+}
+
+func TestSecretTLSTypeOpaque(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, testSecretTLSTypeConfig("secret-tls-opaque.yaml"), "Secret TLS Type", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "The Secret contains TLS material, but is not of type kubernetes.io/tls", comments[0].Summary)
+	assert.Contains(t, comments[0].Description, "The keys tls.crt, tls.key look like")
+	assert.NotContains(t, comments[0].Description, "not-a-real")
+}
+
+func TestSecretTLSTypePEM(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, testSecretTLSTypeConfig("secret-tls-pem.yaml"), "Secret TLS Type", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Contains(t, comments[0].Description, "The key ca.pem looks like")
+	assert.NotContains(t, comments[0].Description, "BEGIN")
+}
+
+func TestSecretTLSTypeTyped(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, testSecretTLSTypeConfig("secret-tls-typed.yaml"), "Secret TLS Type", scorecard.GradeAllOK)
+}
+
+func TestSecretTLSTypeNoTLS(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, testSecretTLSTypeConfig("secret-immutable-not-set.yaml"), "Secret TLS Type", scorecard.GradeAllOK)
+}
+
+func TestSecretDoubleEncoded(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("secret-double-encoded.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"secret-double-encoded": {}},
+	}, "Secret Double Encoded", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "The Secret contains values that look base64 encoded twice", comments[0].Summary)
+	assert.Contains(t, comments[0].Description, "The value of the key api-token is")
+	assert.NotContains(t, comments[0].Description, "correct-horse-battery")
+}
+
+func TestSecretDoubleEncodedOnce(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("secret-tls-opaque.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"secret-double-encoded": {}},
+	}, "Secret Double Encoded", scorecard.GradeAllOK)
+}
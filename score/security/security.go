@@ -1,14 +1,20 @@
 package security
 
 import (
+	"fmt"
+	"path"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/zegl/kube-score/config"
 	"github.com/zegl/kube-score/score/checks"
+	"github.com/zegl/kube-score/score/internal"
 	"github.com/zegl/kube-score/scorecard"
 )
 
-func Register(allChecks *checks.Checks) {
+func Register(allChecks *checks.Checks, kubernetesVersion config.Semver) {
 	allChecks.RegisterOptionalPodCheck("Container Security Context", `Makes sure that all pods have good securityContexts configured`, containerSecurityContext)
 
 	allChecks.RegisterPodCheck("Container Security Context User Group ID", `Makes sure that all pods have a security context with valid UID and GID set `, containerSecurityContextUserGroupID)
@@ -16,6 +22,11 @@ func Register(allChecks *checks.Checks) {
 	allChecks.RegisterPodCheck("Container Security Context ReadOnlyRootFilesystem", "Makes sure that all pods have a security context with read only filesystem set", containerSecurityContextReadOnlyRootFilesystem)
 
 	allChecks.RegisterOptionalPodCheck("Container Seccomp Profile", `Makes sure that all pods have at a seccomp policy configured.`, podSeccompProfile)
+	allChecks.RegisterOptionalPodCheck("Container Seccomp Profile Field", `Makes sure that all containers have securityContext.seccompProfile set to RuntimeDefault or Localhost, either on the container or on the pod`, containerSeccompProfileField(kubernetesVersion))
+	allChecks.RegisterOptionalPodCheck("Container Token Mount", `Makes sure that containers are not manually mounting a volume at the service account token path while the token is also automounted`, containerTokenMount)
+	allChecks.RegisterOptionalPodCheck("Pod Host Namespaces", `Makes sure that pods don't share the network, PID or IPC namespace of the host`, podHostNamespaces)
+	allChecks.RegisterOptionalPodCheck("Container RunAsUser Root", `Makes sure that no container explicitly sets securityContext.runAsUser to 0, either on the container or on the pod`, containerRunAsUserRoot)
+	allChecks.RegisterOptionalPodCheck("Pod FSGroup", `Makes sure that pods running as non-root that mount writable volumes have a securityContext.fsGroup set`, podFSGroup)
 }
 
 // containerSecurityContextReadOnlyRootFilesystem checks for pods using writeable root filesystems
@@ -29,13 +40,13 @@ func containerSecurityContextReadOnlyRootFilesystem(podTemplate corev1.PodTempla
 	for _, container := range allContainers {
 		if container.SecurityContext == nil {
 			noContextSet = true
-			score.AddComment(container.Name, "Container has no configured security context", "Set securityContext to run the container in a more secure context.")
+			score.AddCommentWithCode(container.Name, "Container has no configured security context", "Set securityContext to run the container in a more secure context.", scorecard.CodeContainerNoSecurityContext)
 			continue
 		}
 		sec := container.SecurityContext
 		if sec.ReadOnlyRootFilesystem == nil || *sec.ReadOnlyRootFilesystem == false {
 			hasWritableRootFS = true
-			score.AddComment(container.Name, "The pod has a container with a writable root filesystem", "Set securityContext.readOnlyRootFilesystem to true")
+			score.AddCommentWithCode(container.Name, "The pod has a container with a writable root filesystem", "Set securityContext.readOnlyRootFilesystem to true", scorecard.CodeContainerWritableRootFS)
 		}
 	}
 
@@ -56,7 +67,7 @@ func containerSecurityContextPrivileged(podTemplate corev1.PodTemplateSpec, type
 	for _, container := range allContainers {
 		if container.SecurityContext != nil && container.SecurityContext.Privileged != nil && *container.SecurityContext.Privileged {
 			hasPrivileged = true
-			score.AddComment(container.Name, "The container is privileged", "Set securityContext.privileged to false. Privileged containers can access all devices on the host, and grants almost the same access as non-containerized processes on the host.")
+			score.AddCommentWithCode(container.Name, "The container is privileged", "Set securityContext.privileged to false. Privileged containers can access all devices on the host, and grants almost the same access as non-containerized processes on the host.", scorecard.CodeContainerPrivileged)
 		}
 	}
 	if hasPrivileged {
@@ -78,7 +89,7 @@ func containerSecurityContextUserGroupID(podTemplate corev1.PodTemplateSpec, typ
 	for _, container := range allContainers {
 		if container.SecurityContext == nil && podSecurityContext == nil {
 			noContextSet = true
-			score.AddComment(container.Name, "Container has no configured security context", "Set securityContext to run the container in a more secure context.")
+			score.AddCommentWithCode(container.Name, "Container has no configured security context", "Set securityContext to run the container in a more secure context.", scorecard.CodeContainerNoSecurityContext)
 			continue
 		}
 		sec := container.SecurityContext
@@ -96,12 +107,12 @@ func containerSecurityContextUserGroupID(podTemplate corev1.PodTemplateSpec, typ
 		}
 		if sec.RunAsUser == nil || *sec.RunAsUser < 10000 {
 			hasLowUserID = true
-			score.AddComment(container.Name, "The container is running with a low user ID", "A userid above 10 000 is recommended to avoid conflicts with the host. Set securityContext.runAsUser to a value > 10000")
+			score.AddCommentWithCode(container.Name, "The container is running with a low user ID", "A userid above 10 000 is recommended to avoid conflicts with the host. Set securityContext.runAsUser to a value > 10000", scorecard.CodeContainerLowUserID)
 		}
 
 		if sec.RunAsGroup == nil || *sec.RunAsGroup < 10000 {
 			hasLowGroupID = true
-			score.AddComment(container.Name, "The container running with a low group ID", "A groupid above 10 000 is recommended to avoid conflicts with the host. Set securityContext.runAsGroup to a value > 10000")
+			score.AddCommentWithCode(container.Name, "The container running with a low group ID", "A groupid above 10 000 is recommended to avoid conflicts with the host. Set securityContext.runAsGroup to a value > 10000", scorecard.CodeContainerLowGroupID)
 		}
 	}
 	if noContextSet || hasLowUserID || hasLowGroupID {
@@ -130,7 +141,7 @@ func containerSecurityContext(podTemplate corev1.PodTemplateSpec, typeMeta metav
 
 		if container.SecurityContext == nil && podSecurityContext == nil {
 			noContextSet = true
-			score.AddComment(container.Name, "Container has no configured security context", "Set securityContext to run the container in a more secure context.")
+			score.AddCommentWithCode(container.Name, "Container has no configured security context", "Set securityContext to run the container in a more secure context.", scorecard.CodeContainerNoSecurityContext)
 			continue
 		}
 
@@ -152,22 +163,22 @@ func containerSecurityContext(podTemplate corev1.PodTemplateSpec, typeMeta metav
 
 		if sec.Privileged != nil && *sec.Privileged {
 			hasPrivileged = true
-			score.AddComment(container.Name, "The container is privileged", "Set securityContext.privileged to false. Privileged containers can access all devices on the host, and grants almost the same access as non-containerized processes on the host.")
+			score.AddCommentWithCode(container.Name, "The container is privileged", "Set securityContext.privileged to false. Privileged containers can access all devices on the host, and grants almost the same access as non-containerized processes on the host.", scorecard.CodeContainerPrivileged)
 		}
 
 		if sec.ReadOnlyRootFilesystem == nil || *sec.ReadOnlyRootFilesystem == false {
 			hasWritableRootFS = true
-			score.AddComment(container.Name, "The pod has a container with a writable root filesystem", "Set securityContext.readOnlyRootFilesystem to true")
+			score.AddCommentWithCode(container.Name, "The pod has a container with a writable root filesystem", "Set securityContext.readOnlyRootFilesystem to true", scorecard.CodeContainerWritableRootFS)
 		}
 
 		if sec.RunAsUser == nil || *sec.RunAsUser < 10000 {
 			hasLowUserID = true
-			score.AddComment(container.Name, "The container is running with a low user ID", "A userid above 10 000 is recommended to avoid conflicts with the host. Set securityContext.runAsUser to a value > 10000")
+			score.AddCommentWithCode(container.Name, "The container is running with a low user ID", "A userid above 10 000 is recommended to avoid conflicts with the host. Set securityContext.runAsUser to a value > 10000", scorecard.CodeContainerLowUserID)
 		}
 
 		if sec.RunAsGroup == nil || *sec.RunAsGroup < 10000 {
 			hasLowGroupID = true
-			score.AddComment(container.Name, "The container running with a low group ID", "A groupid above 10 000 is recommended to avoid conflicts with the host. Set securityContext.runAsGroup to a value > 10000")
+			score.AddCommentWithCode(container.Name, "The container running with a low group ID", "A groupid above 10 000 is recommended to avoid conflicts with the host. Set securityContext.runAsGroup to a value > 10000", scorecard.CodeContainerLowGroupID)
 		}
 	}
 
@@ -193,10 +204,248 @@ func podSeccompProfile(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeM
 
 	if !seccompAnnotated {
 		score.Grade = scorecard.GradeWarning
-		score.AddComment(metadata.Name, "The pod has not configured Seccomp for its containers", "Running containers with Seccomp is recommended to reduce the kernel attack surface")
+		score.AddCommentWithCode(metadata.Name, "The pod has not configured Seccomp for its containers", "Running containers with Seccomp is recommended to reduce the kernel attack surface", scorecard.CodePodNoSeccomp)
 	} else {
 		score.Grade = scorecard.GradeAllOK
 	}
 
 	return
 }
+
+// seccompProfileFieldSince is the first version of Kubernetes where securityContext.seccompProfile is GA, older
+// versions configure seccomp with annotations, see podSeccompProfile
+var seccompProfileFieldSince = config.Semver{Major: 1, Minor: 19}
+
+// containerSeccompProfileField checks that all containers run with the RuntimeDefault or a Localhost seccomp profile,
+// the profile of the container is inherited from the pod if it's not set on the container
+func containerSeccompProfileField(kubernetesVersion config.Semver) func(corev1.PodTemplateSpec, metav1.TypeMeta) scorecard.TestScore {
+	return func(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+		if kubernetesVersion.LessThan(seccompProfileFieldSince) {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", fmt.Sprintf("Skipped because securityContext.seccompProfile is not supported before Kubernetes %s", seccompProfileFieldSince), "")
+			return
+		}
+
+		var podProfile *corev1.SeccompProfile
+		if podTemplate.Spec.SecurityContext != nil {
+			podProfile = podTemplate.Spec.SecurityContext.SeccompProfile
+		}
+
+		allContainers := podTemplate.Spec.InitContainers
+		allContainers = append(allContainers, podTemplate.Spec.Containers...)
+
+		score.Grade = scorecard.GradeAllOK
+		for _, container := range allContainers {
+			profile := podProfile
+			if container.SecurityContext != nil && container.SecurityContext.SeccompProfile != nil {
+				profile = container.SecurityContext.SeccompProfile
+			}
+
+			if profile == nil {
+				score.Grade = scorecard.GradeWarning
+				score.AddCommentWithCode(container.Name, "The container has no seccompProfile",
+					"Set securityContext.seccompProfile.type to RuntimeDefault on the pod or the container, to reduce the kernel attack surface",
+					scorecard.CodeContainerNoSeccompProfile)
+				continue
+			}
+
+			if profile.Type != corev1.SeccompProfileTypeRuntimeDefault && profile.Type != corev1.SeccompProfileTypeLocalhost {
+				score.Grade = scorecard.GradeWarning
+				score.AddCommentWithCode(container.Name, fmt.Sprintf("The container runs with the %s seccompProfile", profile.Type),
+					"Set securityContext.seccompProfile.type to RuntimeDefault or Localhost, to reduce the kernel attack surface",
+					scorecard.CodeContainerUnconfinedSeccompProfile)
+			}
+		}
+
+		return
+	}
+}
+
+// serviceAccountTokenPath is the path where Kubernetes mounts the service account token
+const serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// containerTokenMount checks that no container is mounting a volume at the service account token path, if
+// the token is also automatically mounted by Kubernetes
+func containerTokenMount(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	// automountServiceAccountToken defaults to true
+	automount := podTemplate.Spec.AutomountServiceAccountToken
+	if automount != nil && !*automount {
+		return
+	}
+
+	allContainers := podTemplate.Spec.InitContainers
+	allContainers = append(allContainers, podTemplate.Spec.Containers...)
+
+	for _, container := range allContainers {
+		for _, mount := range container.VolumeMounts {
+			mountPath := path.Clean(mount.MountPath)
+			if mountPath != serviceAccountTokenPath && !strings.HasPrefix(mountPath, serviceAccountTokenPath+"/") {
+				continue
+			}
+
+			score.Grade = scorecard.GradeWarning
+			score.AddComment(container.Name,
+				fmt.Sprintf("The container mounts the volume %s at the service account token path %s", mount.Name, mount.MountPath),
+				"The service account token is already mounted automatically. Remove the volumeMount, or set automountServiceAccountToken to false if the token is intentionally provided by the volume.",
+			)
+		}
+	}
+
+	return
+}
+
+// podFSGroup checks that pods running as a non-root user, that are mounting writable volumes, have set a fsGroup
+func podFSGroup(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	podSecurityContext := podTemplate.Spec.SecurityContext
+	if podSecurityContext != nil && podSecurityContext.FSGroup != nil {
+		score.Grade = scorecard.GradeAllOK
+		return
+	}
+
+	allContainers := podTemplate.Spec.InitContainers
+	allContainers = append(allContainers, podTemplate.Spec.Containers...)
+
+	volumes := make(map[string]corev1.Volume)
+	for _, volume := range podTemplate.Spec.Volumes {
+		volumes[volume.Name] = volume
+	}
+
+	var writableVolumes []string
+	seenVolumes := make(map[string]struct{})
+
+	for _, container := range allContainers {
+		if !containerRunsAsNonRoot(podSecurityContext, container.SecurityContext) {
+			continue
+		}
+
+		for _, mount := range container.VolumeMounts {
+			volume, ok := volumes[mount.Name]
+			if !ok || !isWritableVolume(volume, mount) {
+				continue
+			}
+			if _, ok := seenVolumes[volume.Name]; ok {
+				continue
+			}
+			seenVolumes[volume.Name] = struct{}{}
+			writableVolumes = append(writableVolumes, volume.Name)
+		}
+	}
+
+	if len(writableVolumes) == 0 {
+		score.Grade = scorecard.GradeAllOK
+		return
+	}
+
+	score.Grade = scorecard.GradeWarning
+	score.AddComment(internal.PodSpecPath(typeMeta)+".securityContext.fsGroup",
+		fmt.Sprintf("The pod mounts writable volumes without a fsGroup: %s", strings.Join(writableVolumes, ", ")),
+		"Non-root containers are often unable to write to mounted volumes unless the volume is owned by a group that the container is a member of. Set securityContext.fsGroup on the pod to make the volumes group-writable.",
+	)
+	return
+}
+
+// containerRunAsUserRoot checks that no container runs as root because runAsUser is explicitly set to 0. The runAsUser
+// of the container takes precedence over the runAsUser of the pod.
+func containerRunAsUserRoot(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	allContainers := append([]corev1.Container{}, podTemplate.Spec.InitContainers...)
+	allContainers = append(allContainers, podTemplate.Spec.Containers...)
+
+	for _, container := range allContainers {
+		var runAsUser *int64
+		if podTemplate.Spec.SecurityContext != nil {
+			runAsUser = podTemplate.Spec.SecurityContext.RunAsUser
+		}
+		if container.SecurityContext != nil && container.SecurityContext.RunAsUser != nil {
+			runAsUser = container.SecurityContext.RunAsUser
+		}
+
+		if runAsUser == nil || *runAsUser != 0 {
+			continue
+		}
+
+		score.Grade = scorecard.GradeCritical
+		score.AddCommentWithCode(container.Name, "The container explicitly runs as root with runAsUser set to 0",
+			"Unlike a container that only has a low user ID, or no runAsUser at all, setting runAsUser to 0 deliberately overrides the user of the image, even if the image runs as a non-root user. "+
+				"A process that escapes the container has root access to the host. Remove runAsUser, or set it to a non-zero value above 10000.",
+			scorecard.CodeContainerRunAsRoot)
+	}
+
+	return
+}
+
+// containerRunsAsNonRoot returns true if the container has been configured to not run as the root user, either
+// via runAsNonRoot or a non-zero runAsUser. Values are inherited from the PodSecurityContext if not set on the container.
+func containerRunsAsNonRoot(podSecurityContext *corev1.PodSecurityContext, sec *corev1.SecurityContext) bool {
+	var runAsNonRoot *bool
+	var runAsUser *int64
+
+	if podSecurityContext != nil {
+		runAsNonRoot = podSecurityContext.RunAsNonRoot
+		runAsUser = podSecurityContext.RunAsUser
+	}
+	if sec != nil {
+		if sec.RunAsNonRoot != nil {
+			runAsNonRoot = sec.RunAsNonRoot
+		}
+		if sec.RunAsUser != nil {
+			runAsUser = sec.RunAsUser
+		}
+	}
+
+	if runAsUser != nil {
+		return *runAsUser != 0
+	}
+	return runAsNonRoot != nil && *runAsNonRoot
+}
+
+// isWritableVolume returns true if the volume is a PersistentVolumeClaim, or any other volume type that is
+// mounted as writable. ConfigMaps, Secrets, DownwardAPI and Projected volumes are always read only.
+func isWritableVolume(volume corev1.Volume, mount corev1.VolumeMount) bool {
+	if volume.PersistentVolumeClaim != nil {
+		return !volume.PersistentVolumeClaim.ReadOnly && !mount.ReadOnly
+	}
+	if mount.ReadOnly {
+		return false
+	}
+	if volume.ConfigMap != nil || volume.Secret != nil || volume.DownwardAPI != nil || volume.Projected != nil {
+		return false
+	}
+	return true
+}
+
+// podHostNamespaces checks that the pod does not use hostNetwork, hostPID or hostIPC
+func podHostNamespaces(podTemplate corev1.PodTemplateSpec, typeMeta metav1.TypeMeta) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	specPath := internal.PodSpecPath(typeMeta)
+	spec := podTemplate.Spec
+
+	namespaces := []struct {
+		field       string
+		enabled     bool
+		summary     string
+		description string
+	}{
+		{"hostNetwork", spec.HostNetwork, "The pod shares the network namespace of the host",
+			"The containers can listen on and connect to any interface of the node, including ports that are only bound to localhost, and bypass NetworkPolicies. Remove hostNetwork, and use a Service or hostPort if the pod needs to be reachable on the node."},
+		{"hostPID", spec.HostPID, "The pod shares the PID namespace of the host",
+			"The containers can see all processes on the node, read their environment variables and, if running as the same user, signal or attach to them. Remove hostPID."},
+		{"hostIPC", spec.HostIPC, "The pod shares the IPC namespace of the host",
+			"The containers can read and write the shared memory and message queues of all processes on the node. Remove hostIPC."},
+	}
+
+	for _, ns := range namespaces {
+		if !ns.enabled {
+			continue
+		}
+		score.Grade = scorecard.GradeCritical
+		score.AddComment(specPath+"."+ns.field, ns.summary, ns.description)
+	}
+
+	return
+}
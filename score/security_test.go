@@ -35,6 +35,7 @@ func TestPodSecurityContext(test *testing.T) {
 				Path:        "foobar",
 				Summary:     "Container has no configured security context",
 				Description: "Set securityContext to run the container in a more secure context.",
+				Code:        scorecard.CodeContainerNoSecurityContext,
 			},
 		},
 		// All required variables set correctly
@@ -58,6 +59,7 @@ func TestPodSecurityContext(test *testing.T) {
 				Path:        "foobar",
 				Summary:     "The pod has a container with a writable root filesystem",
 				Description: "Set securityContext.readOnlyRootFilesystem to true",
+				Code:        scorecard.CodeContainerWritableRootFS,
 			},
 		},
 		{
@@ -69,6 +71,7 @@ func TestPodSecurityContext(test *testing.T) {
 				Path:        "foobar",
 				Summary:     "The pod has a container with a writable root filesystem",
 				Description: "Set securityContext.readOnlyRootFilesystem to true",
+				Code:        scorecard.CodeContainerWritableRootFS,
 			},
 		},
 
@@ -80,6 +83,7 @@ func TestPodSecurityContext(test *testing.T) {
 				Path:        "foobar",
 				Summary:     "The pod has a container with a writable root filesystem",
 				Description: "Set securityContext.readOnlyRootFilesystem to true",
+				Code:        scorecard.CodeContainerWritableRootFS,
 			},
 		},
 		// Context is non nul, but has all null values
@@ -90,6 +94,7 @@ func TestPodSecurityContext(test *testing.T) {
 				Path:        "foobar",
 				Summary:     "The container is running with a low user ID",
 				Description: "A userid above 10 000 is recommended to avoid conflicts with the host. Set securityContext.runAsUser to a value > 10000",
+				Code:        scorecard.CodeContainerLowUserID,
 			},
 		},
 		// Context is non nul, but has all null values
@@ -100,6 +105,7 @@ func TestPodSecurityContext(test *testing.T) {
 				Path:        "foobar",
 				Summary:     "The container running with a low group ID",
 				Description: "A groupid above 10 000 is recommended to avoid conflicts with the host. Set securityContext.runAsGroup to a value > 10000",
+				Code:        scorecard.CodeContainerLowGroupID,
 			},
 		},
 		// PodSecurityContext is set, assert that the values are inherited
@@ -134,6 +140,7 @@ func TestPodSecurityContext(test *testing.T) {
 				Path:        "foobar",
 				Summary:     "The container running with a low group ID",
 				Description: "A groupid above 10 000 is recommended to avoid conflicts with the host. Set securityContext.runAsGroup to a value > 10000",
+				Code:        scorecard.CodeContainerLowGroupID,
 			},
 		},
 
@@ -180,6 +187,7 @@ func TestPodSecurityContext(test *testing.T) {
 				Path:        "foobar",
 				Summary:     "The container is privileged",
 				Description: "Set securityContext.privileged to false. Privileged containers can access all devices on the host, and grants almost the same access as non-containerized processes on the host.",
+				Code:        scorecard.CodeContainerPrivileged,
 			},
 		},
 	}
@@ -310,6 +318,44 @@ func TestContainerSeccompAllGood(t *testing.T) {
 	}, "Container Seccomp Profile", scorecard.GradeAllOK)
 }
 
+func testSeccompProfileField(t *testing.T, filename string, kubernetesVersion config.Semver, expectedScore scorecard.Grade) []scorecard.TestScoreComment {
+	return testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile(filename)},
+		EnabledOptionalTests: map[string]struct{}{"container-seccomp-profile-field": {}},
+		KubernetesVersion:    kubernetesVersion,
+	}, "Container Seccomp Profile Field", expectedScore)
+}
+
+func TestContainerSeccompProfileFieldMissing(t *testing.T) {
+	t.Parallel()
+	comments := testSeccompProfileField(t, "pod-seccomp-profile-field-missing.yaml", config.Semver{1, 19}, scorecard.GradeWarning)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "foobar", comments[0].Path)
+	assert.Equal(t, "The container has no seccompProfile", comments[0].Summary)
+	assert.Equal(t, "unconfined", comments[1].Path)
+	assert.Equal(t, "The container runs with the Unconfined seccompProfile", comments[1].Summary)
+}
+
+func TestContainerSeccompProfileFieldInherited(t *testing.T) {
+	t.Parallel()
+	comments := testSeccompProfileField(t, "pod-seccomp-profile-field-inherited.yaml", config.Semver{1, 19}, scorecard.GradeAllOK)
+	assert.Empty(t, comments)
+}
+
+func TestContainerSeccompProfileFieldOverridden(t *testing.T) {
+	t.Parallel()
+	comments := testSeccompProfileField(t, "pod-seccomp-profile-field-overridden.yaml", config.Semver{1, 19}, scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "The container runs with the Unconfined seccompProfile", comments[0].Summary)
+}
+
+func TestContainerSeccompProfileFieldOldKubernetes(t *testing.T) {
+	t.Parallel()
+	comments := testSeccompProfileField(t, "pod-seccomp-profile-field-missing.yaml", config.Semver{1, 18}, scorecard.GradeAllOK)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "Skipped because securityContext.seccompProfile is not supported before Kubernetes v1.19", comments[0].Summary)
+}
+
 func TestContainerSecurityContextUserGroupIDAllGood(t *testing.T) {
 	t.Parallel()
 	structMap := make(map[string]struct{})
@@ -333,6 +379,7 @@ func TestContainerSecurityContextUserGroupIDLowGroup(t *testing.T) {
 		Path:        "foobar",
 		Summary:     "The container running with a low group ID",
 		Description: "A groupid above 10 000 is recommended to avoid conflicts with the host. Set securityContext.runAsGroup to a value > 10000",
+		Code:        scorecard.CodeContainerLowGroupID,
 	})
 }
 
@@ -348,6 +395,7 @@ func TestContainerSecurityContextUserGroupIDLowUser(t *testing.T) {
 		Path:        "foobar",
 		Summary:     "The container is running with a low user ID",
 		Description: "A userid above 10 000 is recommended to avoid conflicts with the host. Set securityContext.runAsUser to a value > 10000",
+		Code:        scorecard.CodeContainerLowUserID,
 	})
 }
 
@@ -363,6 +411,7 @@ func TestContainerSecurityContextUserGroupIDNoSecurityContext(t *testing.T) {
 		Path:        "foobar",
 		Summary:     "Container has no configured security context",
 		Description: "Set securityContext to run the container in a more secure context.",
+		Code:        scorecard.CodeContainerNoSecurityContext,
 	})
 }
 
@@ -389,6 +438,7 @@ func TestContainerSecurityContextPrivilegedPrivileged(t *testing.T) {
 		Path:        "foobar",
 		Summary:     "The container is privileged",
 		Description: "Set securityContext.privileged to false. Privileged containers can access all devices on the host, and grants almost the same access as non-containerized processes on the host.",
+		Code:        scorecard.CodeContainerPrivileged,
 	})
 }
 
@@ -415,6 +465,7 @@ func TestContainerSecurityContextReadOnlyRootFilesystemWriteable(t *testing.T) {
 		Path:        "foobar",
 		Summary:     "The pod has a container with a writable root filesystem",
 		Description: "Set securityContext.readOnlyRootFilesystem to true",
+		Code:        scorecard.CodeContainerWritableRootFS,
 	})
 }
 
@@ -430,5 +481,132 @@ func TestContainerSecurityContextReadOnlyRootFilesystemNoSecurityContext(t *test
 		Path:        "foobar",
 		Summary:     "Container has no configured security context",
 		Description: "Set securityContext to run the container in a more secure context.",
+		Code:        scorecard.CodeContainerNoSecurityContext,
 	})
 }
+
+func TestPodFSGroupMissing(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles: []ks.NamedReader{testFile("pod-fsgroup-missing.yaml")},
+		EnabledOptionalTests: map[string]struct{}{
+			"pod-fsgroup": {},
+		},
+	}, "Pod FSGroup", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "The pod mounts writable volumes without a fsGroup: data", comments[0].Summary)
+	assert.Equal(t, "spec.securityContext.fsGroup", comments[0].Path)
+}
+
+func TestPodFSGroupSet(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles: []ks.NamedReader{testFile("pod-fsgroup-set.yaml")},
+		EnabledOptionalTests: map[string]struct{}{
+			"pod-fsgroup": {},
+		},
+	}, "Pod FSGroup", scorecard.GradeAllOK)
+}
+
+func TestPodFSGroupReadOnlyVolumes(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles: []ks.NamedReader{testFile("pod-fsgroup-readonly-volumes.yaml")},
+		EnabledOptionalTests: map[string]struct{}{
+			"pod-fsgroup": {},
+		},
+	}, "Pod FSGroup", scorecard.GradeAllOK)
+}
+
+func TestContainerTokenMountWithAutomount(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles: []ks.NamedReader{testFile("pod-token-mount-automount.yaml")},
+		EnabledOptionalTests: map[string]struct{}{
+			"container-token-mount": {},
+		},
+	}, "Container Token Mount", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "foobar", comments[0].Path)
+}
+
+func TestContainerTokenMountWithoutAutomount(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles: []ks.NamedReader{testFile("pod-token-mount-no-automount.yaml")},
+		EnabledOptionalTests: map[string]struct{}{
+			"container-token-mount": {},
+		},
+	}, "Container Token Mount", scorecard.GradeAllOK)
+}
+
+func TestContainerTokenMountNoMount(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles: []ks.NamedReader{testFile("pod-image-tag-fixed.yaml")},
+		EnabledOptionalTests: map[string]struct{}{
+			"container-token-mount": {},
+		},
+	}, "Container Token Mount", scorecard.GradeAllOK)
+}
+
+func TestPodHostNamespaces(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles: []ks.NamedReader{testFile("pod-host-namespaces.yaml")},
+		EnabledOptionalTests: map[string]struct{}{
+			"pod-host-namespaces": {},
+		},
+	}, "Pod Host Namespaces", scorecard.GradeCritical)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "spec.template.spec.hostNetwork", comments[0].Path)
+	assert.Equal(t, "The pod shares the network namespace of the host", comments[0].Summary)
+	assert.Equal(t, "spec.template.spec.hostPID", comments[1].Path)
+	assert.Equal(t, "The pod shares the PID namespace of the host", comments[1].Summary)
+}
+
+func TestPodHostNamespacesUnset(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles: []ks.NamedReader{testFile("pod-host-namespaces-unset.yaml")},
+		EnabledOptionalTests: map[string]struct{}{
+			"pod-host-namespaces": {},
+		},
+	}, "Pod Host Namespaces", scorecard.GradeAllOK)
+}
+
+func TestContainerRunAsUserRootInherited(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles: []ks.NamedReader{testFile("pod-runasuser-root.yaml")},
+		EnabledOptionalTests: map[string]struct{}{
+			"container-runasuser-root": {},
+		},
+	}, "Container RunAsUser Root", scorecard.GradeCritical)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "inherited", comments[0].Path)
+	assert.Equal(t, "The container explicitly runs as root with runAsUser set to 0", comments[0].Summary)
+	assert.Equal(t, scorecard.CodeContainerRunAsRoot, comments[0].Code)
+}
+
+func TestContainerRunAsUserRootContainer(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles: []ks.NamedReader{testFile("pod-runasuser-root-container.yaml")},
+		EnabledOptionalTests: map[string]struct{}{
+			"container-runasuser-root": {},
+		},
+	}, "Container RunAsUser Root", scorecard.GradeCritical)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "root", comments[0].Path)
+}
+
+func TestContainerRunAsUserRootNotSet(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles: []ks.NamedReader{testFile("pod-security-context-all-good.yaml")},
+		EnabledOptionalTests: map[string]struct{}{
+			"container-runasuser-root": {},
+		},
+	}, "Container RunAsUser Root", scorecard.GradeAllOK)
+}
@@ -1,7 +1,11 @@
 package service
 
 import (
+	"fmt"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/score/checks"
@@ -11,7 +15,33 @@ import (
 
 func Register(allChecks *checks.Checks, pods ks.Pods, podspeccers ks.PodSpeccers) {
 	allChecks.RegisterServiceCheck("Service Targets Pod", `Makes sure that all Services targets a Pod`, serviceTargetsPod(pods.Pods(), podspeccers.PodSpeccers()))
+	allChecks.CrossObject("Service Targets Pod")
 	allChecks.RegisterServiceCheck("Service Type", `Makes sure that the Service type is not NodePort`, serviceType)
+	allChecks.RegisterServiceCheck("Service Container Protocol Match", `Makes sure that the protocol of the Service ports are the same as the protocol of the container ports that they target`, serviceContainerProtocolMatch(pods.Pods(), podspeccers.PodSpeccers()))
+	allChecks.CrossObject("Service Container Protocol Match")
+	allChecks.RegisterOptionalServiceCheck("Service Insecure Exposed Port", `Makes sure that LoadBalancer and NodePort Services are not exposing sensitive well-known ports`, serviceInsecureExposedPort)
+	allChecks.RegisterOptionalServiceCheck("Service Hardcoded NodePort", `Makes sure that NodePort and LoadBalancer Services let the cluster allocate the nodePort of all ports`, serviceHardcodedNodePort)
+}
+
+// SensitivePorts is the list of well-known ports that should not be exposed outside of the cluster,
+// used by the "Service Insecure Exposed Port" check
+var SensitivePorts = map[int32]string{
+	22:    "SSH",
+	23:    "Telnet",
+	2375:  "Docker",
+	3306:  "MySQL",
+	3389:  "RDP",
+	5432:  "PostgreSQL",
+	6379:  "Redis",
+	9200:  "Elasticsearch",
+	11211: "Memcached",
+	27017: "MongoDB",
+}
+
+// InternetFacingAnnotations are annotations (and their values) that makes a LoadBalancer reachable from the internet
+var InternetFacingAnnotations = map[string]string{
+	"service.beta.kubernetes.io/aws-load-balancer-scheme":                "internet-facing",
+	"service.beta.kubernetes.io/alibaba-cloud-loadbalancer-address-type": "internet",
 }
 
 // serviceTargetsPod checks if a Service targets a pod and issues a critical warning if no matching pod
@@ -59,6 +89,98 @@ func serviceTargetsPod(pods []ks.Pod, podspecers []ks.PodSpecer) func(corev1.Ser
 	}
 }
 
+// targetedPod is a pod, or the pod template of an object, that can be targeted by a Service
+type targetedPod struct {
+	name      string
+	namespace string
+	template  corev1.PodTemplateSpec
+}
+
+// serviceContainerProtocolMatch checks that the protocol of all Service ports are the same as the protocol of the
+// container ports that they target, in all pods that are selected by the Service.
+// Ports that are not declared on any container are not checked.
+func serviceContainerProtocolMatch(pods []ks.Pod, podspecers []ks.PodSpecer) func(corev1.Service) scorecard.TestScore {
+	var allPods []targetedPod
+	for _, p := range pods {
+		pod := p.Pod()
+		allPods = append(allPods, targetedPod{
+			name:      pod.Name,
+			namespace: pod.Namespace,
+			template:  corev1.PodTemplateSpec{ObjectMeta: pod.ObjectMeta, Spec: pod.Spec},
+		})
+	}
+	for _, podSpec := range podspecers {
+		allPods = append(allPods, targetedPod{
+			name:      podSpec.GetObjectMeta().Name,
+			namespace: podSpec.GetObjectMeta().Namespace,
+			template:  podSpec.GetPodTemplateSpec(),
+		})
+	}
+
+	return func(service corev1.Service) (score scorecard.TestScore) {
+		score.Grade = scorecard.GradeAllOK
+
+		// Selectorless Services, including ExternalName Services, don't target any pods
+		if len(service.Spec.Selector) == 0 {
+			return
+		}
+
+		for _, pod := range allPods {
+			if pod.namespace != service.Namespace || !internal.LabelSelectorMatchesLabels(service.Spec.Selector, pod.template.Labels) {
+				continue
+			}
+
+			for _, servicePort := range service.Spec.Ports {
+				serviceProtocol := protocolOrDefault(servicePort.Protocol)
+
+				for _, container := range pod.template.Spec.Containers {
+					for _, containerPort := range container.Ports {
+						if !servicePortTargets(servicePort, containerPort) {
+							continue
+						}
+
+						containerProtocol := protocolOrDefault(containerPort.Protocol)
+						if containerProtocol == serviceProtocol {
+							continue
+						}
+
+						score.Grade = scorecard.GradeWarning
+						score.AddComment(servicePort.Name,
+							fmt.Sprintf("The Service port %d uses %s, but the targeted container port uses %s", servicePort.Port, serviceProtocol, containerProtocol),
+							fmt.Sprintf("The port %d of the container %s in %s uses the protocol %s, and will not receive the %s traffic that the Service sends to it. "+
+								"Set the same protocol on the Service port and the container port.", containerPort.ContainerPort, container.Name, pod.name, containerProtocol, serviceProtocol),
+						)
+					}
+				}
+			}
+		}
+
+		return
+	}
+}
+
+// protocolOrDefault returns the protocol, or TCP if the protocol is not set
+func protocolOrDefault(protocol corev1.Protocol) corev1.Protocol {
+	if protocol == "" {
+		return corev1.ProtocolTCP
+	}
+	return protocol
+}
+
+// servicePortTargets returns true if the targetPort of the servicePort references the containerPort, either by name or
+// by number. If the targetPort is not set, the port of the Service is used.
+func servicePortTargets(servicePort corev1.ServicePort, containerPort corev1.ContainerPort) bool {
+	if servicePort.TargetPort.Type == intstr.String && servicePort.TargetPort.StrVal != "" {
+		return servicePort.TargetPort.StrVal == containerPort.Name
+	}
+
+	targetPort := servicePort.TargetPort.IntVal
+	if targetPort == 0 {
+		targetPort = servicePort.Port
+	}
+	return targetPort == containerPort.ContainerPort
+}
+
 func serviceType(service corev1.Service) (score scorecard.TestScore) {
 	if service.Spec.Type == corev1.ServiceTypeNodePort {
 		score.Grade = scorecard.GradeWarning
@@ -69,3 +191,73 @@ func serviceType(service corev1.Service) (score scorecard.TestScore) {
 	score.Grade = scorecard.GradeAllOK
 	return
 }
+
+func serviceInsecureExposedPort(service corev1.Service) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	if service.Spec.Type != corev1.ServiceTypeLoadBalancer && service.Spec.Type != corev1.ServiceTypeNodePort {
+		return
+	}
+
+	internetFacing := false
+	for key, value := range InternetFacingAnnotations {
+		if v, ok := service.Annotations[key]; ok && strings.EqualFold(v, value) {
+			internetFacing = true
+		}
+	}
+
+	for _, port := range service.Spec.Ports {
+		name, ok := SensitivePorts[port.Port]
+		if !ok {
+			continue
+		}
+
+		if internetFacing {
+			score.Grade = scorecard.GradeCritical
+			score.AddComment(port.Name,
+				fmt.Sprintf("The service exposes the sensitive port %d (%s) to the internet", port.Port, name),
+				fmt.Sprintf("Port %d is commonly used by %s, which should not be reachable from the internet. Remove the port from the Service, or make the load balancer internal.", port.Port, name),
+			)
+			continue
+		}
+
+		if score.Grade > scorecard.GradeWarning {
+			score.Grade = scorecard.GradeWarning
+		}
+		score.AddComment(port.Name,
+			fmt.Sprintf("The service exposes the sensitive port %d (%s) outside of the cluster", port.Port, name),
+			fmt.Sprintf("Port %d is commonly used by %s, which should usually only be reachable from within the cluster. Use a Service of type ClusterIP for this port.", port.Port, name),
+		)
+	}
+
+	return
+}
+
+// serviceHardcodedNodePort checks that no port of a NodePort or LoadBalancer Service has a nodePort set, the nodePort
+// is allocated by the cluster if it's not set
+func serviceHardcodedNodePort(service corev1.Service) (score scorecard.TestScore) {
+	score.Grade = scorecard.GradeAllOK
+
+	if service.Spec.Type != corev1.ServiceTypeLoadBalancer && service.Spec.Type != corev1.ServiceTypeNodePort {
+		return
+	}
+
+	var nodePorts []string
+	for _, port := range service.Spec.Ports {
+		if port.NodePort != 0 {
+			nodePorts = append(nodePorts, fmt.Sprintf("%d", port.NodePort))
+		}
+	}
+	if len(nodePorts) == 0 {
+		return
+	}
+
+	score.Grade = scorecard.GradeWarning
+	score.AddComment("spec.ports",
+		fmt.Sprintf("The service has the hardcoded nodePort %s", strings.Join(nodePorts, ", ")),
+		"A nodePort can only be used by one Service in the cluster, so hardcoded nodePorts collide when the same Service is deployed to multiple namespaces, "+
+			"or with other Services in the cluster. Remove the nodePort and let the cluster allocate it. "+
+			"If the nodePort is required, ignore this check with the kube-score/ignore: service-hardcoded-nodeport annotation.",
+	)
+	return
+}
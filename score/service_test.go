@@ -3,6 +3,10 @@ package score
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/scorecard"
 )
 
@@ -75,3 +79,66 @@ func TestServiceTypeDefault(t *testing.T) {
 	t.Parallel()
 	testExpectedScore(t, "service-type-default.yaml", "Service Type", scorecard.GradeAllOK)
 }
+
+func TestServiceInsecureExposedPort(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("service-insecure-exposed-port.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"service-insecure-exposed-port": {}},
+	}, "Service Insecure Exposed Port", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "redis", comments[0].Path)
+	assert.Equal(t, "The service exposes the sensitive port 6379 (Redis) outside of the cluster", comments[0].Summary)
+}
+
+func TestServiceInsecureExposedPortInternetFacing(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("service-insecure-exposed-port-internet-facing.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"service-insecure-exposed-port": {}},
+	}, "Service Insecure Exposed Port", scorecard.GradeCritical)
+}
+
+func TestServiceInsecureExposedPortClusterIP(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("service-type-clusterip.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"service-insecure-exposed-port": {}},
+	}, "Service Insecure Exposed Port", scorecard.GradeAllOK)
+}
+
+func TestServiceContainerProtocolMismatch(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScore(t, "service-container-protocol-mismatch.yaml", "Service Container Protocol Match", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "dns-udp", comments[0].Path)
+	assert.Equal(t, "The Service port 53 uses UDP, but the targeted container port uses TCP", comments[0].Summary)
+}
+
+func TestServiceContainerProtocolMatch(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "service-container-protocol-match.yaml", "Service Container Protocol Match", scorecard.GradeAllOK)
+}
+
+func TestServiceContainerProtocolMatchExternalName(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "service-externalname.yaml", "Service Container Protocol Match", scorecard.GradeAllOK)
+}
+
+func TestServiceHardcodedNodePort(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("service-hardcoded-nodeport.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"service-hardcoded-nodeport": {}},
+	}, "Service Hardcoded NodePort", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "The service has the hardcoded nodePort 30080, 30443", comments[0].Summary)
+}
+
+func TestServiceHardcodedNodePortNotSet(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, config.Configuration{
+		AllFiles:             []ks.NamedReader{testFile("service-type-nodeport.yaml")},
+		EnabledOptionalTests: map[string]struct{}{"service-hardcoded-nodeport": {}},
+	}, "Service Hardcoded NodePort", scorecard.GradeAllOK)
+}
@@ -0,0 +1,49 @@
+package score
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func testObjectKindKnown(t *testing.T, strict bool) map[string]scorecard.Grade {
+	sc, err := testScore(config.Configuration{
+		AllFiles:           []ks.NamedReader{testFile("object-kind-unknown.yaml")},
+		KubernetesVersion:  config.Semver{1, 18},
+		StrictUnknownKinds: strict,
+	})
+	assert.NoError(t, err)
+
+	grades := make(map[string]scorecard.Grade)
+	for _, o := range sc {
+		for _, c := range o.Checks {
+			if c.Check.ID == "object-kind-known" {
+				grades[o.ObjectMeta.Name] = c.Grade
+				assert.Equal(t, "testdata/object-kind-unknown.yaml", o.FileLocation.Name)
+			}
+		}
+	}
+	return grades
+}
+
+func TestObjectKindKnown(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, map[string]scorecard.Grade{
+		"typo":   scorecard.GradeWarning,
+		"casing": scorecard.GradeWarning,
+		"group":  scorecard.GradeWarning,
+	}, testObjectKindKnown(t, false))
+}
+
+func TestObjectKindKnownStrict(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, map[string]scorecard.Grade{
+		"typo":   scorecard.GradeCritical,
+		"casing": scorecard.GradeCritical,
+		"group":  scorecard.GradeCritical,
+	}, testObjectKindKnown(t, true))
+}
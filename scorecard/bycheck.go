@@ -0,0 +1,59 @@
+package scorecard
+
+import (
+	"sort"
+
+	ks "github.com/zegl/kube-score/domain"
+)
+
+// CheckFinding is an object that has failed a check
+type CheckFinding struct {
+	Object *ScoredObject
+	Score  TestScore
+}
+
+// CheckFindings are all objects that have failed the same check
+type CheckFindings struct {
+	Check    ks.Check
+	Findings []CheckFinding
+}
+
+// ByCheck inverts the scorecard, and returns the checks that at least one object has failed together with the
+// objects that failed them. A check is failed if it's graded worse than GradeAllOK, and skipped checks are never
+// failed. The checks are sorted by the number of objects that failed them, with the most failed check first, and
+// the objects by their key.
+func (s Scorecard) ByCheck() []CheckFindings {
+	var keys []string
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var res []CheckFindings
+	index := make(map[string]int)
+
+	for _, key := range keys {
+		o := s[key]
+		for _, ts := range o.Checks {
+			if ts.Skipped || ts.Grade >= GradeAllOK {
+				continue
+			}
+			i, ok := index[ts.Check.ID]
+			if !ok {
+				i = len(res)
+				index[ts.Check.ID] = i
+				res = append(res, CheckFindings{Check: ts.Check})
+			}
+			res[i].Findings = append(res[i].Findings, CheckFinding{Object: o, Score: ts})
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if len(res[i].Findings) != len(res[j].Findings) {
+			return len(res[i].Findings) > len(res[j].Findings)
+		}
+		return res[i].Check.ID < res[j].Check.ID
+	})
+
+	return res
+}
@@ -0,0 +1,18 @@
+package scorecard
+
+// CommentCode is a stable identifier of the kind of finding that a comment describes, so that the findings can be
+// handled by tools without matching on the summary. Codes are never changed once they have been added.
+type CommentCode string
+
+// Codes of the findings of the security context checks
+const (
+	CodeContainerNoSecurityContext        CommentCode = "CONTAINER_NO_SECURITY_CONTEXT"
+	CodeContainerWritableRootFS           CommentCode = "CONTAINER_WRITABLE_ROOTFS"
+	CodeContainerPrivileged               CommentCode = "CONTAINER_PRIVILEGED"
+	CodeContainerLowUserID                CommentCode = "CONTAINER_LOW_USER_ID"
+	CodeContainerRunAsRoot                CommentCode = "CONTAINER_RUN_AS_ROOT"
+	CodeContainerLowGroupID               CommentCode = "CONTAINER_LOW_GROUP_ID"
+	CodePodNoSeccomp                      CommentCode = "POD_NO_SECCOMP"
+	CodeContainerNoSeccompProfile         CommentCode = "CONTAINER_NO_SECCOMP_PROFILE"
+	CodeContainerUnconfinedSeccompProfile CommentCode = "CONTAINER_UNCONFINED_SECCOMP_PROFILE"
+)
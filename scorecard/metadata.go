@@ -0,0 +1,13 @@
+package scorecard
+
+import (
+	"time"
+)
+
+// Metadata describes the run of kube-score that produced a Scorecard, so that archived results are self-describing
+type Metadata struct {
+	KubeScoreVersion      string    `json:"kube_score_version"`
+	KubernetesVersion     string    `json:"kubernetes_version"`
+	Timestamp             time.Time `json:"timestamp"`
+	EnabledOptionalChecks []string  `json:"enabled_optional_checks"`
+}
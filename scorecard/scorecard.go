@@ -27,7 +27,7 @@ func (s Scorecard) NewObject(typeMeta metav1.TypeMeta, objectMeta metav1.ObjectM
 	}
 
 	// If this object already exists, return the previous version
-	if object, ok := s[o.resourceRefKey()]; ok {
+	if object, ok := s[o.ResourceRefKey()]; ok {
 		return object
 	}
 
@@ -35,7 +35,7 @@ func (s Scorecard) NewObject(typeMeta metav1.TypeMeta, objectMeta metav1.ObjectM
 		o.setIgnoredTests()
 	}
 
-	s[o.resourceRefKey()] = o
+	s[o.ResourceRefKey()] = o
 	return o
 }
 
@@ -48,6 +48,49 @@ func (s Scorecard) AnyBelowOrEqualToGrade(threshold Grade) bool {
 	return false
 }
 
+// AnyBelowGradeForChecks returns true if any of the checks with an ID in checkIDs have a grade lower than threshold
+func (s Scorecard) AnyBelowGradeForChecks(threshold Grade, checkIDs map[string]struct{}) bool {
+	for _, o := range s {
+		for _, c := range o.Checks {
+			if _, ok := checkIDs[c.Check.ID]; ok && !c.Skipped && c.Grade < threshold {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WithMinGrade returns a copy of the scorecard where the objects only contain the checks that are graded as minGrade or
+// worse, see ScoredObject.WithMinGrade. All objects are kept, also if none of their checks are.
+func (s Scorecard) WithMinGrade(minGrade Grade, includeSkipped bool) *Scorecard {
+	res := make(Scorecard, len(s))
+	for key, o := range s {
+		res[key] = o.WithMinGrade(minGrade, includeSkipped)
+	}
+	return &res
+}
+
+// WithDocuments returns a copy of the scorecard that also contains the documents that have not been scored, such as
+// objects of kinds that kube-score has no checks for. The added objects have no checks.
+func (s Scorecard) WithDocuments(documents []ks.Document) *Scorecard {
+	res := make(Scorecard, len(s))
+	for key, o := range s {
+		res[key] = o
+	}
+	for _, document := range documents {
+		o := &ScoredObject{
+			TypeMeta:     document.TypeMeta,
+			ObjectMeta:   document.ObjectMeta,
+			FileLocation: document.Location,
+			Checks:       make([]TestScore, 0),
+		}
+		if _, ok := res[o.ResourceRefKey()]; !ok {
+			res[o.ResourceRefKey()] = o
+		}
+	}
+	return &res
+}
+
 type ScoredObject struct {
 	TypeMeta     metav1.TypeMeta
 	ObjectMeta   metav1.ObjectMeta
@@ -66,6 +109,45 @@ func (s ScoredObject) AnyBelowOrEqualToGrade(threshold Grade) bool {
 	return false
 }
 
+// WorstGrade returns the lowest grade of all checks that were not skipped, or GradeAllOK if all checks were skipped
+func (s ScoredObject) WorstGrade() Grade {
+	grade := GradeAllOK
+	for _, c := range s.Checks {
+		if !c.Skipped && c.Grade < grade {
+			grade = c.Grade
+		}
+	}
+	return grade
+}
+
+// ChecksRun returns the number of checks that were not skipped
+func (s ScoredObject) ChecksRun() int {
+	count := 0
+	for _, c := range s.Checks {
+		if !c.Skipped {
+			count++
+		}
+	}
+	return count
+}
+
+// WithMinGrade returns a copy of the object that only contains the checks that are graded as minGrade or worse.
+// Skipped checks are only kept if includeSkipped is set.
+func (so *ScoredObject) WithMinGrade(minGrade Grade, includeSkipped bool) *ScoredObject {
+	res := *so
+	res.Checks = make([]TestScore, 0, len(so.Checks))
+	for _, c := range so.Checks {
+		if c.Skipped && !includeSkipped {
+			continue
+		}
+		if !c.Skipped && c.Grade > minGrade {
+			continue
+		}
+		res.Checks = append(res.Checks, c)
+	}
+	return &res
+}
+
 func (so *ScoredObject) setIgnoredTests() {
 	ignoredMap := make(map[string]struct{})
 	if ignoredCSV, ok := so.ObjectMeta.Annotations[ignoredChecksAnnotation]; ok {
@@ -76,7 +158,8 @@ func (so *ScoredObject) setIgnoredTests() {
 	so.ignoredChecks = ignoredMap
 }
 
-func (so ScoredObject) resourceRefKey() string {
+// ResourceRefKey returns the key that the object is stored with in the Scorecard
+func (so ScoredObject) ResourceRefKey() string {
 	return so.TypeMeta.Kind + "/" + so.TypeMeta.APIVersion + "/" + so.ObjectMeta.Namespace + "/" + so.ObjectMeta.Name
 }
 
@@ -96,19 +179,46 @@ func (so *ScoredObject) Add(ts TestScore, check ks.Check, locationer ks.FileLoca
 	// This test is ignored (via annotations), don't save the score
 	if _, ok := so.ignoredChecks[check.ID]; ok {
 		ts.Skipped = true
+		ts.SkipReason = SkipReasonIgnoredByAnnotation
 		ts.Comments = []TestScoreComment{{Summary: fmt.Sprintf("Skipped because %s is ignored", check.ID)}}
 	}
 
+	// The check skipped itself, because it does not apply to this object
+	if ts.Skipped && ts.SkipReason == "" {
+		ts.SkipReason = SkipReasonNotApplicable
+	}
+
 	so.Checks = append(so.Checks, ts)
 }
 
 type TestScore struct {
-	Check    ks.Check
-	Grade    Grade
-	Skipped  bool
-	Comments []TestScoreComment
+	Check      ks.Check
+	Grade      Grade
+	Skipped    bool
+	SkipReason SkipReason `json:",omitempty"`
+	Comments   []TestScoreComment
 }
 
+// SkipReason describes why a check was skipped
+type SkipReason string
+
+const (
+	// SkipReasonDisabled is used for optional checks that are not enabled
+	SkipReasonDisabled SkipReason = "disabled"
+
+	// SkipReasonIgnored is used for checks that are ignored for all objects, with --ignore-test
+	SkipReasonIgnored SkipReason = "ignored"
+
+	// SkipReasonIgnoredByAnnotation is used for checks that are ignored for a single object, with the kube-score/ignore annotation
+	SkipReasonIgnoredByAnnotation SkipReason = "ignored-by-annotation"
+
+	// SkipReasonNotSelected is used for checks that are not selected, when only some checks are run with --only
+	SkipReasonNotSelected SkipReason = "not-selected"
+
+	// SkipReasonNotApplicable is used when the check does not apply to the object
+	SkipReasonNotApplicable SkipReason = "not-applicable"
+)
+
 type Grade int
 
 const (
@@ -131,11 +241,28 @@ func (g Grade) String() string {
 	}
 }
 
+// ParseGrade parses a case insensitive grade name, as returned by Grade.String()
+func ParseGrade(s string) (Grade, error) {
+	switch strings.ToUpper(s) {
+	case "CRITICAL":
+		return GradeCritical, nil
+	case "WARNING":
+		return GradeWarning, nil
+	case "OK":
+		return GradeAllOK, nil
+	default:
+		return 0, fmt.Errorf("unknown grade: %s", s)
+	}
+}
+
 type TestScoreComment struct {
 	Path             string
 	Summary          string
 	Description      string
 	DocumentationURL string
+
+	// Code identifies the kind of finding, it's empty for checks that have not been assigned codes yet
+	Code CommentCode `json:",omitempty"`
 }
 
 func (ts *TestScore) AddComment(path, summary, description string) {
@@ -154,3 +281,13 @@ func (ts *TestScore) AddCommentWithURL(path, summary, description, documentation
 		DocumentationURL: documentationURL,
 	})
 }
+
+// AddCommentWithCode adds a comment with a code from the controlled vocabulary of CommentCodes
+func (ts *TestScore) AddCommentWithCode(path, summary, description string, code CommentCode) {
+	ts.Comments = append(ts.Comments, TestScoreComment{
+		Path:        path,
+		Summary:     summary,
+		Description: description,
+		Code:        code,
+	})
+}